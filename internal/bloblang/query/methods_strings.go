@@ -6,6 +6,7 @@ import (
 	"crypto/cipher"
 	"crypto/hmac"
 	"crypto/md5"
+	"crypto/rand"
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
@@ -281,7 +282,7 @@ var _ = registerSimpleMethod(
 		"encrypt_aes", "",
 	).InCategory(
 		MethodCategoryEncoding,
-		"Encrypts a string or byte array target according to a chosen AES encryption method and returns a string result. The algorithms require a key and an initialization vector / nonce. Available schemes are: `ctr`, `ofb`, `cbc`.",
+		"Encrypts a string or byte array target according to a chosen AES encryption method and returns a string result. The algorithms require a key and an initialization vector / nonce. Available schemes are: `ctr`, `ofb`, `cbc`, `gcm`. The `gcm` scheme does not accept an initialization vector, instead a random 12 byte nonce is generated for each call and prepended to the result, followed by the ciphertext and a 16 byte authentication tag.",
 		NewExampleSpec("",
 			`let key = "2b7e151628aed2a6abf7158809cf4f3c".decode("hex")
 let vector = "f0f1f2f3f4f5f6f7f8f9fafbfcfdfeff".decode("hex")
@@ -289,10 +290,18 @@ root.encrypted = this.value.encrypt_aes("ctr", $key, $vector).encode("hex")`,
 			`{"value":"hello world!"}`,
 			`{"encrypted":"84e9b31ff7400bdf80be7254"}`,
 		),
+		NewExampleSpec("The `gcm` scheme generates a random nonce for each message, and therefore the result differs each time even when the plaintext and key are the same.",
+			`let key = "2b7e151628aed2a6abf7158809cf4f3c".decode("hex")
+let encrypted = this.value.encrypt_aes("gcm", $key)
+root.same = $encrypted == this.value.encrypt_aes("gcm", $key)
+root.decrypted = $encrypted.decrypt_aes("gcm", $key).string()`,
+			`{"value":"hello world!"}`,
+			`{"decrypted":"hello world!","same":false}`,
+		),
 	).
-		Param(ParamString("scheme", "The scheme to use for encryption, one of `ctr`, `ofb`, `cbc`.")).
+		Param(ParamString("scheme", "The scheme to use for encryption, one of `ctr`, `ofb`, `cbc`, `gcm`.")).
 		Param(ParamString("key", "A key to encrypt with.")).
-		Param(ParamString("iv", "An initialization vector / nonce.")),
+		Param(ParamString("iv", "An initialization vector, required by all schemes other than `gcm`.").Optional()),
 	func(args *ParsedParams) (simpleMethod, error) {
 		schemeStr, err := args.FieldString("scheme")
 		if err != nil {
@@ -307,12 +316,21 @@ root.encrypted = this.value.encrypt_aes("ctr", $key, $vector).encode("hex")`,
 			return nil, err
 		}
 
-		ivStr, err := args.FieldString("iv")
+		ivStr, err := args.FieldOptionalString("iv")
 		if err != nil {
 			return nil, err
 		}
-		iv := []byte(ivStr)
-		if len(iv) != block.BlockSize() {
+		var iv []byte
+		if ivStr != nil {
+			iv = []byte(*ivStr)
+		}
+		if schemeStr == "gcm" {
+			if ivStr != nil {
+				return nil, errors.New("the gcm scheme generates a nonce for each message and does not accept an initialisation vector")
+			}
+		} else if ivStr == nil {
+			return nil, fmt.Errorf("the %v scheme requires an initialisation vector", schemeStr)
+		} else if len(iv) != block.BlockSize() {
 			return nil, errors.New("the key must match the initialisation vector size")
 		}
 
@@ -343,6 +361,18 @@ root.encrypted = this.value.encrypt_aes("ctr", $key, $vector).encode("hex")`,
 				stream.CryptBlocks(ciphertext, b)
 				return string(ciphertext), nil
 			}
+		case "gcm":
+			aead, err := cipher.NewGCM(block)
+			if err != nil {
+				return nil, err
+			}
+			schemeFn = func(b []byte) (string, error) {
+				nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(b)+aead.Overhead())
+				if _, err := rand.Read(nonce); err != nil {
+					return "", err
+				}
+				return string(aead.Seal(nonce, nonce, b, nil)), nil
+			}
 		default:
 			return nil, fmt.Errorf("unrecognized encryption type: %v", schemeStr)
 		}
@@ -369,7 +399,7 @@ var _ = registerSimpleMethod(
 		"decrypt_aes", "",
 	).InCategory(
 		MethodCategoryEncoding,
-		"Decrypts an encrypted string or byte array target according to a chosen AES encryption method and returns the result as a byte array. The algorithms require a key and an initialization vector / nonce. Available schemes are: `ctr`, `ofb`, `cbc`.",
+		"Decrypts an encrypted string or byte array target according to a chosen AES encryption method and returns the result as a byte array. The algorithms require a key and an initialization vector / nonce. Available schemes are: `ctr`, `ofb`, `cbc`, `gcm`. The `gcm` scheme does not accept an initialization vector, and instead expects the 12 byte nonce to be prepended to the ciphertext and the 16 byte authentication tag to be appended, as produced by `encrypt_aes`. An error is returned when the ciphertext fails authentication.",
 		NewExampleSpec("",
 			`let key = "2b7e151628aed2a6abf7158809cf4f3c".decode("hex")
 let vector = "f0f1f2f3f4f5f6f7f8f9fafbfcfdfeff".decode("hex")
@@ -378,9 +408,9 @@ root.decrypted = this.value.decode("hex").decrypt_aes("ctr", $key, $vector).stri
 			`{"decrypted":"hello world!"}`,
 		),
 	).
-		Param(ParamString("scheme", "The scheme to use for decryption, one of `ctr`, `ofb`, `cbc`.")).
+		Param(ParamString("scheme", "The scheme to use for decryption, one of `ctr`, `ofb`, `cbc`, `gcm`.")).
 		Param(ParamString("key", "A key to decrypt with.")).
-		Param(ParamString("iv", "An initialization vector / nonce.")),
+		Param(ParamString("iv", "An initialization vector, required by all schemes other than `gcm`.").Optional()),
 	func(args *ParsedParams) (simpleMethod, error) {
 		schemeStr, err := args.FieldString("scheme")
 		if err != nil {
@@ -396,12 +426,21 @@ root.decrypted = this.value.decode("hex").decrypt_aes("ctr", $key, $vector).stri
 			return nil, err
 		}
 
-		ivStr, err := args.FieldString("iv")
+		ivStr, err := args.FieldOptionalString("iv")
 		if err != nil {
 			return nil, err
 		}
-		iv := []byte(ivStr)
-		if len(iv) != block.BlockSize() {
+		var iv []byte
+		if ivStr != nil {
+			iv = []byte(*ivStr)
+		}
+		if schemeStr == "gcm" {
+			if ivStr != nil {
+				return nil, errors.New("the gcm scheme generates a nonce for each message and does not accept an initialisation vector")
+			}
+		} else if ivStr == nil {
+			return nil, fmt.Errorf("the %v scheme requires an initialisation vector", schemeStr)
+		} else if len(iv) != block.BlockSize() {
 			return nil, errors.New("the key must match the initialisation vector size")
 		}
 
@@ -430,6 +469,17 @@ root.decrypted = this.value.decode("hex").decrypt_aes("ctr", $key, $vector).stri
 				stream.CryptBlocks(b, b)
 				return b, nil
 			}
+		case "gcm":
+			aead, err := cipher.NewGCM(block)
+			if err != nil {
+				return nil, err
+			}
+			schemeFn = func(b []byte) ([]byte, error) {
+				if len(b) < aead.NonceSize()+aead.Overhead() {
+					return nil, errors.New("ciphertext is too short to contain a nonce and authentication tag")
+				}
+				return aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], nil)
+			}
 		default:
 			return nil, fmt.Errorf("unrecognized decryption type: %v", schemeStr)
 		}
@@ -1804,6 +1804,73 @@ func TestMethods(t *testing.T) {
 			),
 			err: `method decode: ciphertext is not a multiple of the block size`,
 		},
+		"check aes-gcm round trip": {
+			input: methods(
+				literalFn("00000000000000000000000000000000"),
+				method("decode", "hex"),
+				method(
+					"encrypt_aes", "gcm",
+					methods(
+						literalFn("00000000000000000000000000000000"),
+						method("decode", "hex"),
+					),
+				),
+				method(
+					"decrypt_aes", "gcm",
+					methods(
+						literalFn("00000000000000000000000000000000"),
+						method("decode", "hex"),
+					),
+				),
+				method("encode", "hex"),
+			),
+			output: `00000000000000000000000000000000`,
+		},
+		"check aes-gcm decryption": {
+			input: methods(
+				literalFn("0000000000000000000000000388dace60b6a392f328c2b971b2fe78ab6e47d42cec13bdf53a67b21257bddf"),
+				method("decode", "hex"),
+				method(
+					"decrypt_aes", "gcm",
+					methods(
+						literalFn("00000000000000000000000000000000"),
+						method("decode", "hex"),
+					),
+				),
+				method("encode", "hex"),
+			),
+			output: `00000000000000000000000000000000`,
+		},
+		"check aes-gcm decryption auth failure": {
+			input: methods(
+				literalFn("0000000000000000000000000388dace60b6a392f328c2b971b2fe78ab6e47d42cec13bdf53a67b21257bdde"),
+				method("decode", "hex"),
+				method(
+					"decrypt_aes", "gcm",
+					methods(
+						literalFn("00000000000000000000000000000000"),
+						method("decode", "hex"),
+					),
+				),
+				method("encode", "hex"),
+			),
+			err: `method decode: cipher: message authentication failed`,
+		},
+		"check aes-gcm decryption too short": {
+			input: methods(
+				literalFn("000000000000000000000000"),
+				method("decode", "hex"),
+				method(
+					"decrypt_aes", "gcm",
+					methods(
+						literalFn("00000000000000000000000000000000"),
+						method("decode", "hex"),
+					),
+				),
+				method("encode", "hex"),
+			),
+			err: `method decode: ciphertext is too short to contain a nonce and authentication tag`,
+		},
 		"check any no array": {
 			input: methods(
 				literalFn("foo"),
@@ -2026,6 +2093,33 @@ func TestMethods(t *testing.T) {
 	}
 }
 
+func TestMethodsEncryptAESGCMNonce(t *testing.T) {
+	key := NewLiteralFunction("", []byte("0123456789abcdef"))
+
+	enc, err := InitMethodHelper("encrypt_aes", NewLiteralFunction("", "hello world"), "gcm", key)
+	require.NoError(t, err)
+
+	var ciphertexts []string
+	for i := 0; i < 2; i++ {
+		res, err := enc.Exec(FunctionContext{})
+		require.NoError(t, err)
+
+		dec, err := InitMethodHelper("decrypt_aes", NewLiteralFunction("", res), "gcm", key)
+		require.NoError(t, err)
+
+		plaintext, err := dec.Exec(FunctionContext{})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("hello world"), plaintext)
+
+		ciphertexts = append(ciphertexts, res.(string))
+	}
+	assert.NotEqual(t, ciphertexts[0], ciphertexts[1])
+
+	_, err = InitMethodHelper("encrypt_aes", NewLiteralFunction("", "hello world"), "gcm", key, NewLiteralFunction("", []byte("000000000000")))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not accept an initialisation vector")
+}
+
 func TestMethodTargets(t *testing.T) {
 	function := func(name string, args ...any) Function {
 		t.Helper()
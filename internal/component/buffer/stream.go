@@ -30,6 +30,10 @@ type ReaderWriter interface {
 	// the message is preserved until the returned AckFunc is called. Some
 	// temporal buffer implementations such as windowers will ignore the ack
 	// func.
+	//
+	// The AckFunc is called with the error returned by the output, where a
+	// non-nil error indicates that the batch was rejected and should be
+	// retained for redelivery by buffers that preserve delivery guarantees.
 	Read(context.Context) (message.Batch, AckFunc, error)
 
 	// Write a new message batch to the stack.
@@ -98,6 +102,7 @@ func (m *Stream) inputLoop() {
 	var (
 		mReceivedCount      = m.stats.GetCounter("buffer_received")
 		mReceivedBatchCount = m.stats.GetCounter("buffer_batch_received")
+		mWriteBlocked       = m.stats.GetCounter("buffer_write_blocked")
 	)
 
 	closeAtLeisureCtx, doneLeisure := m.shutSig.SoftStopCtx(context.Background())
@@ -131,7 +136,11 @@ func (m *Stream) inputLoop() {
 		batchLen := tr.Payload.Len()
 
 		writeBatch, _ := tracing.WithSiblingSpans(m.tracer, m.typeStr, tr.Payload)
+		writeStarted := time.Now()
 		err := m.buffer.Write(closeAtLeisureCtx, writeBatch, ackFunc)
+		if time.Since(writeStarted) > component.BlockedWriteThreshold {
+			mWriteBlocked.Incr(1)
+		}
 		if err == nil {
 			mReceivedCount.Incr(int64(batchLen))
 			mReceivedBatchCount.Incr(1)
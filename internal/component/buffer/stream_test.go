@@ -8,8 +8,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
+	"github.com/benthosdev/benthos/v4/internal/log"
 	"github.com/benthosdev/benthos/v4/internal/message"
 )
 
@@ -146,6 +150,150 @@ func TestStreamMemoryBuffer(t *testing.T) {
 	close(tChan)
 }
 
+type localObs struct {
+	stats *metrics.Local
+}
+
+func (l localObs) Metrics() metrics.Type {
+	return l.stats
+}
+
+func (l localObs) Logger() log.Modular {
+	return log.Noop()
+}
+
+func (l localObs) Tracer() trace.TracerProvider {
+	return noop.NewTracerProvider()
+}
+
+func TestStreamBufferWriteBlocked(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*5)
+	defer done()
+
+	tChan := make(chan message.Transaction)
+	resChan := make(chan error, 3)
+
+	stats := metrics.NewLocal()
+
+	b := NewStream("meow", newMemoryBuffer(1), localObs{stats: stats})
+	require.NoError(t, b.Consume(tChan))
+
+	// The first message is read into the output loop and the second fills the
+	// buffer, leaving the third blocked.
+	for i := 0; i < 3; i++ {
+		select {
+		case tChan <- message.NewTransaction(message.QuickBatch([][]byte{{byte(i)}}), resChan):
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for message %v send", i)
+		}
+	}
+
+	<-time.After(component.BlockedWriteThreshold + (time.Millisecond * 50))
+	assert.Equal(t, int64(0), stats.GetCounters()["buffer_write_blocked"])
+
+	for i := 0; i < 3; i++ {
+		select {
+		case outTr := <-b.TransactionChan():
+			assert.Equal(t, byte(i), outTr.Payload.Get(0).AsBytes()[0])
+			require.NoError(t, outTr.Ack(tCtx, nil))
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for message %v read", i)
+		}
+	}
+
+	assert.Eventually(t, func() bool {
+		return stats.GetCounters()["buffer_write_blocked"] == 1
+	}, time.Second, time.Millisecond*10)
+
+	b.TriggerCloseNow()
+	require.NoError(t, b.WaitForClose(tCtx))
+}
+
+type deferredAckBuffer struct {
+	pending chan deferredAckBatch
+	eoi     chan struct{}
+}
+
+type deferredAckBatch struct {
+	b   message.Batch
+	aFn AckFunc
+}
+
+func (d *deferredAckBuffer) Read(ctx context.Context) (message.Batch, AckFunc, error) {
+	select {
+	case p := <-d.pending:
+		return p.b, p.aFn, nil
+	case <-d.eoi:
+		return nil, nil, component.ErrTypeClosed
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
+
+func (d *deferredAckBuffer) Write(ctx context.Context, b message.Batch, aFn AckFunc) error {
+	select {
+	case d.pending <- deferredAckBatch{b: b, aFn: aFn}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (d *deferredAckBuffer) EndOfInput() {}
+
+func (d *deferredAckBuffer) Close(ctx context.Context) error {
+	return nil
+}
+
+func TestStreamBufferPropagatesRejections(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*5)
+	defer done()
+
+	tChan := make(chan message.Transaction)
+	resChan := make(chan error)
+
+	b := NewStream("meow", &deferredAckBuffer{
+		pending: make(chan deferredAckBatch, 1),
+		eoi:     make(chan struct{}),
+	}, component.NoopObservability())
+	require.NoError(t, b.Consume(tChan))
+
+	for _, ackErr := range []error{errors.New("nope"), nil} {
+		select {
+		case tChan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte("hello")}), resChan):
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for message send")
+		}
+
+		var outTr message.Transaction
+		select {
+		case outTr = <-b.TransactionChan():
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for message read")
+		}
+
+		// Simulate a slow consumer, the input must not receive a response in
+		// the meantime.
+		select {
+		case res := <-resChan:
+			t.Fatalf("Unexpected response before delivery: %v", res)
+		case <-time.After(time.Millisecond * 50):
+		}
+
+		require.NoError(t, outTr.Ack(tCtx, ackErr))
+
+		select {
+		case res := <-resChan:
+			assert.Equal(t, ackErr, res)
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for response")
+		}
+	}
+
+	b.TriggerCloseNow()
+	require.NoError(t, b.WaitForClose(tCtx))
+}
+
 func TestStreamBufferClosing(t *testing.T) {
 	tCtx, done := context.WithTimeout(context.Background(), time.Second*5)
 	defer done()
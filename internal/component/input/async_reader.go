@@ -85,6 +85,7 @@ func (r *AsyncReader) loop() {
 		mFailedConn = r.mgr.Metrics().GetCounter("input_connection_failed")
 		mLostConn   = r.mgr.Metrics().GetCounter("input_connection_lost")
 		mLatency    = r.mgr.Metrics().GetTimer("input_latency_ns")
+		mBlocked    = r.mgr.Metrics().GetCounter("input_write_blocked")
 
 		traceName = "input_" + r.typeStr
 	)
@@ -206,6 +207,9 @@ func (r *AsyncReader) loop() {
 		case <-r.shutSig.SoftStopChan():
 			return
 		}
+		if time.Since(startedAt) > component.BlockedWriteThreshold {
+			mBlocked.Incr(1)
+		}
 
 		pendingAcks.Add(1)
 		go func(
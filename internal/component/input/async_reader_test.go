@@ -15,6 +15,7 @@ import (
 
 	"github.com/benthosdev/benthos/v4/internal/component"
 	"github.com/benthosdev/benthos/v4/internal/component/input"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
 )
@@ -469,6 +470,60 @@ func TestAsyncReaderHappyPath(t *testing.T) {
 	}
 }
 
+func TestAsyncReaderWriteBlocked(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*5)
+	defer done()
+
+	readerImpl := newMockAsyncReader()
+
+	mgr := mock.NewManager()
+	stats := metrics.NewLocal()
+	mgr.M = stats
+
+	r, err := input.NewAsyncReader("foo", readerImpl, mgr)
+	require.NoError(t, err)
+
+	select {
+	case readerImpl.connChan <- nil:
+	case <-time.After(time.Second):
+		t.Fatal("Timed out")
+	}
+
+	select {
+	case readerImpl.readChan <- nil:
+	case <-time.After(time.Second):
+		t.Fatal("Timed out")
+	}
+
+	// Leave the batch unconsumed for longer than the blocked threshold.
+	<-time.After(time.Millisecond * 150)
+
+	var ts message.Transaction
+	select {
+	case ts = <-r.TransactionChan():
+	case <-time.After(time.Second):
+		t.Fatal("Timed out")
+	}
+
+	assert.Eventually(t, func() bool {
+		return stats.GetCounters()["input_write_blocked"] == 1
+	}, time.Second, time.Millisecond*10)
+
+	go func() {
+		select {
+		case readerImpl.ackChan <- nil:
+		case <-time.After(time.Second):
+		}
+	}()
+	require.NoError(t, ts.Ack(tCtx, nil))
+
+	r.TriggerStopConsuming()
+	close(readerImpl.readChan)
+	close(readerImpl.connChan)
+
+	require.NoError(t, r.WaitForClose(tCtx))
+}
+
 func TestAsyncReaderCloseWithPendingAcks(t *testing.T) {
 	tCtx, done := context.WithTimeout(context.Background(), time.Second*5)
 	defer done()
@@ -1,6 +1,9 @@
 package metrics
 
-import "net/http"
+import (
+	"errors"
+	"net/http"
+)
 
 type combinedWrapper struct {
 	t1 Type
@@ -191,7 +194,5 @@ func (c *combinedWrapper) HandlerFunc() http.HandlerFunc {
 }
 
 func (c *combinedWrapper) Close() error {
-	c.t1.Close()
-	c.t2.Close()
-	return nil
+	return errors.Join(c.t1.Close(), c.t2.Close())
 }
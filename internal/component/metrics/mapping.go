@@ -85,7 +85,7 @@ func (m *Mapping) mapPath(path string, labelNames, labelValues []string) (outPat
 		Value: &v,
 	}); err != nil {
 		m.logger.Error("Failed to apply path mapping on '%v': %v\n", path, err)
-		return path, nil, nil
+		return path, labelNames, labelValues
 	}
 
 	_ = outPart.MetaIterStr(func(k, v string) error {
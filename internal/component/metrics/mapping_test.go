@@ -58,7 +58,17 @@ func TestPathMapping(t *testing.T) {
 		{
 			name:    "throw an error",
 			mapping: `root = throw("nope")`,
-			cases:   []testCase{{input: "foo", output: "foo"}},
+			cases: []testCase{
+				{input: "foo", output: "foo"},
+				{
+					input:    "foo",
+					inLabels: []string{"label", "path"},
+					inValues: []string{"bar", "root.output"},
+					output:   "foo",
+					labels:   []string{"label", "path"},
+					values:   []string{"bar", "root.output"},
+				},
+			},
 		},
 		{
 			name: "set a static label",
@@ -101,6 +111,38 @@ func TestPathMapping(t *testing.T) {
 				{input: "hello world", output: "hello world"},
 			},
 		},
+		{
+			name:    "rename with capture groups",
+			mapping: `root = this.re_replace_all("^output_(.+)$", "sink_$1")`,
+			cases: []testCase{
+				{input: "output_sent", output: "sink_sent"},
+				{input: "input_received", output: "input_received"},
+			},
+		},
+		{
+			name: "extract a path segment into a label",
+			mapping: `let index = meta("path").or("").re_find_object("outputs\\.(?P<index>[0-9]+)").index
+			meta output_index = if $index != null { $index }
+			meta path = meta("path").or("").re_replace_all("\\.outputs\\.[0-9]+", ".outputs")`,
+			cases: []testCase{
+				{
+					input:    "output_sent",
+					inLabels: []string{"path"},
+					inValues: []string{"root.output.broker.outputs.2"},
+					output:   "output_sent",
+					labels:   []string{"output_index", "path"},
+					values:   []string{"2", "root.output.broker.outputs"},
+				},
+				{
+					input:    "input_received",
+					inLabels: []string{"path"},
+					inValues: []string{"root.input"},
+					output:   "input_received",
+					labels:   []string{"path"},
+					values:   []string{"root.input"},
+				},
+			},
+		},
 		{
 			name:    "empty mapping",
 			mapping: ``,
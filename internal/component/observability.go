@@ -1,6 +1,8 @@
 package component
 
 import (
+	"time"
+
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
 
@@ -17,6 +19,11 @@ type Observability interface {
 	Tracer() trace.TracerProvider
 }
 
+// BlockedWriteThreshold is the duration after which handing data to the next
+// layer of a stream, such as a write to a buffer, is considered to have been
+// blocked by back pressure.
+const BlockedWriteThreshold = time.Millisecond * 100
+
 type mockObs struct{}
 
 func (m mockObs) Metrics() metrics.Type {
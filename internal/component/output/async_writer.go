@@ -93,6 +93,7 @@ func (w *AsyncWriter) loop() {
 		mConn       = w.stats.GetCounter("output_connection_up")
 		mFailedConn = w.stats.GetCounter("output_connection_failed")
 		mLostConn   = w.stats.GetCounter("output_connection_lost")
+		mInFlight   = w.stats.GetGauge("output_in_flight")
 
 		traceName = "output_" + w.typeStr
 	)
@@ -199,6 +200,7 @@ func (w *AsyncWriter) loop() {
 				return
 			}
 
+			mInFlight.Incr(1)
 			w.log.Trace("Attempting to write %v messages to '%v'.\n", ts.Payload.Len(), w.typeStr)
 			_, spans := tracing.WithChildSpans(w.tracer, traceName, ts.Payload)
 
@@ -213,6 +215,7 @@ func (w *AsyncWriter) loop() {
 
 			// Close immediately if our writer is closed.
 			if errors.Is(err, component.ErrTypeClosed) {
+				mInFlight.Decr(1)
 				return
 			}
 
@@ -236,6 +239,7 @@ func (w *AsyncWriter) loop() {
 			}
 
 			_ = ts.Ack(closeLeisureCtx, err)
+			mInFlight.Decr(1)
 		}
 	}
 
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/message"
 )
 
@@ -569,6 +570,74 @@ func TestAsyncWriterHappyPath(t *testing.T) {
 	}
 }
 
+type obsWithMetrics struct {
+	component.Observability
+	stats metrics.Type
+}
+
+func (o obsWithMetrics) Metrics() metrics.Type {
+	return o.stats
+}
+
+func TestAsyncWriterInFlightMetric(t *testing.T) {
+	t.Parallel()
+
+	writerImpl := newAsyncMockWriter()
+	stats := metrics.NewLocal()
+
+	w, err := NewAsyncWriter("foo", 2, writerImpl, obsWithMetrics{
+		Observability: component.NoopObservability(),
+		stats:         stats,
+	})
+	require.NoError(t, err)
+
+	msgChan := make(chan message.Transaction)
+	resChan := make(chan error)
+	require.NoError(t, w.Consume(msgChan))
+
+	select {
+	case writerImpl.connChan <- nil:
+	case <-time.After(time.Second):
+		t.Fatal("Timed out")
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case msgChan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte("foo")}), resChan):
+		case <-time.After(time.Second):
+			t.Fatal("Timed out")
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		return stats.GetCounters()["output_in_flight"] == 2
+	}, time.Second, time.Millisecond*5)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case writerImpl.writeChan <- nil:
+		case <-time.After(time.Second):
+			t.Fatal("Timed out")
+		}
+		select {
+		case res := <-resChan:
+			require.NoError(t, res)
+		case <-time.After(time.Second):
+			t.Fatal("Timed out")
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		return stats.GetCounters()["output_in_flight"] == 0
+	}, time.Second, time.Millisecond*5)
+
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	w.TriggerCloseNow()
+	require.NoError(t, w.WaitForClose(ctx))
+}
+
 func TestAsyncWriterSadPath(t *testing.T) {
 	t.Parallel()
 
@@ -68,8 +68,13 @@ func (m *Impl) loop() {
 	defer func() {
 		close(m.messagesOut)
 
-		m.child.TriggerCloseNow()
-		_ = m.child.WaitForClose(context.Background())
+		// Unless we're being forced to stop give the child a chance to finish
+		// writing batches that it has already been sent, including the final
+		// partial batch flushed when our input closes.
+		if err := m.child.WaitForClose(closeNowCtx); err != nil {
+			m.child.TriggerCloseNow()
+			_ = m.child.WaitForClose(context.Background())
+		}
 
 		_ = m.batcher.Close(context.Background())
 
@@ -15,6 +15,7 @@ import (
 	batchInternal "github.com/benthosdev/benthos/v4/internal/batch"
 	"github.com/benthosdev/benthos/v4/internal/batch/policy"
 	"github.com/benthosdev/benthos/v4/internal/batch/policy/batchconfig"
+	"github.com/benthosdev/benthos/v4/internal/component/output"
 	"github.com/benthosdev/benthos/v4/internal/component/output/batcher"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -399,3 +400,72 @@ func TestBatcherTimed(t *testing.T) {
 
 	close(resChan)
 }
+
+type slowAsyncSink struct {
+	mut     sync.Mutex
+	written [][]byte
+}
+
+func (s *slowAsyncSink) Connect(ctx context.Context) error {
+	return nil
+}
+
+func (s *slowAsyncSink) WriteBatch(ctx context.Context, msg message.Batch) error {
+	select {
+	case <-time.After(time.Millisecond * 50):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	s.mut.Lock()
+	s.written = append(s.written, message.GetAllBytes(msg)...)
+	s.mut.Unlock()
+	return nil
+}
+
+func (s *slowAsyncSink) Close(ctx context.Context) error {
+	return nil
+}
+
+func TestBatcherFlushesOnInputClose(t *testing.T) {
+	tInChan := make(chan message.Transaction)
+	resChan := make(chan error, 3)
+
+	policyConf := batchconfig.NewConfig()
+	policyConf.Count = 10
+	batchPol, err := policy.New(policyConf, mock.NewManager())
+	require.NoError(t, err)
+
+	sink := &slowAsyncSink{}
+	out, err := output.NewAsyncWriter("foo", 1, sink, mock.NewManager())
+	require.NoError(t, err)
+
+	b := batcher.New(batchPol, out, mock.NewManager())
+	require.NoError(t, b.Consume(tInChan))
+
+	exp := [][]byte{[]byte("foo"), []byte("bar"), []byte("baz")}
+	for _, p := range exp {
+		select {
+		case tInChan <- message.NewTransaction(message.QuickBatch([][]byte{p}), resChan):
+		case <-time.After(time.Second):
+			t.Fatal("timed out")
+		}
+	}
+	close(tInChan)
+
+	for range exp {
+		select {
+		case res := <-resChan:
+			require.NoError(t, res)
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out")
+		}
+	}
+
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+	require.NoError(t, b.WaitForClose(ctx))
+
+	sink.mut.Lock()
+	assert.Equal(t, exp, sink.written)
+	sink.mut.Unlock()
+}
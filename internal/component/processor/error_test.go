@@ -0,0 +1,41 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/benthosdev/benthos/v4/internal/message"
+	"github.com/benthosdev/benthos/v4/internal/tracing"
+)
+
+func TestMarkErrSpanStatus(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() {
+		_ = tp.Shutdown(context.Background())
+	})
+
+	part, span := tracing.WithChildSpan(tp, "foo", message.NewPart([]byte("hello")))
+	MarkErr(part, span, errors.New("nope"))
+	span.Finish()
+
+	assert.EqualError(t, part.ErrorGet(), "nope")
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "nope", spans[0].Status.Description)
+
+	var eventNames []string
+	for _, e := range spans[0].Events {
+		eventNames = append(eventNames, e.Name)
+	}
+	assert.Contains(t, eventNames, "exception")
+}
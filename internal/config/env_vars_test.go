@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestEnvSwapping(t *testing.T) {
@@ -50,6 +51,7 @@ func TestEnvSwapping(t *testing.T) {
 		"foo ${BENTHOS_TEST_THIS_DOESNT_EXIST_LOL} baz":                            {errContains: "required environment variables were not set: [BENTHOS_TEST_THIS_DOESNT_EXIST_LOL]"},
 		"foo ${BENTHOS_TEST_NOPE_A} baz ${BENTHOS_TEST_NOPE_B} buz":                {errContains: "required environment variables were not set: [BENTHOS_TEST_NOPE_A BENTHOS_TEST_NOPE_B]"},
 		"foo ${DOES_NOT_EXIST::} baz":                                              {result: "foo : baz"},
+		"foo ${BENTHOS_TEST_NOPE_A} baz ${BENTHOS_TEST_NOPE_A} buz":                {errContains: "required environment variables were not set: [BENTHOS_TEST_NOPE_A]"},
 	}
 
 	for in, exp := range tests {
@@ -63,3 +65,48 @@ func TestEnvSwapping(t *testing.T) {
 		}
 	}
 }
+
+func TestEnvSwappingYAMLQuoting(t *testing.T) {
+	envFn := func(s string) (string, bool) {
+		switch s {
+		case "BENTHOS_TEST_FOO":
+			return "foo bar", true
+		case "BENTHOS_TEST_URL":
+			return "tcp://localhost:9092", true
+		}
+		return "", false
+	}
+
+	tests := []struct {
+		name   string
+		input  string
+		result string
+	}{
+		{name: "plain", input: `a: ${BENTHOS_TEST_FOO}`, result: "foo bar"},
+		{name: "single quoted", input: `a: '${BENTHOS_TEST_FOO}'`, result: "foo bar"},
+		{name: "double quoted", input: `a: "${BENTHOS_TEST_FOO}"`, result: "foo bar"},
+		{name: "block literal", input: "a: |\n  ${BENTHOS_TEST_FOO}\n", result: "foo bar\n"},
+		{name: "block folded", input: "a: >-\n  ${BENTHOS_TEST_FOO}", result: "foo bar"},
+		{name: "plain with colon", input: `a: ${BENTHOS_TEST_URL}`, result: "tcp://localhost:9092"},
+		{name: "double quoted default", input: `a: "${BENTHOS_TEST_NOPE:baz buz}"`, result: "baz buz"},
+		{name: "single quoted default", input: `a: '${BENTHOS_TEST_NOPE:http://foo}'`, result: "http://foo"},
+		{name: "plain escaped", input: `a: ${{BENTHOS_TEST_FOO}}`, result: "${BENTHOS_TEST_FOO}"},
+		{name: "double quoted escaped", input: `a: "${{BENTHOS_TEST_FOO}}"`, result: "${BENTHOS_TEST_FOO}"},
+		{name: "with function interpolation", input: `a: '${BENTHOS_TEST_FOO}-${! count("c") }'`, result: `foo bar-${! count("c") }`},
+		{name: "default with function interpolation", input: `a: "${BENTHOS_TEST_NOPE:${! meta(\"foo\") }}"`, result: `${! meta("foo") }`},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			out, err := ReplaceEnvVariables([]byte(test.input), envFn)
+			require.NoError(t, err)
+
+			var v struct {
+				A string `yaml:"a"`
+			}
+			require.NoError(t, yaml.Unmarshal(out, &v))
+			assert.Equal(t, test.result, v.A)
+		})
+	}
+}
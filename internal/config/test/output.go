@@ -9,9 +9,11 @@ import (
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/nsf/jsondiff"
+	"github.com/pmezard/go-difflib/difflib"
 
 	"github.com/benthosdev/benthos/v4/internal/bloblang"
 	"github.com/benthosdev/benthos/v4/internal/bloblang/mapping"
@@ -211,11 +213,42 @@ type ContentEqualsCondition string
 
 func (c ContentEqualsCondition) Check(fs fs.FS, dir string, p *message.Part) error {
 	if exp, act := string(c), string(p.AsBytes()); exp != act {
+		if strings.Contains(exp, "\n") || strings.Contains(act, "\n") {
+			return fmt.Errorf("content mismatch\n%v", contentDiff(exp, act))
+		}
 		return fmt.Errorf("content mismatch\n  expected: %v\n  received: %v", blue(exp), red(act))
 	}
 	return nil
 }
 
+// contentDiff returns a line by line diff of multiple line contents, where
+// lines that were expected are prefixed with - and lines received are prefixed
+// with +.
+func contentDiff(exp, act string) string {
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(exp),
+		B:        difflib.SplitLines(act),
+		FromFile: "expected",
+		ToFile:   "received",
+		Context:  2,
+	})
+
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		if line == "" {
+			continue
+		}
+		switch line[0] {
+		case '-':
+			line = blue(line)
+		case '+':
+			line = red(line)
+		}
+		b.WriteString("  " + line)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
 type ContentMatchesCondition string
 
 func (c ContentMatchesCondition) Check(fs fs.FS, dir string, p *message.Part) error {
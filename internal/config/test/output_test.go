@@ -170,6 +170,26 @@ func TestContentCondition(t *testing.T) {
 	}
 }
 
+func TestContentConditionMultipleLines(t *testing.T) {
+	color.NoColor = true
+
+	cond := ContentEqualsCondition("foo\nbar\nbaz\nbuz\n")
+
+	require.NoError(t, cond.Check(ifs.OS(), "", message.NewPart([]byte("foo\nbar\nbaz\nbuz\n"))))
+
+	err := cond.Check(ifs.OS(), "", message.NewPart([]byte("foo\nnope\nbaz\nbuz\n")))
+	require.Error(t, err)
+	assert.Equal(t, `content mismatch
+  --- expected
+  +++ received
+  @@ -1,4 +1,4 @@
+   foo
+  -bar
+  +nope
+   baz
+   buz`, err.Error())
+}
+
 func TestContentMatchesCondition(t *testing.T) {
 	color.NoColor = true
 
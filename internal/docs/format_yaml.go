@@ -644,10 +644,11 @@ func (f FieldSpec) LintYAML(ctx LintContext, node *yaml.Node) []Lint {
 
 	// Otherwise we're a leaf node, so do basic type checking
 	switch f.Type {
-	// TODO: Do proper checking for bool and number types.
 	case FieldTypeBool, FieldTypeString, FieldTypeInt, FieldTypeFloat:
 		if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
 			lints = append(lints, NewLintError(node.Line, LintExpectedScalar, fmt.Errorf("expected %v value", f.Type)))
+		} else if node.Kind == yaml.ScalarNode && !scalarDecodesAs(f.Type, node) {
+			lints = append(lints, NewLintError(node.Line, LintExpectedScalar, fmt.Errorf("expected %v value, got %v", f.Type, node.ShortTag())))
 		}
 	case FieldTypeObject:
 		if node.Kind != yaml.MappingNode && node.Kind != yaml.AliasNode {
@@ -657,6 +658,31 @@ func (f FieldSpec) LintYAML(ctx LintContext, node *yaml.Node) []Lint {
 	return lints
 }
 
+// scalarDecodesAs returns whether a scalar node can be decoded into the
+// provided field type, matching the strictness of the config parser.
+func scalarDecodesAs(t FieldType, node *yaml.Node) bool {
+	var err error
+	switch t {
+	case FieldTypeBool:
+		var b bool
+		err = node.Decode(&b)
+	case FieldTypeInt:
+		var i int
+		if err = node.Decode(&i); err == nil && node.ShortTag() == "!!float" {
+			// Floats are silently truncated when decoded into an int, which
+			// changes the value, so only whole numbers are accepted.
+			var f float64
+			if err = node.Decode(&f); err == nil && f != float64(i) {
+				return false
+			}
+		}
+	case FieldTypeFloat:
+		var f float64
+		err = node.Decode(&f)
+	}
+	return err == nil
+}
+
 // LintYAML walks a yaml node and returns a list of linting errors found.
 func (f FieldSpecs) LintYAML(ctx LintContext, node *yaml.Node) []Lint {
 	node = unwrapDocumentNode(node)
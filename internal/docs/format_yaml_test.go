@@ -694,6 +694,8 @@ func TestYAMLComponentLinting(t *testing.T) {
 				docs.FieldObject("foo8", "").Map().WithChildren(
 					docs.FieldInt("foochild1", "").Optional(),
 				).Optional().Advanced(),
+				docs.FieldBool("foo9", "").Optional().Advanced(),
+				docs.FieldFloat("foo10", "").Optional().Advanced(),
 			),
 		})
 		prov.RegisterDocs(docs.ComponentSpec{
@@ -1003,6 +1005,62 @@ testlintfooinput:
 				docs.NewLintError(4, docs.LintExpectedObject, errors.New("expected object value, got !!seq")),
 			},
 		},
+		{
+			name:      "map field wrong scalar type",
+			inputType: docs.TypeInput,
+			inputConf: `
+testlintfooinput:
+  foo8:
+    key1:
+      foochild1: nope
+    key2:
+      foochild1: "10"
+    key3:
+      foochild1: null`,
+			res: []docs.Lint{
+				docs.NewLintError(5, docs.LintExpectedScalar, errors.New("expected int value, got !!str")),
+				docs.NewLintError(7, docs.LintExpectedScalar, errors.New("expected int value, got !!str")),
+			},
+		},
+		{
+			name:      "int as bool field",
+			inputType: docs.TypeInput,
+			inputConf: `
+testlintfooinput:
+  foo9: 1`,
+			res: []docs.Lint{
+				docs.NewLintError(3, docs.LintExpectedScalar, errors.New("expected bool value, got !!int")),
+			},
+		},
+		{
+			name:      "string as bool field",
+			inputType: docs.TypeInput,
+			inputConf: `
+testlintfooinput:
+  foo9: nope`,
+			res: []docs.Lint{
+				docs.NewLintError(3, docs.LintExpectedScalar, errors.New("expected bool value, got !!str")),
+			},
+		},
+		{
+			name:      "float as int field",
+			inputType: docs.TypeInput,
+			inputConf: `
+testlintfooinput:
+  foo8:
+    key1:
+      foochild1: 10.5`,
+			res: []docs.Lint{
+				docs.NewLintError(5, docs.LintExpectedScalar, errors.New("expected int value, got !!float")),
+			},
+		},
+		{
+			name:      "int as float field",
+			inputType: docs.TypeInput,
+			inputConf: `
+testlintfooinput:
+  foo10: 5`,
+		},
 		{
 			name:      "custom lint",
 			inputType: docs.TypeInput,
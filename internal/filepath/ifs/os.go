@@ -48,6 +48,17 @@ func WriteFile(f fs.FS, name string, data []byte, perm fs.FileMode) error {
 	return err
 }
 
+// Rename renames a file provided the fs.FS supports it.
+func Rename(f fs.FS, oldpath, newpath string) error {
+	rf, ok := f.(interface {
+		Rename(oldpath, newpath string) error
+	})
+	if !ok {
+		return errors.New("the filesystem does not support renaming files")
+	}
+	return rf.Rename(oldpath, newpath)
+}
+
 // FileWrite attempts to write to an fs.File provided it supports io.Writer.
 func FileWrite(file fs.File, data []byte) (int, error) {
 	writer, isw := file.(io.Writer)
@@ -93,3 +104,7 @@ func (o *osPT) Remove(name string) error {
 func (o *osPT) MkdirAll(path string, perm fs.FileMode) error {
 	return os.MkdirAll(path, perm)
 }
+
+func (o *osPT) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
@@ -10,6 +10,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -32,6 +33,8 @@ type Client struct {
 	rateLimit     string
 	numRetries    int
 	retryThrottle *throttle.Type
+	maxBackoff    time.Duration
+	afterFn       func(time.Duration) <-chan time.Time
 	backoffOn     map[int]struct{}
 	dropOn        map[int]struct{}
 	successOn     map[int]struct{}
@@ -44,6 +47,7 @@ type Client struct {
 	mgr *service.Resources
 
 	mLatency *service.MetricTimer
+	mRetries *service.MetricCounter
 	mCodes   map[int]*service.MetricCounter
 	codesMut sync.RWMutex
 }
@@ -123,6 +127,7 @@ func NewClientFromOldConfig(conf OldConfig, mgr *service.Resources, opts ...Requ
 	}
 
 	h.mLatency = h.mgr.Metrics().NewTimer("http_request_latency_ns")
+	h.mRetries = h.mgr.Metrics().NewCounter("http_request_retry")
 	h.mCodes = map[int]*service.MetricCounter{}
 
 	if h.rateLimit = conf.RateLimit; h.rateLimit != "" {
@@ -132,6 +137,8 @@ func NewClientFromOldConfig(conf OldConfig, mgr *service.Resources, opts ...Requ
 	}
 
 	h.numRetries = conf.NumRetries
+	h.maxBackoff = conf.MaxBackoff
+	h.afterFn = time.After
 	h.retryThrottle = throttle.New(
 		throttle.OptMaxUnthrottledRetries(0),
 		throttle.OptThrottlePeriod(conf.Retry),
@@ -299,6 +306,30 @@ func (h *Client) checkStatus(code int) (succeeded bool, retStrat retryStrategy)
 	return true, noRetry
 }
 
+// retryAfter returns the period to wait before retrying a request as
+// indicated by the Retry-After header of a response, capped at the configured
+// maximum backoff. Zero is returned when the header is absent or invalid.
+func (h *Client) retryAfter(res *http.Response) time.Duration {
+	v := res.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+
+	var period time.Duration
+	if secs, err := strconv.Atoi(v); err == nil {
+		period = time.Duration(secs) * time.Second
+	} else if t, err := http.ParseTime(v); err == nil {
+		period = time.Until(t)
+	}
+	if period < 0 {
+		return 0
+	}
+	if h.maxBackoff > 0 && period > h.maxBackoff {
+		period = h.maxBackoff
+	}
+	return period
+}
+
 var errTimedOut = errors.New("timed out waiting for next request")
 
 // SendToResponse attempts to create an HTTP request from a provided message,
@@ -344,6 +375,7 @@ func (h *Client) SendToResponse(ctx context.Context, sendMsg service.MessageBatc
 
 	rateLimited := false
 	numRetries := h.numRetries
+	var retryAfter time.Duration
 
 	startedAt := time.Now()
 	if res, err = h.client.Do(req.WithContext(ctx)); err == nil {
@@ -353,6 +385,7 @@ func (h *Client) SendToResponse(ctx context.Context, sendMsg service.MessageBatc
 			if retryStrat == noRetry {
 				numRetries = 0
 			}
+			retryAfter = h.retryAfter(res)
 			err = unexpectedErr(res)
 			if res.Body != nil {
 				res.Body.Close()
@@ -367,7 +400,13 @@ func (h *Client) SendToResponse(ctx context.Context, sendMsg service.MessageBatc
 		if req, err = h.reqCreator.Create(sendMsg); err != nil {
 			continue
 		}
-		if rateLimited {
+		if retryAfter > 0 {
+			select {
+			case <-h.afterFn(retryAfter):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		} else if rateLimited {
 			if !h.retryThrottle.ExponentialRetryWithContext(ctx) {
 				if ctx.Err() != nil {
 					return nil, ctx.Err()
@@ -389,7 +428,9 @@ func (h *Client) SendToResponse(ctx context.Context, sendMsg service.MessageBatc
 			return nil, errTimedOut
 		}
 		rateLimited = false
+		retryAfter = 0
 
+		h.mRetries.Incr(1)
 		startedAt = time.Now()
 		if res, err = h.client.Do(req.WithContext(ctx)); err == nil {
 			h.incrCode(res.StatusCode)
@@ -398,6 +439,7 @@ func (h *Client) SendToResponse(ctx context.Context, sendMsg service.MessageBatc
 				if retryStrat == noRetry {
 					j = 0
 				}
+				retryAfter = h.retryAfter(res)
 				err = unexpectedErr(res)
 				if res.Body != nil {
 					res.Body.Close()
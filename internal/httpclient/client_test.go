@@ -58,6 +58,94 @@ retries: 3
 	assert.Equal(t, uint32(4), atomic.LoadUint32(&reqCount))
 }
 
+func TestHTTPClientRetryAfter(t *testing.T) {
+	var reqCount uint32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddUint32(&reqCount, 1) == 1 {
+			w.Header().Set("Retry-After", "5")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	conf := clientConfig(t, `
+url: %v
+retry_period: 1ms
+retries: 3
+`, ts.URL+"/testpost")
+
+	h, err := NewClientFromOldConfig(conf, service.MockResources())
+	require.NoError(t, err)
+	defer h.Close(context.Background())
+
+	var waited []time.Duration
+	h.afterFn = func(d time.Duration) <-chan time.Time {
+		waited = append(waited, d)
+		c := make(chan time.Time, 1)
+		c <- time.Now()
+		return c
+	}
+
+	_, err = h.Send(context.Background(), service.MessageBatch{service.NewMessage([]byte("test"))})
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{5 * time.Second}, waited)
+	assert.Equal(t, uint32(2), atomic.LoadUint32(&reqCount))
+}
+
+func TestHTTPClientRetryAfterCancelled(t *testing.T) {
+	var reqCount uint32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddUint32(&reqCount, 1)
+		w.Header().Set("Retry-After", "5")
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	conf := clientConfig(t, `
+url: %v
+retry_period: 1ms
+retries: 3
+`, ts.URL+"/testpost")
+
+	h, err := NewClientFromOldConfig(conf, service.MockResources())
+	require.NoError(t, err)
+	defer h.Close(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.afterFn = func(d time.Duration) <-chan time.Time {
+		cancel()
+		return make(chan time.Time)
+	}
+
+	_, err = h.Send(ctx, service.MessageBatch{service.NewMessage([]byte("test"))})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&reqCount))
+}
+
+func TestHTTPClientRetryAfterHeader(t *testing.T) {
+	h := &Client{maxBackoff: 10 * time.Second}
+
+	for _, test := range []struct {
+		value    string
+		expected time.Duration
+	}{
+		{value: "", expected: 0},
+		{value: "3", expected: 3 * time.Second},
+		{value: "60", expected: 10 * time.Second},
+		{value: "-1", expected: 0},
+		{value: "nope", expected: 0},
+		{value: "Mon, 02 Jan 2006 15:04:05 GMT", expected: 0},
+	} {
+		res := &http.Response{Header: http.Header{}}
+		if test.value != "" {
+			res.Header.Set("Retry-After", test.value)
+		}
+		assert.Equal(t, test.expected, h.retryAfter(res), test.value)
+	}
+}
+
 func TestHTTPClientBadRequest(t *testing.T) {
 	conf := clientConfig(t, `
 url: htp://notvalid:1111
@@ -78,11 +78,11 @@ func ConfigField(defaultVerb string, forOutput bool, extraChildren ...*service.C
 			Advanced().
 			Default("1s"),
 		service.NewDurationField(hcFieldMaxRetryBackoff).
-			Description("The maximum period to wait between failed requests.").
+			Description("The maximum period to wait between failed requests. This also caps the period requested by a `Retry-After` response header.").
 			Advanced().
 			Default("300s"),
 		service.NewIntField(hcFieldRetries).
-			Description("The maximum number of retry attempts to make.").
+			Description("The maximum number of retry attempts to make. When a failed response contains a `Retry-After` header the next attempt is delayed by the period it specifies rather than the configured retry period.").
 			Advanced().
 			Default(3),
 		service.NewIntListField(hcFieldBackoffOn).
@@ -17,6 +17,7 @@ const (
 	consumerTagField             = "consumer_tag"
 	autoAckField                 = "auto_ack"
 	nackRejectPattensField       = "nack_reject_patterns"
+	nackRequeueField             = "nack_requeue"
 	prefetchCountField           = "prefetch_count"
 	prefetchSizeField            = "prefetch_size"
 
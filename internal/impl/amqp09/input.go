@@ -103,6 +103,11 @@ You can access these metadata fields using [function interpolation](/docs/config
 			Advanced().
 			Version("3.64.0").
 			Default([]any{}),
+		service.NewBoolField(nackRequeueField).
+			Description("Whether messages that have failed to be delivered by Benthos and do not match any of the `nack_reject_patterns` should be nacked with requeue enabled. Setting this to `false` causes all failed messages to be rejected, allowing dead-letter exchange policies on the queue to take effect.").
+			Advanced().
+			Version("4.28.0").
+			Default(true),
 		service.NewIntField(prefetchCountField).
 			Description("The maximum number of pending messages to have consumed at a time.").
 			Default(10),
@@ -148,6 +153,7 @@ type amqp09Reader struct {
 	autoAck       bool
 
 	nackRejectPattens []*regexp.Regexp
+	nackRequeue       bool
 
 	queueDeclare    bool
 	queueDurable    bool
@@ -199,6 +205,9 @@ func amqp09ReaderFromParsed(conf *service.ParsedConfig, mgr *service.Resources)
 	if a.autoAck, err = conf.FieldBool(autoAckField); err != nil {
 		return nil, err
 	}
+	if a.nackRequeue, err = conf.FieldBool(nackRequeueField); err != nil {
+		return nil, err
+	}
 
 	if conf.Contains(nackRejectPattensField) {
 		nackPatternStrs, err := conf.FieldStringList(nackRejectPattensField)
@@ -262,6 +271,7 @@ func (a *amqp09Reader) Connect(ctx context.Context) (err error) {
 
 	amqpChan, err = conn.Channel()
 	if err != nil {
+		_ = conn.Close()
 		return fmt.Errorf("AMQP 0.9 Channel: %w", err)
 	}
 
@@ -461,7 +471,7 @@ func (a *amqp09Reader) Read(ctx context.Context) (*service.Message, service.AckF
 						return data.Nack(false, false)
 					}
 				}
-				return data.Nack(false, true)
+				return data.Nack(false, a.nackRequeue)
 			}
 			return data.Ack(false)
 		}, nil
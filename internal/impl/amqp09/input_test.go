@@ -0,0 +1,92 @@
+package amqp09
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+type fakeAcknowledger struct {
+	acks  []uint64
+	nacks []string
+}
+
+func (f *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	f.acks = append(f.acks, tag)
+	return nil
+}
+
+func (f *fakeAcknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	f.nacks = append(f.nacks, fmt.Sprintf("%v:%v", tag, requeue))
+	return nil
+}
+
+func (f *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return errors.New("not expected")
+}
+
+func TestAMQP09InputNackRequeue(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		conf     string
+		expected []string
+	}{
+		{
+			name:     "default",
+			conf:     ``,
+			expected: []string{"1:true", "2:false"},
+		},
+		{
+			name:     "no requeue",
+			conf:     `nack_requeue: false`,
+			expected: []string{"1:false", "2:false"},
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			pConf, err := amqp09InputSpec().ParseYAML(fmt.Sprintf(`
+urls: [ amqp://localhost:5672 ]
+queue: foo
+nack_reject_patterns: [ '^reject' ]
+%v
+`, test.conf), nil)
+			require.NoError(t, err)
+
+			a, err := amqp09ReaderFromParsed(pConf, service.MockResources())
+			require.NoError(t, err)
+
+			acker := &fakeAcknowledger{}
+			deliveries := make(chan amqp.Delivery, 3)
+			for i := uint64(1); i <= 3; i++ {
+				deliveries <- amqp.Delivery{
+					Acknowledger: acker,
+					DeliveryTag:  i,
+					Body:         []byte(fmt.Sprintf("msg%v", i)),
+				}
+			}
+			a.conn = &amqp.Connection{}
+			a.consumerChan = deliveries
+
+			ctx := context.Background()
+			for _, res := range []error{
+				errors.New("failed downstream"),
+				errors.New("reject this one"),
+				nil,
+			} {
+				_, ackFn, err := a.Read(ctx)
+				require.NoError(t, err)
+				require.NoError(t, ackFn(ctx, res))
+			}
+
+			assert.Equal(t, test.expected, acker.nacks)
+			assert.Equal(t, []uint64{3}, acker.acks)
+		})
+	}
+}
@@ -21,11 +21,7 @@ func amqp1OutputSpec() *service.ConfigSpec {
 		Description(`
 ### Metadata
 
-Message metadata is added to each AMQP message as string annotations. In order to control which metadata keys are added use the `+"`metadata`"+` config field.
-
-## Performance
-
-This output benefits from sending multiple messages in flight in parallel for improved performance. You can tune the max number of in flight messages (or message batches) with the field `+"`max_in_flight`"+`.`).
+Message metadata is added to each AMQP message as string annotations. In order to control which metadata keys are added use the `+"`metadata`"+` config field.`+service.OutputPerformanceDocs(true, false)).
 		Fields(
 			service.NewURLField(urlField).
 				Description("A URL to connect to.").
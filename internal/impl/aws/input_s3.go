@@ -150,6 +150,7 @@ This input adds the following metadata fields to each message:
 - s3_last_modified (RFC3339)
 - s3_content_type
 - s3_content_encoding
+- s3_content_length
 - s3_version_id
 - All user defined metadata
 `+"```"+`
@@ -731,6 +732,9 @@ func s3MetaToBatch(p *s3PendingObject, parts service.MessageBatch) {
 		if p.obj.ContentEncoding != nil {
 			part.MetaSetMut("s3_content_encoding", *p.obj.ContentEncoding)
 		}
+		if p.obj.ContentLength != nil {
+			part.MetaSetMut("s3_content_length", *p.obj.ContentLength)
+		}
 		if p.obj.VersionId != nil && *p.obj.VersionId != "null" {
 			part.MetaSetMut("s3_version_id", *p.obj.VersionId)
 		}
@@ -0,0 +1,73 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestS3MetaToBatch(t *testing.T) {
+	lastModified := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	batch := service.MessageBatch{
+		service.NewMessage([]byte("foo")),
+		service.NewMessage([]byte("bar")),
+	}
+	s3MetaToBatch(&s3PendingObject{
+		target: newS3ObjectTarget("foo.txt", "things", time.Time{}, nil),
+		obj: &s3.GetObjectOutput{
+			LastModified:  &lastModified,
+			ContentType:   aws.String("text/plain"),
+			ContentLength: aws.Int64(7),
+			VersionId:     aws.String("v1"),
+			Metadata:      map[string]string{"owner": "meow"},
+		},
+	}, batch)
+
+	for _, part := range batch {
+		for k, exp := range map[string]any{
+			"s3_key":                "foo.txt",
+			"s3_bucket":             "things",
+			"s3_last_modified":      "2023-01-02T03:04:05Z",
+			"s3_last_modified_unix": lastModified.Unix(),
+			"s3_content_type":       "text/plain",
+			"s3_content_length":     int64(7),
+			"s3_version_id":         "v1",
+			"owner":                 "meow",
+		} {
+			v, exists := part.MetaGetMut(k)
+			assert.True(t, exists, k)
+			assert.Equal(t, exp, v, k)
+		}
+	}
+}
+
+func TestS3MetaToBatchMissingFields(t *testing.T) {
+	batch := service.MessageBatch{service.NewMessage([]byte("foo"))}
+	s3MetaToBatch(&s3PendingObject{
+		target: newS3ObjectTarget("foo.txt", "things", time.Time{}, nil),
+		obj: &s3.GetObjectOutput{
+			VersionId: aws.String("null"),
+		},
+	}, batch)
+
+	for _, k := range []string{
+		"s3_last_modified",
+		"s3_content_type",
+		"s3_content_encoding",
+		"s3_content_length",
+		"s3_version_id",
+	} {
+		_, exists := batch[0].MetaGetMut(k)
+		assert.False(t, exists, k)
+	}
+
+	v, exists := batch[0].MetaGetMut("s3_key")
+	assert.True(t, exists)
+	assert.Equal(t, "foo.txt", v)
+}
@@ -26,6 +26,7 @@ const (
 	sqsiFieldMaxNumberOfMessages = "max_number_of_messages"
 
 	sqsiAttributeNameVisibilityTimeout = "VisibilityTimeout"
+	sqsiDefaultVisibilityTimeout       = 30
 )
 
 type sqsiConfig struct {
@@ -68,6 +69,10 @@ services. It's also possible to set them explicitly at the component level,
 allowing you to transfer data across accounts. You can find out more
 [in this document](/docs/guides/cloud/aws).
 
+### Visibility Timeout
+
+Messages that are yet to be acknowledged have their visibility timeout extended periodically in order to prevent them from being redelivered whilst they're still being processed. The timeout is extended each time half of the visibility timeout of the queue has elapsed, which is obtained from the queue attributes when connecting and therefore requires the permission `+"`sqs:GetQueueAttributes`"+`. When the queue attributes cannot be obtained a visibility timeout of 30 seconds is assumed.
+
 ### Metadata
 
 This input adds the following metadata fields to each message:
@@ -131,6 +136,7 @@ type sqsAPI interface {
 	ReceiveMessage(context.Context, *sqs.ReceiveMessageInput, ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error)
 	DeleteMessageBatch(context.Context, *sqs.DeleteMessageBatchInput, ...func(*sqs.Options)) (*sqs.DeleteMessageBatchOutput, error)
 	ChangeMessageVisibilityBatch(context.Context, *sqs.ChangeMessageVisibilityBatchInput, ...func(*sqs.Options)) (*sqs.ChangeMessageVisibilityBatchOutput, error)
+	GetQueueAttributes(context.Context, *sqs.GetQueueAttributesInput, ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error)
 	SendMessageBatch(context.Context, *sqs.SendMessageBatchInput, ...func(*sqs.Options)) (*sqs.SendMessageBatchOutput, error)
 }
 
@@ -168,7 +174,8 @@ func (a *awsSQSReader) Connect(ctx context.Context) error {
 	}
 
 	ift := &sqsInFlightTracker{
-		handles: map[string]sqsInFlightHandle{},
+		handles:        map[string]sqsInFlightHandle{},
+		timeoutSeconds: a.getQueueVisibilityTimeout(ctx),
 	}
 
 	var wg sync.WaitGroup
@@ -182,35 +189,64 @@ func (a *awsSQSReader) Connect(ctx context.Context) error {
 	return nil
 }
 
+// getQueueVisibilityTimeout obtains the default visibility timeout of the
+// queue, which determines how often the visibility of in flight messages needs
+// to be extended. If the queue attributes cannot be obtained then a default of
+// 30 seconds, which matches the default of SQS, is assumed.
+func (a *awsSQSReader) getQueueVisibilityTimeout(ctx context.Context) int {
+	timeoutSeconds := sqsiDefaultVisibilityTimeout
+
+	res, err := a.sqs.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(a.conf.URL),
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameVisibilityTimeout},
+	})
+	if err != nil {
+		a.log.Warnf("Failed to obtain the visibility timeout of the queue, assuming %v seconds: %v", timeoutSeconds, err)
+		return timeoutSeconds
+	}
+	if timeoutStr, exists := res.Attributes[sqsiAttributeNameVisibilityTimeout]; exists {
+		if tmpTimeoutSeconds, err := strconv.Atoi(timeoutStr); err == nil && tmpTimeoutSeconds > 0 {
+			timeoutSeconds = tmpTimeoutSeconds
+		}
+	}
+	return timeoutSeconds
+}
+
 type sqsInFlightHandle struct {
-	receiptHandle  string
-	timeoutSeconds int
-	addedAt        time.Time
+	receiptHandle string
+	deadline      time.Time
 }
 
 type sqsInFlightTracker struct {
-	handles map[string]sqsInFlightHandle
-	m       sync.Mutex
+	handles        map[string]sqsInFlightHandle
+	timeoutSeconds int
+	m              sync.Mutex
 }
 
+// PullToRefresh returns the handles of in flight messages that are within
+// half of the visibility timeout of becoming visible again, along with the
+// timeout that they should be extended by. The deadlines of the returned
+// handles are reset under the assumption that they'll be refreshed.
 func (t *sqsInFlightTracker) PullToRefresh() (handles []sqsMessageHandle, timeoutSeconds int) {
 	t.m.Lock()
 	defer t.m.Unlock()
 
+	timeout := time.Duration(t.timeoutSeconds) * time.Second
+	now := time.Now()
+
 	handles = make([]sqsMessageHandle, 0, len(t.handles))
 	for k, v := range t.handles {
-		if time.Since(v.addedAt) < time.Second {
+		if v.deadline.Sub(now) > timeout/2 {
 			continue
 		}
 		handles = append(handles, sqsMessageHandle{
 			id:            k,
 			receiptHandle: v.receiptHandle,
 		})
-		if v.timeoutSeconds > timeoutSeconds {
-			timeoutSeconds = v.timeoutSeconds
-		}
+		v.deadline = now.Add(timeout)
+		t.handles[k] = v
 	}
-	return
+	return handles, t.timeoutSeconds
 }
 
 func (t *sqsInFlightTracker) Remove(id string) {
@@ -223,24 +259,15 @@ func (t *sqsInFlightTracker) AddNew(messages ...types.Message) {
 	t.m.Lock()
 	defer t.m.Unlock()
 
+	deadline := time.Now().Add(time.Duration(t.timeoutSeconds) * time.Second)
 	for _, m := range messages {
 		if m.MessageId == nil || m.ReceiptHandle == nil {
 			continue
 		}
-
-		handle := sqsInFlightHandle{
-			timeoutSeconds: 30,
-			receiptHandle:  *m.ReceiptHandle,
-			addedAt:        time.Now(),
-		}
-		if timeoutStr, exists := m.Attributes[sqsiAttributeNameVisibilityTimeout]; exists {
-			// Might as well keep the queue timeout setting refreshed as we
-			// consume new data.
-			if tmpTimeoutSeconds, err := strconv.Atoi(timeoutStr); err == nil {
-				handle.timeoutSeconds = tmpTimeoutSeconds
-			}
+		t.handles[*m.MessageId] = sqsInFlightHandle{
+			receiptHandle: *m.ReceiptHandle,
+			deadline:      deadline,
 		}
-		t.handles[*m.MessageId] = handle
 	}
 }
 
@@ -74,7 +74,7 @@ func (m *mockSqsInput) ReceiveMessage(context.Context, *sqs.ReceiveMessageInput,
 	return &sqs.ReceiveMessageOutput{Messages: messages}, nil
 }
 
-func (m *mockSqsInput) GetQueueAttributes(input *sqs.GetQueueAttributesInput) (*sqs.GetQueueAttributesOutput, error) {
+func (m *mockSqsInput) GetQueueAttributes(ctx context.Context, input *sqs.GetQueueAttributesInput, opts ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error) {
 	return &sqs.GetQueueAttributesOutput{Attributes: map[string]string{sqsiAttributeNameVisibilityTimeout: strconv.Itoa(int(m.queueTimeout))}}, nil
 }
 
@@ -285,3 +285,29 @@ func TestSQSInputBatchAck(t *testing.T) {
 		return msgsLen == 0
 	}, 5*time.Second, time.Second)
 }
+
+func TestSQSInFlightTrackerRefresh(t *testing.T) {
+	ift := &sqsInFlightTracker{
+		handles:        map[string]sqsInFlightHandle{},
+		timeoutSeconds: 2,
+	}
+
+	ift.AddNew(
+		types.Message{MessageId: aws.String("foo"), ReceiptHandle: aws.String("foo-handle")},
+		types.Message{MessageId: aws.String("bar"), ReceiptHandle: aws.String("bar-handle")},
+		types.Message{MessageId: aws.String("baz")},
+	)
+
+	handles, _ := ift.PullToRefresh()
+	assert.Empty(t, handles, "handles should not be refreshed before half the timeout has elapsed")
+
+	ift.Remove("bar")
+	time.Sleep(time.Millisecond * 1100)
+
+	handles, timeoutSeconds := ift.PullToRefresh()
+	assert.Equal(t, 2, timeoutSeconds)
+	assert.Equal(t, []sqsMessageHandle{{id: "foo", receiptHandle: "foo-handle"}}, handles)
+
+	handles, _ = ift.PullToRefresh()
+	assert.Empty(t, handles, "refreshed handles should have their deadline reset")
+}
@@ -8,8 +8,10 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/cenkalti/backoff/v4"
 
 	"github.com/benthosdev/benthos/v4/internal/impl/aws/config"
 	"github.com/benthosdev/benthos/v4/public/service"
@@ -288,16 +290,28 @@ type cwMetrics struct {
 	ctx    context.Context
 	cancel func()
 
+	throttleBackOffCtor func() backoff.BackOff
+
 	config cwmConfig
 	log    *service.Logger
 }
 
+func cwmThrottleBackOff() backoff.BackOff {
+	boff := backoff.NewExponentialBackOff()
+	boff.InitialInterval = time.Millisecond * 500
+	boff.MaxInterval = time.Second * 10
+	boff.MaxElapsedTime = time.Minute
+	return boff
+}
+
 func newCloudWatch(config cwmConfig, sess aws.Config, log *service.Logger) (service.MetricsExporter, error) {
 	c := &cwMetrics{
 		config:    config,
 		datumses:  map[string]*cloudWatchDatum{},
 		datumLock: &sync.Mutex{},
 		log:       log,
+
+		throttleBackOffCtor: cwmThrottleBackOff,
 	}
 
 	c.ctx, c.cancel = context.WithCancel(context.Background())
@@ -387,7 +401,7 @@ func (c *cwMetrics) loop() {
 		case <-c.ctx.Done():
 			return
 		case <-ticker.C:
-			c.flush()
+			_ = c.flush(c.ctx)
 		}
 	}
 }
@@ -446,7 +460,7 @@ func valuesMapToSlices(m map[int64]int64) (values, counts []float64) {
 	return
 }
 
-func (c *cwMetrics) flush() error {
+func (c *cwMetrics) flush(ctx context.Context) error {
 	c.datumLock.Lock()
 	datumMap := c.datumses
 	c.datumses = map[string]*cloudWatchDatum{}
@@ -475,6 +489,9 @@ func (c *cwMetrics) flush() error {
 		MetricData: datums,
 	}
 
+	throttles := retry.IsErrorThrottles(retry.DefaultThrottles)
+	throttleBoff := c.throttleBackOffCtor()
+
 	throttled := false
 	for len(input.MetricData) > 0 {
 		if !throttled {
@@ -486,17 +503,33 @@ func (c *cwMetrics) flush() error {
 		}
 		throttled = false
 
-		if _, err := c.client.PutMetricData(c.ctx, &input); err != nil {
-			if c.ctx.Err() != nil {
+		if _, err := c.client.PutMetricData(ctx, &input); err != nil {
+			if ctx.Err() != nil {
 				return err
 			}
-			c.log.Errorf("Failed to send metric data: %v", err)
+
+			// Throttled requests are retried with the same data until the
+			// back off is exhausted, any other failure drops the data.
+			wait := time.Second
+			if throttles.IsErrorThrottle(err) == aws.TrueTernary {
+				if wait = throttleBoff.NextBackOff(); wait != backoff.Stop {
+					throttled = true
+					c.log.Warnf("Metric data was throttled, retrying in %v: %v", wait, err)
+				} else {
+					wait = time.Second
+				}
+			}
+			if !throttled {
+				c.log.Errorf("Failed to send metric data: %v", err)
+			}
 
 			select {
-			case <-time.After(time.Second):
-			case <-c.ctx.Done():
-				return c.ctx.Err()
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return ctx.Err()
 			}
+		} else {
+			throttleBoff.Reset()
 		}
 
 		if !throttled {
@@ -515,6 +548,6 @@ func (c *cwMetrics) HandlerFunc() http.HandlerFunc {
 
 func (c *cwMetrics) Close(ctx context.Context) error {
 	c.cancel()
-	c.flush()
+	_ = c.flush(ctx)
 	return nil
 }
@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/cenkalti/backoff/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockCloudWatchClient struct {
@@ -24,6 +26,10 @@ func cwmMock(svc cloudWatchAPI) *cwMetrics {
 		datumLock: &sync.Mutex{},
 		log:       nil,
 		client:    svc,
+
+		throttleBackOffCtor: func() backoff.BackOff {
+			return backoff.WithMaxRetries(&backoff.ZeroBackOff{}, 2)
+		},
 	}
 }
 
@@ -117,7 +123,7 @@ func TestCloudWatchBasic(t *testing.T) {
 	tmgFoo.Timing(87001)
 	tmgFoo.Timing(23010)
 
-	cw.flush()
+	cw.flush(context.Background())
 
 	ctrFoo.Incr(2)
 
@@ -132,7 +138,7 @@ func TestCloudWatchBasic(t *testing.T) {
 	tmgFoo.Timing(87120)
 	tmgFoo.Timing(23400)
 
-	cw.flush()
+	cw.flush(context.Background())
 
 	assert.Len(t, mockSvc.inputs, 2)
 
@@ -219,7 +225,7 @@ func TestCloudWatchMoreThan20Items(t *testing.T) {
 		}
 	}
 
-	cw.flush()
+	cw.flush(context.Background())
 
 	assert.Len(t, mockSvc.inputs, 2)
 	assert.Len(t, mockSvc.inputs[0].MetricData, 20)
@@ -257,7 +263,7 @@ func TestCloudWatchMoreThan150Values(t *testing.T) {
 		gge.Set(v)
 	}
 
-	cw.flush()
+	cw.flush(context.Background())
 
 	assert.Len(t, mockSvc.inputs, 1)
 	assert.Len(t, mockSvc.inputs[0].MetricData, 1)
@@ -280,7 +286,7 @@ func TestCloudWatchMoreThan150RandomReduce(t *testing.T) {
 		gge.Set(i)
 	}
 
-	cw.flush()
+	cw.flush(context.Background())
 
 	assert.Len(t, mockSvc.inputs, 1)
 	assert.Len(t, mockSvc.inputs[0].MetricData, 1)
@@ -300,7 +306,7 @@ func TestCloudWatchMoreThan150LiveReduce(t *testing.T) {
 		gge.Set(i)
 	}
 
-	cw.flush()
+	cw.flush(context.Background())
 
 	assert.Len(t, mockSvc.inputs, 1)
 	assert.Len(t, mockSvc.inputs[0].MetricData, 1)
@@ -323,7 +329,7 @@ func TestCloudWatchTags(t *testing.T) {
 	ctr("").Incr(3) // Test that empty ones are skipped
 	gge("third").Set(3)
 
-	cw.flush()
+	cw.flush(context.Background())
 
 	assert.Len(t, mockSvc.inputs, 1)
 	assert.Equal(t, "Benthos", *mockSvc.inputs[0].Namespace)
@@ -379,7 +385,7 @@ func TestCloudWatchTagsMoreThan20(t *testing.T) {
 	ctrFoo := cw.NewCounterCtor("counter.foo", tagNames...)
 	ctrFoo(tagValues...).Incr(3)
 
-	cw.flush()
+	cw.flush(context.Background())
 
 	expKey := fmt.Sprintf("counter.foo:%v", expTagMap)
 
@@ -395,3 +401,43 @@ func TestCloudWatchTagsMoreThan20(t *testing.T) {
 		},
 	}, checkInput(mockSvc.inputs[0]))
 }
+
+type mockThrottleErr struct{}
+
+func (mockThrottleErr) Error() string     { return "rate exceeded" }
+func (mockThrottleErr) ErrorCode() string { return "Throttling" }
+
+func TestCloudWatchThrottledRetry(t *testing.T) {
+	throttleErr := mockThrottleErr{}
+	mockSvc := &mockCloudWatchClient{
+		errs: []error{throttleErr, throttleErr},
+	}
+	cw := cwmMock(mockSvc)
+	cw.ctx, cw.cancel = context.WithCancel(context.Background())
+
+	for i := 0; i < 30; i++ {
+		cw.NewCounterCtor(fmt.Sprintf("counter.%v", i))().Incr(1)
+	}
+
+	require.NoError(t, cw.flush(context.Background()))
+
+	require.Len(t, mockSvc.inputs, 4)
+	assert.Len(t, mockSvc.inputs[0].MetricData, 20)
+	assert.Equal(t, mockSvc.inputs[0].MetricData, mockSvc.inputs[1].MetricData)
+	assert.Equal(t, mockSvc.inputs[0].MetricData, mockSvc.inputs[2].MetricData)
+	assert.Len(t, mockSvc.inputs[3].MetricData, 10)
+}
+
+func TestCloudWatchCloseFlushes(t *testing.T) {
+	mockSvc := &mockCloudWatchClient{}
+	cw := cwmMock(mockSvc)
+	cw.ctx, cw.cancel = context.WithCancel(context.Background())
+
+	cw.NewCounterCtor("counter.foo")().Incr(3)
+	require.NoError(t, cw.Close(context.Background()))
+
+	require.Len(t, mockSvc.inputs, 1)
+	assert.Equal(t, map[string]checkedDatum{
+		"counter.foo": {unit: "Count", value: 3},
+	}, checkInput(mockSvc.inputs[0]))
+}
@@ -37,6 +37,7 @@ const (
 	s3oFieldKMSKeyID                = "kms_key_id"
 	s3oFieldServerSideEncryption    = "server_side_encryption"
 	s3oFieldBatching                = "batching"
+	s3oFieldBatchDelimiter          = "batch_delimiter"
 )
 
 type s3TagPair struct {
@@ -61,6 +62,7 @@ type s3oConfig struct {
 	KMSKeyID                string
 	ServerSideEncryption    string
 	UsePathStyle            bool
+	BatchDelimiter          *string
 
 	aconf aws.Config
 }
@@ -124,6 +126,13 @@ func s3oConfigFromParsed(pConf *service.ParsedConfig) (conf s3oConfig, err error
 	if conf.ServerSideEncryption, err = pConf.FieldString(s3oFieldServerSideEncryption); err != nil {
 		return
 	}
+	if pConf.Contains(s3oFieldBatchDelimiter) {
+		var delim string
+		if delim, err = pConf.FieldString(s3oFieldBatchDelimiter); err != nil {
+			return
+		}
+		conf.BatchDelimiter = &delim
+	}
 	if conf.aconf, err = GetSession(context.TODO(), pConf); err != nil {
 		return
 	}
@@ -194,7 +203,9 @@ output:
       processors:
         - archive:
             format: json_array
-`+"```"+``+service.OutputPerformanceDocs(true, false)).
+`+"```"+`
+
+For simpler layouts, such as newline delimited documents, the field `+"`batch_delimiter`"+` can be set instead, which joins the messages of each batch with the delimiter and uploads them as a single object. In this case the path and all other interpolated fields are resolved against the first message of the batch.`+service.OutputPerformanceDocs(true, false)).
 		Fields(
 			service.NewStringField(s3oFieldBucket).
 				Description("The bucket to upload messages to."),
@@ -261,6 +272,11 @@ output:
 				Advanced().
 				Default("5s"),
 			service.NewBatchPolicyField(s3oFieldBatching),
+			service.NewStringField(s3oFieldBatchDelimiter).
+				Description("An optional delimiter used to join the messages of a batch into a single object. When omitted each message of a batch is uploaded as a separate object.").
+				Example("\n").
+				Version("4.28.0").
+				Optional(),
 		).
 		Fields(config.SessionFields()...)
 }
@@ -320,114 +336,132 @@ func (a *amazonS3Writer) WriteBatch(wctx context.Context, msg service.MessageBat
 	ctx, cancel := context.WithTimeout(wctx, a.conf.Timeout)
 	defer cancel()
 
-	return msg.WalkWithBatchedErrors(func(i int, m *service.Message) error {
-		metadata := map[string]string{}
-		_ = a.conf.Metadata.WalkMut(m, func(k string, v any) error {
-			metadata[k] = bloblang.ValueToString(v)
-			return nil
-		})
-
-		var contentEncoding *string
-		ce, err := msg.TryInterpolatedString(i, a.conf.ContentEncoding)
-		if err != nil {
-			return fmt.Errorf("content encoding interpolation: %w", err)
-		}
-		if ce != "" {
-			contentEncoding = aws.String(ce)
-		}
-		var cacheControl *string
-		if ce, err = msg.TryInterpolatedString(i, a.conf.CacheControl); err != nil {
-			return fmt.Errorf("cache control interpolation: %w", err)
-		}
-		if ce != "" {
-			cacheControl = aws.String(ce)
-		}
-		var contentDisposition *string
-		if ce, err = msg.TryInterpolatedString(i, a.conf.ContentDisposition); err != nil {
-			return fmt.Errorf("content disposition interpolation: %w", err)
-		}
-		if ce != "" {
-			contentDisposition = aws.String(ce)
-		}
-		var contentLanguage *string
-		if ce, err = msg.TryInterpolatedString(i, a.conf.ContentLanguage); err != nil {
-			return fmt.Errorf("content language interpolation: %w", err)
-		}
-		if ce != "" {
-			contentLanguage = aws.String(ce)
-		}
-		var websiteRedirectLocation *string
-		if ce, err = msg.TryInterpolatedString(i, a.conf.WebsiteRedirectLocation); err != nil {
-			return fmt.Errorf("website redirect location interpolation: %w", err)
-		}
-		if ce != "" {
-			websiteRedirectLocation = aws.String(ce)
+	if a.conf.BatchDelimiter != nil {
+		parts := make([][]byte, len(msg))
+		for i, m := range msg {
+			var err error
+			if parts[i], err = m.AsBytes(); err != nil {
+				return err
+			}
 		}
+		// The object key, tags and other fields are resolved against the first
+		// message of the original batch, only the body is joined.
+		return a.upload(ctx, msg, 0, bytes.Join(parts, []byte(*a.conf.BatchDelimiter)))
+	}
 
-		key, err := msg.TryInterpolatedString(i, a.conf.Path)
+	return msg.WalkWithBatchedErrors(func(i int, m *service.Message) error {
+		mBytes, err := m.AsBytes()
 		if err != nil {
-			return fmt.Errorf("key interpolation: %w", err)
+			return err
 		}
+		return a.upload(ctx, msg, i, mBytes)
+	})
+}
 
-		contentType, err := msg.TryInterpolatedString(i, a.conf.ContentType)
-		if err != nil {
-			return fmt.Errorf("content type interpolation: %w", err)
-		}
+func (a *amazonS3Writer) upload(ctx context.Context, msg service.MessageBatch, i int, body []byte) error {
+	m := msg[i]
 
-		storageClass, err := msg.TryInterpolatedString(i, a.conf.StorageClass)
-		if err != nil {
-			return fmt.Errorf("storage class interpolation: %w", err)
-		}
+	metadata := map[string]string{}
+	_ = a.conf.Metadata.WalkMut(m, func(k string, v any) error {
+		metadata[k] = bloblang.ValueToString(v)
+		return nil
+	})
 
-		mBytes, err := m.AsBytes()
-		if err != nil {
-			return err
-		}
+	var contentEncoding *string
+	ce, err := msg.TryInterpolatedString(i, a.conf.ContentEncoding)
+	if err != nil {
+		return fmt.Errorf("content encoding interpolation: %w", err)
+	}
+	if ce != "" {
+		contentEncoding = aws.String(ce)
+	}
+	var cacheControl *string
+	if ce, err = msg.TryInterpolatedString(i, a.conf.CacheControl); err != nil {
+		return fmt.Errorf("cache control interpolation: %w", err)
+	}
+	if ce != "" {
+		cacheControl = aws.String(ce)
+	}
+	var contentDisposition *string
+	if ce, err = msg.TryInterpolatedString(i, a.conf.ContentDisposition); err != nil {
+		return fmt.Errorf("content disposition interpolation: %w", err)
+	}
+	if ce != "" {
+		contentDisposition = aws.String(ce)
+	}
+	var contentLanguage *string
+	if ce, err = msg.TryInterpolatedString(i, a.conf.ContentLanguage); err != nil {
+		return fmt.Errorf("content language interpolation: %w", err)
+	}
+	if ce != "" {
+		contentLanguage = aws.String(ce)
+	}
+	var websiteRedirectLocation *string
+	if ce, err = msg.TryInterpolatedString(i, a.conf.WebsiteRedirectLocation); err != nil {
+		return fmt.Errorf("website redirect location interpolation: %w", err)
+	}
+	if ce != "" {
+		websiteRedirectLocation = aws.String(ce)
+	}
 
-		uploadInput := &s3.PutObjectInput{
-			Bucket:                  &a.conf.Bucket,
-			Key:                     aws.String(key),
-			Body:                    bytes.NewReader(mBytes),
-			ContentType:             aws.String(contentType),
-			ContentEncoding:         contentEncoding,
-			CacheControl:            cacheControl,
-			ContentDisposition:      contentDisposition,
-			ContentLanguage:         contentLanguage,
-			WebsiteRedirectLocation: websiteRedirectLocation,
-			StorageClass:            types.StorageClass(storageClass),
-			Metadata:                metadata,
-		}
+	key, err := msg.TryInterpolatedString(i, a.conf.Path)
+	if err != nil {
+		return fmt.Errorf("key interpolation: %w", err)
+	}
+
+	contentType, err := msg.TryInterpolatedString(i, a.conf.ContentType)
+	if err != nil {
+		return fmt.Errorf("content type interpolation: %w", err)
+	}
 
-		// Prepare tags, escaping keys and values to ensure they're valid query string parameters.
-		if len(a.conf.Tags) > 0 {
-			tags := make([]string, len(a.conf.Tags))
-			for j, pair := range a.conf.Tags {
-				tagStr, err := msg.TryInterpolatedString(i, pair.value)
-				if err != nil {
-					return fmt.Errorf("tag %v interpolation: %w", pair.key, err)
-				}
-				tags[j] = url.QueryEscape(pair.key) + "=" + url.QueryEscape(tagStr)
+	storageClass, err := msg.TryInterpolatedString(i, a.conf.StorageClass)
+	if err != nil {
+		return fmt.Errorf("storage class interpolation: %w", err)
+	}
+
+	uploadInput := &s3.PutObjectInput{
+		Bucket:                  &a.conf.Bucket,
+		Key:                     aws.String(key),
+		Body:                    bytes.NewReader(body),
+		ContentType:             aws.String(contentType),
+		ContentEncoding:         contentEncoding,
+		CacheControl:            cacheControl,
+		ContentDisposition:      contentDisposition,
+		ContentLanguage:         contentLanguage,
+		WebsiteRedirectLocation: websiteRedirectLocation,
+		StorageClass:            types.StorageClass(storageClass),
+		Metadata:                metadata,
+	}
+
+	// Prepare tags, escaping keys and values to ensure they're valid query string parameters.
+	if len(a.conf.Tags) > 0 {
+		tags := make([]string, len(a.conf.Tags))
+		for j, pair := range a.conf.Tags {
+			tagStr, err := msg.TryInterpolatedString(i, pair.value)
+			if err != nil {
+				return fmt.Errorf("tag %v interpolation: %w", pair.key, err)
 			}
-			uploadInput.Tagging = aws.String(strings.Join(tags, "&"))
+			tags[j] = url.QueryEscape(pair.key) + "=" + url.QueryEscape(tagStr)
 		}
+		uploadInput.Tagging = aws.String(strings.Join(tags, "&"))
+	}
 
-		if a.conf.KMSKeyID != "" {
-			uploadInput.ServerSideEncryption = types.ServerSideEncryptionAwsKms
-			uploadInput.SSEKMSKeyId = &a.conf.KMSKeyID
-		}
+	if a.conf.KMSKeyID != "" {
+		uploadInput.ServerSideEncryption = types.ServerSideEncryptionAwsKms
+		uploadInput.SSEKMSKeyId = &a.conf.KMSKeyID
+	}
 
-		// NOTE: This overrides the ServerSideEncryption set above. We need this to preserve
-		// backwards compatibility, where it is allowed to only set kms_key_id in the config and
-		// the ServerSideEncryption value of "aws:kms" is implied.
-		if a.conf.ServerSideEncryption != "" {
-			uploadInput.ServerSideEncryption = types.ServerSideEncryption(a.conf.ServerSideEncryption)
-		}
+	// NOTE: This overrides the ServerSideEncryption set above. We need this to preserve
+	// backwards compatibility, where it is allowed to only set kms_key_id in the config and
+	// the ServerSideEncryption value of "aws:kms" is implied.
+	if a.conf.ServerSideEncryption != "" {
+		uploadInput.ServerSideEncryption = types.ServerSideEncryption(a.conf.ServerSideEncryption)
+	}
 
-		if _, err := a.uploader.Upload(ctx, uploadInput); err != nil {
-			return err
-		}
-		return nil
-	})
+	if _, err := a.uploader.Upload(ctx, uploadInput); err != nil {
+		return err
+	}
+	return nil
 }
 
 func (a *amazonS3Writer) Close(context.Context) error {
@@ -0,0 +1,90 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+type s3oTestObject struct {
+	body    string
+	tagging string
+}
+
+func TestS3OutputBatchDelimiter(t *testing.T) {
+	var objectsMut sync.Mutex
+	objects := map[string]s3oTestObject{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			http.Error(w, "unexpected method", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		objectsMut.Lock()
+		objects[r.URL.Path] = s3oTestObject{
+			body:    string(body),
+			tagging: r.Header.Get("X-Amz-Tagging"),
+		}
+		objectsMut.Unlock()
+	}))
+	t.Cleanup(server.Close)
+
+	pConf, err := s3oOutputSpec().ParseYAML(fmt.Sprintf(`
+bucket: foo
+path: 'batches/${! meta("id") }-${! batch_size() }-${! content() }.txt'
+tags:
+  first: '${! content() }'
+batch_delimiter: "\n"
+force_path_style_urls: true
+region: us-east-1
+endpoint: %v
+credentials:
+  id: xxxxx
+  secret: xxxxx
+`, server.URL), nil)
+	require.NoError(t, err)
+
+	conf, err := s3oConfigFromParsed(pConf)
+	require.NoError(t, err)
+
+	w, err := newAmazonS3Writer(conf, service.MockResources())
+	require.NoError(t, err)
+
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	require.NoError(t, w.Connect(ctx))
+
+	var batch service.MessageBatch
+	for _, c := range []string{"a", "b", "c"} {
+		m := service.NewMessage([]byte(c))
+		m.MetaSetMut("id", c+"id")
+		batch = append(batch, m)
+	}
+	require.NoError(t, w.WriteBatch(ctx, batch))
+
+	objectsMut.Lock()
+	defer objectsMut.Unlock()
+
+	assert.Equal(t, map[string]s3oTestObject{
+		"/foo/batches/aid-3-a.txt": {
+			body:    "a\nb\nc",
+			tagging: "first=a",
+		},
+	}, objects)
+}
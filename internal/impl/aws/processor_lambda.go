@@ -9,6 +9,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/cenkalti/backoff/v4"
 
 	"github.com/benthosdev/benthos/v4/internal/impl/aws/config"
 	"github.com/benthosdev/benthos/v4/public/service"
@@ -24,23 +25,15 @@ In order to map or encode the payload to a specific request body, and map the re
 
 ### Error Handling
 
-When Benthos is unable to connect to the AWS endpoint or is otherwise unable to invoke the target lambda function it will retry the request according to the configured number of retries. Once these attempts have been exhausted the failed message will continue through the pipeline with it's contents unchanged, but flagged as having failed, allowing you to use [standard processor error handling patterns](/docs/configuration/error_handling).
+When Benthos is unable to connect to the AWS endpoint or is otherwise unable to invoke the target lambda function it will retry the request according to the configured number of retries, backing off exponentially between attempts. Once these attempts have been exhausted the failed message will continue through the pipeline with it's contents unchanged, but flagged as having failed, allowing you to use [standard processor error handling patterns](/docs/configuration/error_handling).
 
-However, if the invocation of the function is successful but the function itself throws an error, then the message will have it's contents updated with a JSON payload describing the reason for the failure, and a metadata field `+"`lambda_function_error`"+` will be added to the message allowing you to detect and handle function errors with a `+"[`branch`](/docs/components/processors/branch)"+`:
+However, if the invocation of the function is successful but the function itself throws an error, then the request is not retried. Instead the message will have it's contents updated with a JSON payload describing the reason for the failure, a metadata field `+"`lambda_function_error`"+` will be added to the message, and the message will be flagged as having failed, allowing you to route function errors elsewhere:
 
 `+"```yaml"+`
 pipeline:
   processors:
-    - branch:
-        processors:
-          - aws_lambda:
-              function: foo
-        result_map: |
-          root = if meta().exists("lambda_function_error") {
-            throw("Invocation failed due to %v: %v".format(this.errorType, this.errorMessage))
-          } else {
-            this
-          }
+    - aws_lambda:
+        function: foo
 output:
   switch:
     retry_until_success: false
@@ -74,6 +67,11 @@ pipeline:
 		Field(service.NewBoolField("parallel").
 			Description("Whether messages of a batch should be dispatched in parallel.").
 			Default(false)).
+		Field(service.NewIntField("max_parallel").
+			Description("When `parallel` is enabled, the maximum number of messages of a batch to invoke the function with concurrently. When set to zero the function is invoked for all messages of a batch at once.").
+			Default(0).
+			Advanced().
+			Version("4.28.0")).
 		Field(service.NewStringField("function").
 			Description("The function to invoke.")).
 		Field(service.NewStringField("rate_limit").
@@ -107,6 +105,11 @@ pipeline:
 				return nil, err
 			}
 
+			maxParallel, err := conf.FieldInt("max_parallel")
+			if err != nil {
+				return nil, err
+			}
+
 			function, err := conf.FieldString("function")
 			if err != nil {
 				return nil, err
@@ -127,7 +130,7 @@ pipeline:
 				return nil, err
 			}
 
-			return newLambdaProc(lambda.NewFromConfig(aconf), parallel, function, numRetries, rateLimit, timeout, mgr)
+			return newLambdaProc(lambda.NewFromConfig(aconf), parallel, maxParallel, function, numRetries, rateLimit, timeout, mgr)
 		})
 	if err != nil {
 		panic(err)
@@ -141,8 +144,9 @@ type lambdaAPI interface {
 }
 
 type lambdaProc struct {
-	client   *lambdaClient
-	parallel bool
+	client      *lambdaClient
+	parallel    bool
+	maxParallel int
 
 	functionName string
 	log          *service.Logger
@@ -151,6 +155,7 @@ type lambdaProc struct {
 func newLambdaProc(
 	lambda lambdaAPI,
 	parallel bool,
+	maxParallel int,
 	function string,
 	numRetries int,
 	rateLimit string,
@@ -161,6 +166,10 @@ func newLambdaProc(
 		functionName: function,
 		log:          mgr.Logger(),
 		parallel:     parallel,
+		maxParallel:  maxParallel,
+	}
+	if maxParallel < 0 {
+		return nil, fmt.Errorf("max_parallel must not be negative, got %v", maxParallel)
 	}
 	var err error
 	if l.client, err = newLambdaClient(lambda, function, numRetries, rateLimit, timeout, mgr); err != nil {
@@ -174,23 +183,32 @@ func newLambdaProc(
 func (l *lambdaProc) ProcessBatch(ctx context.Context, batch service.MessageBatch) ([]service.MessageBatch, error) {
 	if !l.parallel || len(batch) == 1 {
 		for _, p := range batch {
-			if err := l.client.InvokeV2(p); err != nil {
+			if err := l.client.InvokeV2(ctx, p); err != nil {
 				l.log.Errorf("Lambda function '%v' failed: %v\n", l.functionName, err)
 				p.SetError(err)
 			}
 		}
 	} else {
+		var sem chan struct{}
+		if l.maxParallel > 0 {
+			sem = make(chan struct{}, l.maxParallel)
+		}
+
 		wg := sync.WaitGroup{}
 		wg.Add(len(batch))
 
 		for i := 0; i < len(batch); i++ {
 			go func(index int) {
-				err := l.client.InvokeV2(batch[index])
+				defer wg.Done()
+				if sem != nil {
+					sem <- struct{}{}
+					defer func() { <-sem }()
+				}
+				err := l.client.InvokeV2(ctx, batch[index])
 				if err != nil {
 					l.log.Errorf("Lambda parallel request to '%v' failed: %v\n", l.functionName, err)
 					batch[index].SetError(err)
 				}
-				wg.Done()
 			}(i)
 		}
 
@@ -216,6 +234,7 @@ type lambdaClient struct {
 	retries   int
 	rateLimit string
 	timeout   time.Duration
+	boffCtor  func() backoff.BackOff
 }
 
 func newLambdaClient(
@@ -234,6 +253,13 @@ func newLambdaClient(
 		retries:   numRetries,
 		rateLimit: rateLimit,
 		timeout:   timeout,
+		boffCtor: func() backoff.BackOff {
+			boff := backoff.NewExponentialBackOff()
+			boff.InitialInterval = time.Millisecond * 100
+			boff.MaxInterval = time.Second * 5
+			boff.MaxElapsedTime = 0
+			return boff
+		},
 	}
 	if function == "" {
 		return nil, errors.New("lambda function must not be empty")
@@ -266,41 +292,57 @@ func (l *lambdaClient) waitForAccess(ctx context.Context) bool {
 			l.log.Errorf("Rate limit error: %v\n", err)
 			period = time.Second
 		}
-		if period > 0 {
-			<-time.After(period)
-		} else {
+		if period <= 0 {
 			return true
 		}
+		select {
+		case <-time.After(period):
+		case <-ctx.Done():
+			return false
+		}
 	}
 }
 
-func (l *lambdaClient) InvokeV2(p *service.Message) error {
+func (l *lambdaClient) InvokeV2(ctx context.Context, p *service.Message) error {
 	remainingRetries := l.retries
+	var boff backoff.BackOff
 	for {
-		l.waitForAccess(context.Background())
+		if !l.waitForAccess(ctx) {
+			return ctx.Err()
+		}
 
 		mBytes, err := p.AsBytes()
 		if err != nil {
 			return err
 		}
 
-		ctx, done := context.WithTimeout(context.Background(), l.timeout)
-		result, err := l.lambda.Invoke(ctx, &lambda.InvokeInput{
+		invokeCtx, done := context.WithTimeout(ctx, l.timeout)
+		result, err := l.lambda.Invoke(invokeCtx, &lambda.InvokeInput{
 			FunctionName: aws.String(l.function),
 			Payload:      mBytes,
 		})
 		done()
 		if err == nil {
+			p.SetBytes(result.Payload)
 			if result.FunctionError != nil {
 				p.MetaSet("lambda_function_error", *result.FunctionError)
+				return fmt.Errorf("function error: %v", *result.FunctionError)
 			}
-			p.SetBytes(result.Payload)
 			return nil
 		}
 
 		remainingRetries--
-		if remainingRetries < 0 {
+		if remainingRetries < 0 || ctx.Err() != nil {
 			return err
 		}
+
+		if boff == nil {
+			boff = l.boffCtor()
+		}
+		select {
+		case <-time.After(boff.NextBackOff()):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
 }
@@ -3,10 +3,13 @@ package aws
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/cenkalti/backoff/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -29,8 +32,11 @@ func TestLambdaErrors(t *testing.T) {
 		},
 	}
 
-	p, err := newLambdaProc(mock, false, "foofn", 3, "", time.Second, service.MockResources())
+	p, err := newLambdaProc(mock, false, 0, "foofn", 3, "", time.Second, service.MockResources())
 	require.NoError(t, err)
+	p.client.boffCtor = func() backoff.BackOff {
+		return backoff.NewConstantBackOff(time.Millisecond)
+	}
 
 	bCtx := context.Background()
 	inBatch := service.MessageBatch{
@@ -49,8 +55,11 @@ func TestLambdaErrors(t *testing.T) {
 	assert.EqualError(t, outBatches[0][1].GetError(), "meow bar")
 	assert.EqualError(t, outBatches[0][2].GetError(), "meow baz")
 
-	p, err = newLambdaProc(mock, true, "foofn", 3, "", time.Second, service.MockResources())
+	p, err = newLambdaProc(mock, true, 0, "foofn", 3, "", time.Second, service.MockResources())
 	require.NoError(t, err)
+	p.client.boffCtor = func() backoff.BackOff {
+		return backoff.NewConstantBackOff(time.Millisecond)
+	}
 
 	outBatches, err = p.ProcessBatch(bCtx, inBatch)
 	require.NoError(t, err)
@@ -73,7 +82,7 @@ func TestLambdaMutations(t *testing.T) {
 		},
 	}
 
-	p, err := newLambdaProc(mock, false, "foofn", 3, "", time.Second, service.MockResources())
+	p, err := newLambdaProc(mock, false, 0, "foofn", 3, "", time.Second, service.MockResources())
 	require.NoError(t, err)
 
 	bCtx := context.Background()
@@ -104,7 +113,7 @@ func TestLambdaMutations(t *testing.T) {
 	b, _ = inBatch[2].AsBytes()
 	assert.Equal(t, "baz", string(b))
 
-	p, err = newLambdaProc(mock, true, "foofn", 3, "", time.Second, service.MockResources())
+	p, err = newLambdaProc(mock, true, 0, "foofn", 3, "", time.Second, service.MockResources())
 	require.NoError(t, err)
 
 	outBatches, err = p.ProcessBatch(bCtx, inBatch.Copy())
@@ -128,3 +137,136 @@ func TestLambdaMutations(t *testing.T) {
 	b, _ = inBatch[2].AsBytes()
 	assert.Equal(t, "baz", string(b))
 }
+
+func TestLambdaMaxParallel(t *testing.T) {
+	var inFlight, maxInFlight int32
+	mock := &mockLambda{
+		fn: func(ii *lambda.InvokeInput) (*lambda.InvokeOutput, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				prev := atomic.LoadInt32(&maxInFlight)
+				if n <= prev || atomic.CompareAndSwapInt32(&maxInFlight, prev, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond * 10)
+			return &lambda.InvokeOutput{
+				Payload: []byte("meow " + string(ii.Payload)),
+			}, nil
+		},
+	}
+
+	p, err := newLambdaProc(mock, true, 2, "foofn", 3, "", time.Second, service.MockResources())
+	require.NoError(t, err)
+
+	var inBatch service.MessageBatch
+	for i := 0; i < 6; i++ {
+		inBatch = append(inBatch, service.NewMessage([]byte("foo")))
+	}
+
+	outBatches, err := p.ProcessBatch(context.Background(), inBatch)
+	require.NoError(t, err)
+	require.Len(t, outBatches, 1)
+	require.Len(t, outBatches[0], 6)
+
+	for _, m := range outBatches[0] {
+		require.NoError(t, m.GetError())
+		b, _ := m.AsBytes()
+		assert.Equal(t, "meow foo", string(b))
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+}
+
+func TestLambdaCancelled(t *testing.T) {
+	var calls int32
+	mock := &mockLambda{
+		fn: func(ii *lambda.InvokeInput) (*lambda.InvokeOutput, error) {
+			atomic.AddInt32(&calls, 1)
+			return nil, errors.New("nope")
+		},
+	}
+
+	p, err := newLambdaProc(mock, false, 0, "foofn", 10, "", time.Second, service.MockResources())
+	require.NoError(t, err)
+
+	ctx, done := context.WithCancel(context.Background())
+	done()
+
+	outBatches, err := p.ProcessBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("foo")),
+	})
+	require.NoError(t, err)
+	require.Len(t, outBatches, 1)
+	require.Error(t, outBatches[0][0].GetError())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestLambdaRetryBackoff(t *testing.T) {
+	var attempts []time.Time
+	mock := &mockLambda{
+		fn: func(ii *lambda.InvokeInput) (*lambda.InvokeOutput, error) {
+			attempts = append(attempts, time.Now())
+			if len(attempts) < 3 {
+				return nil, errors.New("nope")
+			}
+			return &lambda.InvokeOutput{
+				Payload: []byte("meow " + string(ii.Payload)),
+			}, nil
+		},
+	}
+
+	p, err := newLambdaProc(mock, false, 0, "foofn", 3, "", time.Second, service.MockResources())
+	require.NoError(t, err)
+	p.client.boffCtor = func() backoff.BackOff {
+		return backoff.NewConstantBackOff(time.Millisecond * 50)
+	}
+
+	outBatches, err := p.ProcessBatch(context.Background(), service.MessageBatch{
+		service.NewMessage([]byte("foo")),
+	})
+	require.NoError(t, err)
+	require.Len(t, outBatches, 1)
+	require.NoError(t, outBatches[0][0].GetError())
+
+	b, _ := outBatches[0][0].AsBytes()
+	assert.Equal(t, "meow foo", string(b))
+
+	require.Len(t, attempts, 3)
+	for i := 1; i < len(attempts); i++ {
+		assert.GreaterOrEqual(t, attempts[i].Sub(attempts[i-1]), time.Millisecond*50)
+	}
+}
+
+func TestLambdaFunctionError(t *testing.T) {
+	var calls int32
+	mock := &mockLambda{
+		fn: func(ii *lambda.InvokeInput) (*lambda.InvokeOutput, error) {
+			atomic.AddInt32(&calls, 1)
+			return &lambda.InvokeOutput{
+				FunctionError: aws.String("Unhandled"),
+				Payload:       []byte(`{"errorMessage":"meow"}`),
+			}, nil
+		},
+	}
+
+	p, err := newLambdaProc(mock, false, 0, "foofn", 3, "", time.Second, service.MockResources())
+	require.NoError(t, err)
+
+	outBatches, err := p.ProcessBatch(context.Background(), service.MessageBatch{
+		service.NewMessage([]byte("foo")),
+	})
+	require.NoError(t, err)
+	require.Len(t, outBatches, 1)
+
+	m := outBatches[0][0]
+	assert.EqualError(t, m.GetError(), "function error: Unhandled")
+
+	v, ok := m.MetaGet("lambda_function_error")
+	require.True(t, ok)
+	assert.Equal(t, "Unhandled", v)
+
+	b, _ := m.AsBytes()
+	assert.Equal(t, `{"errorMessage":"meow"}`, string(b))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
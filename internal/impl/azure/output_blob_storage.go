@@ -5,10 +5,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/appendblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
 
 	"github.com/benthosdev/benthos/v4/public/service"
@@ -19,6 +22,7 @@ const (
 	bsoFieldContainer         = "container"
 	bsoFieldPath              = "path"
 	bsoFieldBlobType          = "blob_type"
+	bsoFieldContentType       = "content_type"
 	bsoFieldPublicAccessLevel = "public_access_level"
 )
 
@@ -27,6 +31,7 @@ type bsoConfig struct {
 	Container         *service.InterpolatedString
 	Path              *service.InterpolatedString
 	BlobType          *service.InterpolatedString
+	ContentType       *service.InterpolatedString
 	PublicAccessLevel *service.InterpolatedString
 }
 
@@ -52,6 +57,9 @@ func bsoConfigFromParsed(pConf *service.ParsedConfig) (conf bsoConfig, err error
 	if conf.BlobType, err = pConf.FieldInterpolatedString(bsoFieldBlobType); err != nil {
 		return
 	}
+	if conf.ContentType, err = pConf.FieldInterpolatedString(bsoFieldContentType); err != nil {
+		return
+	}
 	if conf.PublicAccessLevel, err = pConf.FieldInterpolatedString(bsoFieldPublicAccessLevel); err != nil {
 		return
 	}
@@ -77,7 +85,11 @@ Supports multiple authentication methods but only one of the following is requir
 If multiple are set then the `+"`storage_connection_string`"+` is given priority.
 
 If the `+"`storage_connection_string`"+` does not contain the `+"`AccountName`"+` parameter, please specify it in the
-`+"`storage_account`"+` field.`+service.OutputPerformanceDocs(true, false)).
+`+"`storage_account`"+` field.
+
+### Metrics
+
+The time taken for each upload request, including any retries to create the container or the append blob, is recorded by the timer metric `+"`azure_blob_storage_upload_latency_ns`"+`.`+service.OutputPerformanceDocs(true, false)).
 		Fields(
 			service.NewInterpolatedStringField(bsoFieldContainer).
 				Description("The container for uploading the messages to.").
@@ -92,6 +104,12 @@ If the `+"`storage_connection_string`"+` does not contain the `+"`AccountName`"+
 				Description("Block and Append blobs are comprised of blocks, and each blob can support up to 50,000 blocks. The default value is `+\"`BLOCK`\"+`.`").
 				Advanced().
 				Default("BLOCK"),
+			service.NewInterpolatedStringField(bsoFieldContentType).
+				Description("The content type to set for each blob. For append blobs this is only set when the blob is created.").
+				Example("application/json").
+				Advanced().
+				Version("4.28.0").
+				Default("application/octet-stream"),
 			service.NewInterpolatedStringEnumField(bsoFieldPublicAccessLevel, "PRIVATE", "BLOB", "CONTAINER").
 				Description(`The container's public access level. The default value is `+"`PRIVATE`"+`.`).
 				Advanced().
@@ -110,7 +128,7 @@ func init() {
 			if mif, err = conf.FieldMaxInFlight(); err != nil {
 				return
 			}
-			if out, err = newAzureBlobStorageWriter(pConf, mgr.Logger()); err != nil {
+			if out, err = newAzureBlobStorageWriter(pConf, mgr); err != nil {
 				return
 			}
 			return
@@ -123,12 +141,15 @@ func init() {
 type azureBlobStorageWriter struct {
 	conf bsoConfig
 	log  *service.Logger
+
+	mLatency *service.MetricTimer
 }
 
-func newAzureBlobStorageWriter(conf bsoConfig, log *service.Logger) (*azureBlobStorageWriter, error) {
+func newAzureBlobStorageWriter(conf bsoConfig, mgr *service.Resources) (*azureBlobStorageWriter, error) {
 	a := &azureBlobStorageWriter{
-		conf: conf,
-		log:  log,
+		conf:     conf,
+		log:      mgr.Logger(),
+		mLatency: mgr.Metrics().NewTimer("azure_blob_storage_upload_latency_ns"),
 	}
 	return a, nil
 }
@@ -137,15 +158,16 @@ func (a *azureBlobStorageWriter) Connect(ctx context.Context) error {
 	return nil
 }
 
-func (a *azureBlobStorageWriter) uploadBlob(ctx context.Context, containerName, blobName, blobType string, message []byte) error {
+func (a *azureBlobStorageWriter) uploadBlob(ctx context.Context, containerName, blobName, blobType, contentType string, message []byte) error {
 	containerClient := a.conf.client.ServiceClient().NewContainerClient(containerName)
+	headers := &blob.HTTPHeaders{BlobContentType: &contentType}
 	var err error
 	if blobType == "APPEND" {
 		appendBlobClient := containerClient.NewAppendBlobClient(blobName)
 		_, err = appendBlobClient.AppendBlock(ctx, streaming.NopCloser(bytes.NewReader(message)), nil)
 		if err != nil {
 			if isErrorCode(err, bloberror.BlobNotFound) {
-				_, err := appendBlobClient.Create(ctx, nil)
+				_, err := appendBlobClient.Create(ctx, &appendblob.CreateOptions{HTTPHeaders: headers})
 				if err != nil && !isErrorCode(err, bloberror.BlobAlreadyExists) {
 					return fmt.Errorf("failed to create append blob: %w", err)
 				}
@@ -160,7 +182,7 @@ func (a *azureBlobStorageWriter) uploadBlob(ctx context.Context, containerName,
 			}
 		}
 	} else {
-		_, err = containerClient.NewBlockBlobClient(blobName).UploadStream(ctx, bytes.NewReader(message), nil)
+		_, err = containerClient.NewBlockBlobClient(blobName).UploadStream(ctx, bytes.NewReader(message), &azblob.UploadStreamOptions{HTTPHeaders: headers})
 		if err != nil {
 			return fmt.Errorf("failed to push block to blob: %w", err)
 		}
@@ -198,12 +220,22 @@ func (a *azureBlobStorageWriter) Write(ctx context.Context, msg *service.Message
 		return fmt.Errorf("blob type interpolation error: %s", err)
 	}
 
+	contentType, err := a.conf.ContentType.TryString(msg)
+	if err != nil {
+		return fmt.Errorf("content type interpolation error: %s", err)
+	}
+
 	mBytes, err := msg.AsBytes()
 	if err != nil {
 		return err
 	}
 
-	if err := a.uploadBlob(ctx, containerName, blobName, blobType, mBytes); err != nil {
+	startedAt := time.Now()
+	defer func() {
+		a.mLatency.Timing(time.Since(startedAt).Nanoseconds())
+	}()
+
+	if err := a.uploadBlob(ctx, containerName, blobName, blobType, contentType, mBytes); err != nil {
 		if isErrorCode(err, bloberror.ContainerNotFound) {
 			var accessLevel string
 			if accessLevel, err = a.conf.PublicAccessLevel.TryString(msg); err != nil {
@@ -216,7 +248,7 @@ func (a *azureBlobStorageWriter) Write(ctx context.Context, msg *service.Message
 				}
 			}
 
-			if err := a.uploadBlob(ctx, containerName, blobName, blobType, mBytes); err != nil {
+			if err := a.uploadBlob(ctx, containerName, blobName, blobType, contentType, mBytes); err != nil {
 				return fmt.Errorf("error retrying to upload blob: %s", err)
 			}
 		} else {
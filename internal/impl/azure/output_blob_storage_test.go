@@ -0,0 +1,147 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
+	"github.com/benthosdev/benthos/v4/internal/manager/mock"
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+type blobStorageTestRequest struct {
+	comp        string
+	blobType    string
+	contentType string
+}
+
+func testBlobStorageServer(t *testing.T) (*httptest.Server, func() []blobStorageTestRequest) {
+	t.Helper()
+
+	var reqsMut sync.Mutex
+	var reqs []blobStorageTestRequest
+	appendBlobs := map[string]struct{}{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+
+		reqsMut.Lock()
+		defer reqsMut.Unlock()
+
+		req := blobStorageTestRequest{
+			comp:        r.URL.Query().Get("comp"),
+			blobType:    r.Header.Get("x-ms-blob-type"),
+			contentType: r.Header.Get("x-ms-blob-content-type"),
+		}
+		reqs = append(reqs, req)
+
+		switch {
+		case req.comp == "appendblock":
+			if _, exists := appendBlobs[r.URL.Path]; !exists {
+				w.Header().Set("x-ms-error-code", "BlobNotFound")
+				http.Error(w, "blob not found", http.StatusNotFound)
+				return
+			}
+		case req.blobType == "AppendBlob":
+			appendBlobs[r.URL.Path] = struct{}{}
+		}
+
+		w.Header().Set("ETag", `"0x1"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []blobStorageTestRequest {
+		reqsMut.Lock()
+		defer reqsMut.Unlock()
+		return append([]blobStorageTestRequest(nil), reqs...)
+	}
+}
+
+func testBlobStorageWriter(t *testing.T, server *httptest.Server, extra string, stats metrics.Type) *azureBlobStorageWriter {
+	t.Helper()
+
+	port := server.Listener.Addr().(*net.TCPAddr).Port
+
+	pConf, err := bsoSpec().ParseYAML(fmt.Sprintf(`
+storage_connection_string: %q
+container: foo
+path: bar.json
+%v
+`, getEmulatorConnectionString(fmt.Sprint(port), "1", "1"), extra), nil)
+	require.NoError(t, err)
+
+	conf, err := bsoConfigFromParsed(pConf)
+	require.NoError(t, err)
+
+	w, err := newAzureBlobStorageWriter(conf, service.MockResources(func(m *mock.Manager) {
+		m.M = stats
+	}))
+	require.NoError(t, err)
+	return w
+}
+
+func TestAzureBlobStorageOutputContentType(t *testing.T) {
+	server, getReqs := testBlobStorageServer(t)
+
+	stats := metrics.NewLocal()
+	w := testBlobStorageWriter(t, server, `
+content_type: application/json
+`, stats)
+
+	require.NoError(t, w.Write(context.Background(), service.NewMessage([]byte(`{"hello":"world"}`))))
+
+	assert.Equal(t, []blobStorageTestRequest{
+		{blobType: "BlockBlob", contentType: "application/json"},
+	}, getReqs())
+
+	timing, exists := stats.GetTimings()["azure_blob_storage_upload_latency_ns"]
+	require.True(t, exists)
+	assert.Equal(t, int64(1), timing.Count())
+}
+
+func TestAzureBlobStorageOutputAppendContentType(t *testing.T) {
+	server, getReqs := testBlobStorageServer(t)
+
+	w := testBlobStorageWriter(t, server, `
+blob_type: APPEND
+content_type: ${! meta("content_type") }
+`, metrics.Noop())
+
+	msg := service.NewMessage([]byte(`hello world`))
+	msg.MetaSetMut("content_type", "text/plain")
+	require.NoError(t, w.Write(context.Background(), msg))
+
+	// The first append fails as the blob does not exist, it is then created
+	// with the content type and the append is retried.
+	assert.Equal(t, []blobStorageTestRequest{
+		{comp: "appendblock"},
+		{blobType: "AppendBlob", contentType: "text/plain"},
+		{comp: "appendblock"},
+	}, getReqs())
+}
+
+func TestAzureBlobStorageOutputContentTypeInterpolationError(t *testing.T) {
+	server, getReqs := testBlobStorageServer(t)
+
+	w := testBlobStorageWriter(t, server, `
+content_type: ${! meta("content_type").not_null() }
+`, metrics.Noop())
+
+	err := w.Write(context.Background(), service.NewMessage([]byte(`hello world`)))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "content type interpolation error")
+	assert.Empty(t, getReqs())
+}
@@ -14,15 +14,17 @@ import (
 
 const (
 	// Queue Storage Output Fields
-	qsoFieldQueueName = "queue_name"
-	qsoFieldTTL       = "ttl"
-	qsoFieldBatching  = "batching"
+	qsoFieldQueueName         = "queue_name"
+	qsoFieldTTL               = "ttl"
+	qsoFieldVisibilityTimeout = "visibility_timeout"
+	qsoFieldBatching          = "batching"
 )
 
 type qsoConfig struct {
-	client    *azqueue.ServiceClient
-	QueueName *service.InterpolatedString
-	TTL       *service.InterpolatedString
+	client            *azqueue.ServiceClient
+	QueueName         *service.InterpolatedString
+	TTL               *service.InterpolatedString
+	VisibilityTimeout *service.InterpolatedString
 }
 
 func qsoConfigFromParsed(pConf *service.ParsedConfig) (conf qsoConfig, err error) {
@@ -35,6 +37,9 @@ func qsoConfigFromParsed(pConf *service.ParsedConfig) (conf qsoConfig, err error
 	if conf.TTL, err = pConf.FieldInterpolatedString(qsoFieldTTL); err != nil {
 		return
 	}
+	if conf.VisibilityTimeout, err = pConf.FieldInterpolatedString(qsoFieldVisibilityTimeout); err != nil {
+		return
+	}
 	return
 }
 
@@ -46,7 +51,11 @@ func qsoSpec() *service.ConfigSpec {
 		Description(`
 Only one authentication method is required, `+"`storage_connection_string`"+` or `+"`storage_account` and `storage_access_key`"+`. If both are set then the `+"`storage_connection_string`"+` is given priority.
 
-In order to set the `+"`queue_name`"+` you can use function interpolations described [here](/docs/configuration/interpolation#bloblang-queries), which are calculated per message of a batch.`+service.OutputPerformanceDocs(true, true)).
+In order to set the `+"`queue_name`"+` you can use function interpolations described [here](/docs/configuration/interpolation#bloblang-queries), which are calculated per message of a batch.
+
+### Metrics
+
+The time taken for each enqueue request, including any retry after creating a missing queue, is recorded by the timer metric `+"`azure_queue_storage_enqueue_latency_ns`"+`.`+service.OutputPerformanceDocs(true, true)).
 		Fields(
 			service.NewInterpolatedStringField(qsoFieldQueueName).
 				Description("The name of the target Queue Storage queue."),
@@ -55,6 +64,12 @@ In order to set the `+"`queue_name`"+` you can use function interpolations descr
 				Example("60s").Example("5m").Example("36h").
 				Advanced().
 				Default(""),
+			service.NewInterpolatedStringField(qsoFieldVisibilityTimeout).
+				Description("The period of time each individual message is hidden from consumers after being enqueued as a duration string. Defaults to 0, meaning messages are visible immediately. This must be smaller than the `ttl` of the message when one is set.").
+				Example("30s").Example("10m").
+				Advanced().
+				Version("4.28.0").
+				Default(""),
 			service.NewOutputMaxInFlightField().
 				Description("The maximum number of parallel message batches to have in flight at any given time."),
 			service.NewBatchPolicyField(qsoFieldBatching),
@@ -74,7 +89,7 @@ func init() {
 			if mif, err = conf.FieldMaxInFlight(); err != nil {
 				return
 			}
-			if out, err = newAzureQueueStorageWriter(pConf, mgr.Logger()); err != nil {
+			if out, err = newAzureQueueStorageWriter(pConf, mgr); err != nil {
 				return
 			}
 			return
@@ -87,12 +102,15 @@ func init() {
 type azureQueueStorageWriter struct {
 	conf qsoConfig
 	log  *service.Logger
+
+	mLatency *service.MetricTimer
 }
 
-func newAzureQueueStorageWriter(conf qsoConfig, log *service.Logger) (*azureQueueStorageWriter, error) {
+func newAzureQueueStorageWriter(conf qsoConfig, mgr *service.Resources) (*azureQueueStorageWriter, error) {
 	s := &azureQueueStorageWriter{
-		conf: conf,
-		log:  log,
+		conf:     conf,
+		log:      mgr.Logger(),
+		mLatency: mgr.Metrics().NewTimer("azure_queue_storage_enqueue_latency_ns"),
 	}
 	return s, nil
 }
@@ -131,12 +149,35 @@ func (a *azureQueueStorageWriter) WriteBatch(ctx context.Context, batch service.
 			return nil
 		}()
 
+		visibilityStr, err := batch.TryInterpolatedString(i, a.conf.VisibilityTimeout)
+		if err != nil {
+			return fmt.Errorf("visibility timeout interpolation error: %w", err)
+		}
+
+		var visibilityTimeout *int32
+		if visibilityStr != "" {
+			vd, err := time.ParseDuration(visibilityStr)
+			if err != nil {
+				return fmt.Errorf("visibility timeout must be a duration: %w", err)
+			}
+			vSeconds := int32(vd.Seconds())
+			visibilityTimeout = &vSeconds
+		}
+
 		mBytes, err := msg.AsBytes()
 		if err != nil {
 			return err
 		}
 		message := string(mBytes)
-		opts := &azqueue.EnqueueMessageOptions{TimeToLive: timeToLive}
+		opts := &azqueue.EnqueueMessageOptions{
+			TimeToLive:        timeToLive,
+			VisibilityTimeout: visibilityTimeout,
+		}
+
+		startedAt := time.Now()
+		defer func() {
+			a.mLatency.Timing(time.Since(startedAt).Nanoseconds())
+		}()
 		if _, err = queue.EnqueueMessage(ctx, message, opts); err != nil {
 			if cerr, ok := err.(*azcore.ResponseError); ok {
 				if cerr.StatusCode == http.StatusNotFound {
@@ -0,0 +1,118 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
+	"github.com/benthosdev/benthos/v4/internal/manager/mock"
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func testQueueStorageServer(t *testing.T) (*httptest.Server, func() []url.Values) {
+	t.Helper()
+
+	var reqsMut sync.Mutex
+	var reqs []url.Values
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/messages") {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+
+		reqsMut.Lock()
+		reqs = append(reqs, r.URL.Query())
+		reqsMut.Unlock()
+
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?>
+<QueueMessagesList>
+  <QueueMessage>
+    <MessageId>foo</MessageId>
+    <InsertionTime>Mon, 02 Jan 2006 15:04:05 GMT</InsertionTime>
+    <ExpirationTime>Mon, 02 Jan 2006 15:05:05 GMT</ExpirationTime>
+    <PopReceipt>bar</PopReceipt>
+    <TimeNextVisible>Mon, 02 Jan 2006 15:04:35 GMT</TimeNextVisible>
+  </QueueMessage>
+</QueueMessagesList>`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []url.Values {
+		reqsMut.Lock()
+		defer reqsMut.Unlock()
+		return reqs
+	}
+}
+
+func testQueueStorageWriter(t *testing.T, server *httptest.Server, extra string, stats metrics.Type) *azureQueueStorageWriter {
+	t.Helper()
+
+	port := server.Listener.Addr().(*net.TCPAddr).Port
+
+	pConf, err := qsoSpec().ParseYAML(fmt.Sprintf(`
+storage_connection_string: %q
+queue_name: foo
+%v
+`, getEmulatorConnectionString("1", fmt.Sprint(port), "1"), extra), nil)
+	require.NoError(t, err)
+
+	conf, err := qsoConfigFromParsed(pConf)
+	require.NoError(t, err)
+
+	w, err := newAzureQueueStorageWriter(conf, service.MockResources(func(m *mock.Manager) {
+		m.M = stats
+	}))
+	require.NoError(t, err)
+	return w
+}
+
+func TestAzureQueueStorageOutputVisibilityTimeout(t *testing.T) {
+	server, getReqs := testQueueStorageServer(t)
+
+	stats := metrics.NewLocal()
+	w := testQueueStorageWriter(t, server, `
+ttl: 60s
+visibility_timeout: ${! meta("visibility") }
+`, stats)
+
+	msg := service.NewMessage([]byte("hello world"))
+	msg.MetaSetMut("visibility", "30s")
+	require.NoError(t, w.WriteBatch(context.Background(), service.MessageBatch{msg}))
+
+	reqs := getReqs()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "60", reqs[0].Get("messagettl"))
+	assert.Equal(t, "30", reqs[0].Get("visibilitytimeout"))
+
+	timing, exists := stats.GetTimings()["azure_queue_storage_enqueue_latency_ns"]
+	require.True(t, exists)
+	assert.Equal(t, int64(1), timing.Count())
+}
+
+func TestAzureQueueStorageOutputBadVisibilityTimeout(t *testing.T) {
+	server, getReqs := testQueueStorageServer(t)
+
+	w := testQueueStorageWriter(t, server, `
+visibility_timeout: nope
+`, metrics.Noop())
+
+	err := w.WriteBatch(context.Background(), service.MessageBatch{
+		service.NewMessage([]byte("hello world")),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "visibility timeout must be a duration")
+	assert.Empty(t, getReqs())
+}
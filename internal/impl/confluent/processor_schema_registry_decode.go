@@ -48,7 +48,13 @@ This processor decodes protobuf messages to JSON documents, you can read more ab
 		Field(service.NewBoolField("avro_raw_json").
 			Description("Whether Avro messages should be decoded into normal JSON (\"json that meets the expectations of regular internet json\") rather than [Avro JSON](https://avro.apache.org/docs/current/specification/_print/#json-encoding). If `true` the schema returned from the subject should be decoded as [standard json](https://pkg.go.dev/github.com/linkedin/goavro/v2#NewCodecForStandardJSONFull) instead of as [avro json](https://pkg.go.dev/github.com/linkedin/goavro/v2#NewCodec). There is a [comment in goavro](https://github.com/linkedin/goavro/blob/5ec5a5ee7ec82e16e6e2b438d610e1cab2588393/union.go#L224-L249), the [underlining library used for avro serialization](https://github.com/linkedin/goavro), that explains in more detail the difference between the standard json and avro json.").
 			Advanced().Default(false)).
-		Field(service.NewURLField("url").Description("The base URL of the schema registry service."))
+		Field(service.NewURLField("url").Description("The base URL of the schema registry service.")).
+		Field(service.NewDurationField("cache_duration").
+			Description("The duration after which a schema that has not been used is purged from the cache, and must therefore be obtained from the schema registry service again when next required.").
+			Advanced().
+			Default("10m").
+			Example("1h").
+			Version("4.28.0"))
 
 	for _, f := range service.NewHTTPRequestAuthSignerFields() {
 		spec = spec.Field(f.Version("4.7.0"))
@@ -71,8 +77,9 @@ func init() {
 //------------------------------------------------------------------------------
 
 type schemaRegistryDecoder struct {
-	avroRawJSON bool
-	client      *schemaRegistryClient
+	avroRawJSON   bool
+	cacheDuration time.Duration
+	client        *schemaRegistryClient
 
 	schemas    map[int]*cachedSchemaDecoder
 	cacheMut   sync.RWMutex
@@ -100,7 +107,11 @@ func newSchemaRegistryDecoderFromConfig(conf *service.ParsedConfig, mgr *service
 	if err != nil {
 		return nil, err
 	}
-	return newSchemaRegistryDecoder(urlStr, authSigner, tlsConf, avroRawJSON, mgr)
+	cacheDuration, err := conf.FieldDuration("cache_duration")
+	if err != nil {
+		return nil, err
+	}
+	return newSchemaRegistryDecoder(urlStr, authSigner, tlsConf, avroRawJSON, cacheDuration, mgr)
 }
 
 func newSchemaRegistryDecoder(
@@ -108,14 +119,16 @@ func newSchemaRegistryDecoder(
 	reqSigner func(f fs.FS, req *http.Request) error,
 	tlsConf *tls.Config,
 	avroRawJSON bool,
+	cacheDuration time.Duration,
 	mgr *service.Resources,
 ) (*schemaRegistryDecoder, error) {
 	s := &schemaRegistryDecoder{
-		avroRawJSON: avroRawJSON,
-		schemas:     map[int]*cachedSchemaDecoder{},
-		shutSig:     shutdown.NewSignaller(),
-		logger:      mgr.Logger(),
-		mgr:         mgr,
+		avroRawJSON:   avroRawJSON,
+		cacheDuration: cacheDuration,
+		schemas:       map[int]*cachedSchemaDecoder{},
+		shutSig:       shutdown.NewSignaller(),
+		logger:        mgr.Logger(),
+		mgr:           mgr,
 	}
 	var err error
 	if s.client, err = newSchemaRegistryClient(urlStr, reqSigner, tlsConf, mgr); err != nil {
@@ -190,6 +203,10 @@ func extractID(b []byte) (id int, remaining []byte, err error) {
 		err = fmt.Errorf("serialization format version number %v not supported", b[0])
 		return
 	}
+	if len(b) < 5 {
+		err = fmt.Errorf("message length %v is too short to contain a schema ID", len(b))
+		return
+	}
 	id = int(binary.BigEndian.Uint32(b[1:5]))
 	remaining = b[5:]
 	return
@@ -203,7 +220,7 @@ const (
 func (s *schemaRegistryDecoder) clearExpired() {
 	// First pass in read only mode to gather candidates
 	s.cacheMut.RLock()
-	targetTime := time.Now().Add(-schemaStaleAfter).Unix()
+	targetTime := time.Now().Add(-s.cacheDuration).Unix()
 	var targets []int
 	for k, v := range s.schemas {
 		if atomic.LoadInt64(&v.lastUsedUnixSeconds) < targetTime {
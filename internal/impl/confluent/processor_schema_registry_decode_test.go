@@ -227,7 +227,7 @@ func TestSchemaRegistryDecodeAvro(t *testing.T) {
 		return nil, nil
 	})
 
-	decoder, err := newSchemaRegistryDecoder(urlStr, noopReqSign, nil, false, service.MockResources())
+	decoder, err := newSchemaRegistryDecoder(urlStr, noopReqSign, nil, false, schemaStaleAfter, service.MockResources())
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -261,6 +261,11 @@ func TestSchemaRegistryDecodeAvro(t *testing.T) {
 			input:       "\x06\x00\x00\x00\x03\x06foo\x02\x06foo\x06bar",
 			errContains: "version number 6 not supported",
 		},
+		{
+			name:        "truncated schema id",
+			input:       "\x00\x00\x03",
+			errContains: "too short to contain a schema ID",
+		},
 		{
 			name:        "non-existing schema",
 			input:       "\x00\x00\x00\x00\x06\x06foo\x02\x06foo\x06bar",
@@ -330,7 +335,7 @@ func TestSchemaRegistryDecodeAvroRawJson(t *testing.T) {
 		return nil, nil
 	})
 
-	decoder, err := newSchemaRegistryDecoder(urlStr, noopReqSign, nil, true, service.MockResources())
+	decoder, err := newSchemaRegistryDecoder(urlStr, noopReqSign, nil, true, schemaStaleAfter, service.MockResources())
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -408,7 +413,7 @@ func TestSchemaRegistryDecodeClearExpired(t *testing.T) {
 		return nil, fmt.Errorf("nope")
 	})
 
-	decoder, err := newSchemaRegistryDecoder(urlStr, noopReqSign, nil, false, service.MockResources())
+	decoder, err := newSchemaRegistryDecoder(urlStr, noopReqSign, nil, false, schemaStaleAfter, service.MockResources())
 	require.NoError(t, err)
 	require.NoError(t, decoder.Close(context.Background()))
 
@@ -434,6 +439,34 @@ func TestSchemaRegistryDecodeClearExpired(t *testing.T) {
 	decoder.cacheMut.Unlock()
 }
 
+func TestSchemaRegistryDecodeClearExpiredCacheDuration(t *testing.T) {
+	urlStr := runSchemaRegistryServer(t, func(path string) ([]byte, error) {
+		return nil, fmt.Errorf("nope")
+	})
+
+	decoder, err := newSchemaRegistryDecoder(urlStr, noopReqSign, nil, false, time.Minute, service.MockResources())
+	require.NoError(t, err)
+	require.NoError(t, decoder.Close(context.Background()))
+
+	tStale := time.Now().Add(-time.Minute * 2).Unix()
+	tNotStale := time.Now().Unix()
+
+	decoder.cacheMut.Lock()
+	decoder.schemas = map[int]*cachedSchemaDecoder{
+		5:  {lastUsedUnixSeconds: tStale},
+		10: {lastUsedUnixSeconds: tNotStale},
+	}
+	decoder.cacheMut.Unlock()
+
+	decoder.clearExpired()
+
+	decoder.cacheMut.Lock()
+	assert.Equal(t, map[int]*cachedSchemaDecoder{
+		10: {lastUsedUnixSeconds: tNotStale},
+	}, decoder.schemas)
+	decoder.cacheMut.Unlock()
+}
+
 func TestSchemaRegistryDecodeProtobuf(t *testing.T) {
 	payload1, err := json.Marshal(struct {
 		Type   string `json:"schemaType"`
@@ -455,7 +488,7 @@ func TestSchemaRegistryDecodeProtobuf(t *testing.T) {
 		return nil, nil
 	})
 
-	decoder, err := newSchemaRegistryDecoder(urlStr, noopReqSign, nil, false, service.MockResources())
+	decoder, err := newSchemaRegistryDecoder(urlStr, noopReqSign, nil, false, schemaStaleAfter, service.MockResources())
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -518,7 +551,7 @@ func TestSchemaRegistryDecodeJson(t *testing.T) {
 		return nil, nil
 	})
 
-	decoder, err := newSchemaRegistryDecoder(urlStr, noopReqSign, nil, false, service.MockResources())
+	decoder, err := newSchemaRegistryDecoder(urlStr, noopReqSign, nil, false, schemaStaleAfter, service.MockResources())
 	require.NoError(t, err)
 
 	tests := []struct {
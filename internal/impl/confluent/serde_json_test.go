@@ -96,7 +96,7 @@ func TestResolveJsonSchema(t *testing.T) {
 			encoder, err := newSchemaRegistryEncoder(urlStr, noopReqSign, nil, subj, true, time.Minute*10, time.Minute, service.MockResources())
 			require.NoError(t, err)
 
-			decoder, err := newSchemaRegistryDecoder(urlStr, noopReqSign, nil, true, service.MockResources())
+			decoder, err := newSchemaRegistryDecoder(urlStr, noopReqSign, nil, true, schemaStaleAfter, service.MockResources())
 			require.NoError(t, err)
 
 			t.Cleanup(func() {
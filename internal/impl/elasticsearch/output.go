@@ -190,6 +190,14 @@ func OutputSpec() *service.ConfigSpec {
 		Description(`
 Both the `+"`id` and `index`"+` fields can be dynamically set using function interpolations described [here](/docs/configuration/interpolation#bloblang-queries). When sending batched messages these interpolations are performed per message part.
 
+### Error Handling
+
+When a bulk request is partially successful only the rejected documents are considered for a retry. Documents rejected with a status code of 429 or within the 5XX range are retried using the configured backoff, whereas any other rejection (such as a mapping error or a version conflict) is considered permanent and fails only the message that caused it, which allows it to be handled individually with patterns such as a `+"[`fallback`](/docs/components/outputs/fallback)"+` output.
+
+### Metrics
+
+This output emits the counters `+"`elasticsearch_docs_indexed`"+`, counting documents that were successfully written, and `+"`elasticsearch_docs_rejected`"+`, counting documents that were rejected permanently.
+
 ### AWS
 
 It's possible to enable AWS connectivity with this output using the `+"`aws`"+` fields. However, you may need to set `+"`sniff` and `healthcheck`"+` to false for connections to succeed.`+service.OutputPerformanceDocs(true, true)).
@@ -278,6 +286,9 @@ type Output struct {
 	log  *service.Logger
 	conf esoConfig
 
+	mIndexed  *service.MetricCounter
+	mRejected *service.MetricCounter
+
 	client *elastic.Client
 }
 
@@ -290,6 +301,9 @@ func OutputFromParsed(pConf *service.ParsedConfig, mgr *service.Resources) (*Out
 	return &Output{
 		log:  mgr.Logger(),
 		conf: conf,
+
+		mIndexed:  mgr.Metrics().NewCounter("elasticsearch_docs_indexed"),
+		mRejected: mgr.Metrics().NewCounter("elasticsearch_docs_rejected"),
 	}, nil
 }
 
@@ -310,6 +324,9 @@ func (e *Output) Connect(ctx context.Context) error {
 }
 
 func shouldRetry(s int) bool {
+	if s == http.StatusTooManyRequests {
+		return true
+	}
 	if s >= 500 && s <= 599 {
 		return true
 	}
@@ -324,6 +341,10 @@ type pendingBulkIndex struct {
 	Type     string
 	Doc      any
 	ID       string
+
+	// The index of the message within the batch that this request was
+	// created from.
+	msgIndex int
 }
 
 func (e *Output) WriteBatch(ctx context.Context, msg service.MessageBatch) error {
@@ -342,7 +363,7 @@ func (e *Output) WriteBatch(ctx context.Context, msg service.MessageBatch) error
 			return fmt.Errorf("failed to marshal message into JSON document: %w", ierr)
 		}
 
-		pbi := &pendingBulkIndex{Doc: jObj}
+		pbi := &pendingBulkIndex{Doc: jObj, msgIndex: i}
 		if pbi.Action, ierr = msg.TryInterpolatedString(i, e.conf.actionStr); ierr != nil {
 			return fmt.Errorf("action interpolation error: %w", ierr)
 		}
@@ -373,6 +394,14 @@ func (e *Output) WriteBatch(ctx context.Context, msg service.MessageBatch) error
 		b.Add(bulkReq)
 	}
 
+	var batchErr *service.BatchError
+	batchErrFailed := func(i int, err error) {
+		if batchErr == nil {
+			batchErr = service.NewBatchError(msg, err)
+		}
+		batchErr.Failed(i, err)
+	}
+
 	lastErrReason := "no reason given"
 	for b.NumberOfActions() != 0 {
 		result, err := b.Do(ctx)
@@ -380,13 +409,15 @@ func (e *Output) WriteBatch(ctx context.Context, msg service.MessageBatch) error
 			return err
 		}
 		if !result.Errors {
-			return nil
+			e.mIndexed.Incr(int64(len(requests)))
+			break
 		}
 
 		var newRequests []*pendingBulkIndex
 		for i, resp := range result.Items {
 			for _, item := range resp {
 				if item.Status >= 200 && item.Status <= 299 {
+					e.mIndexed.Incr(1)
 					continue
 				}
 
@@ -396,15 +427,18 @@ func (e *Output) WriteBatch(ctx context.Context, msg service.MessageBatch) error
 					lastErrReason = fmt.Sprintf("status [%v]: %v", item.Status, reason)
 				}
 
-				e.log.Errorf("Elasticsearch message '%v' rejected with status [%v]: %v\n", item.Id, item.Status, reason)
-				if !shouldRetry(item.Status) {
-					return fmt.Errorf("failed to send message '%v': %v", item.Id, reason)
-				}
-
 				// IMPORTANT: i exactly matches the index of our source requests
 				// and when we re-run our bulk request with errored requests
 				// that must remain true.
 				sourceReq := requests[i]
+
+				e.log.Errorf("Elasticsearch message '%v' rejected with status [%v]: %v\n", item.Id, item.Status, reason)
+				if !shouldRetry(item.Status) {
+					e.mRejected.Incr(1)
+					batchErrFailed(sourceReq.msgIndex, fmt.Errorf("failed to send message '%v': status [%v]: %v", item.Id, item.Status, reason))
+					continue
+				}
+
 				bulkReq, err := e.buildBulkableRequest(sourceReq)
 				if err != nil {
 					return err
@@ -414,10 +448,17 @@ func (e *Output) WriteBatch(ctx context.Context, msg service.MessageBatch) error
 			}
 		}
 		requests = newRequests
+		if len(requests) == 0 {
+			break
+		}
 
 		wait := boff.NextBackOff()
 		if wait == backoff.Stop {
-			return fmt.Errorf("retries exhausted for messages, aborting with last error reported as: %v", lastErrReason)
+			retryErr := fmt.Errorf("retries exhausted for messages, aborting with last error reported as: %v", lastErrReason)
+			for _, r := range requests {
+				batchErrFailed(r.msgIndex, retryErr)
+			}
+			break
 		}
 		select {
 		case <-time.After(wait):
@@ -426,6 +467,9 @@ func (e *Output) WriteBatch(ctx context.Context, msg service.MessageBatch) error
 		}
 	}
 
+	if batchErr != nil {
+		return batchErr
+	}
 	return nil
 }
 
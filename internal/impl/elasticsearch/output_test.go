@@ -0,0 +1,103 @@
+package elasticsearch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestOutputPartialBulkFailure(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempt := atomic.AddInt32(&attempts, 1)
+
+		var items []any
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var action map[string]struct {
+				ID string `json:"_id"`
+			}
+			if !assert.NoError(t, json.Unmarshal(scanner.Bytes(), &action)) {
+				http.Error(w, "bad action line", http.StatusBadRequest)
+				return
+			}
+			id := action["index"].ID
+
+			// Skip the document line
+			if !assert.True(t, scanner.Scan()) {
+				http.Error(w, "missing document line", http.StatusBadRequest)
+				return
+			}
+
+			status := 201
+			switch {
+			case id == "bad":
+				status = 400
+			case id == "busy" && attempt == 1:
+				status = 429
+			}
+			item := map[string]any{"_id": id, "status": status}
+			if status >= 300 {
+				item["error"] = map[string]any{"reason": fmt.Sprintf("nope %v", id)}
+			}
+			items = append(items, map[string]any{"index": item})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"errors": true,
+			"items":  items,
+		})
+	}))
+	defer ts.Close()
+
+	pConf, err := OutputSpec().ParseYAML(fmt.Sprintf(`
+urls: [ %v ]
+index: foo
+id: ${! json("id") }
+sniff: false
+healthcheck: false
+backoff:
+  initial_interval: 1ms
+  max_interval: 1ms
+`, ts.URL), nil)
+	require.NoError(t, err)
+
+	o, err := OutputFromParsed(pConf, service.MockResources())
+	require.NoError(t, err)
+	require.NoError(t, o.Connect(context.Background()))
+
+	var batch service.MessageBatch
+	for _, id := range []string{"good", "bad", "busy"} {
+		batch = append(batch, service.NewMessage([]byte(fmt.Sprintf(`{"id":%q}`, id))))
+	}
+
+	err = o.WriteBatch(context.Background(), batch)
+	require.Error(t, err)
+
+	var bErr *service.BatchError
+	require.True(t, errors.As(err, &bErr), err.Error())
+	assert.Equal(t, 1, bErr.IndexedErrors())
+
+	var failed []int
+	bErr.WalkMessages(func(i int, m *service.Message, err error) bool {
+		if err != nil {
+			failed = append(failed, i)
+			assert.Contains(t, err.Error(), "nope bad")
+		}
+		return true
+	})
+	assert.Equal(t, []int{1}, failed)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"strings"
 	"sync"
+	"time"
 
 	"cloud.google.com/go/pubsub"
 	"google.golang.org/api/option"
@@ -19,6 +20,7 @@ const (
 	pbiFieldEndpoint               = "endpoint"
 	pbiFieldMaxOutstandingMessages = "max_outstanding_messages"
 	pbiFieldMaxOutstandingBytes    = "max_outstanding_bytes"
+	pbiFieldMaxExtension           = "max_extension"
 	pbiFieldSync                   = "sync"
 	pbiFieldCreateSub              = "create_subscription"
 	pbiFieldCreateSubEnabled       = "enabled"
@@ -31,6 +33,7 @@ type pbiConfig struct {
 	Endpoint               string
 	MaxOutstandingMessages int
 	MaxOutstandingBytes    int
+	MaxExtension           time.Duration
 	Sync                   bool
 	CreateEnabled          bool
 	CreateTopicID          string
@@ -52,6 +55,11 @@ func pbiConfigFromParsed(pConf *service.ParsedConfig) (conf pbiConfig, err error
 	if conf.MaxOutstandingBytes, err = pConf.FieldInt(pbiFieldMaxOutstandingBytes); err != nil {
 		return
 	}
+	if pConf.Contains(pbiFieldMaxExtension) {
+		if conf.MaxExtension, err = pConf.FieldDuration(pbiFieldMaxExtension); err != nil {
+			return
+		}
+	}
 	if conf.Sync, err = pConf.FieldBool(pbiFieldSync); err != nil {
 		return
 	}
@@ -82,10 +90,15 @@ This input adds the following metadata fields to each message:
 `+"``` text"+`
 - gcp_pubsub_publish_time_unix - The time at which the message was published to the topic.
 - gcp_pubsub_delivery_attempt - When dead lettering is enabled, this is set to the number of times PubSub has attempted to deliver a message.
+- gcp_pubsub_ordering_key - The ordering key of the message, if one was set when it was published.
 - All message attributes
 `+"```"+`
 
 You can access these metadata fields using [function interpolation](/docs/configuration/interpolation#bloblang-queries).
+
+### Acknowledgements
+
+Messages are only acknowledged once they have been successfully processed and delivered by the pipeline, and if a message fails to be delivered it is nacked so that Pub/Sub redelivers it. Whilst a message is pending the client automatically extends its ack deadline, up to a maximum period that can be set with the field `+"`"+pbiFieldMaxExtension+"`"+`.
 `).
 		Fields(
 			service.NewStringField(pbiFieldProjectID).
@@ -106,6 +119,12 @@ You can access these metadata fields using [function interpolation](/docs/config
 			service.NewIntField(pbiFieldMaxOutstandingBytes).
 				Description("The maximum number of outstanding pending messages to be consumed measured in bytes.").
 				Default(1e9), // pubsub.DefaultReceiveSettings.MaxOutstandingBytes (1G)
+			service.NewDurationField(pbiFieldMaxExtension).
+				Description("The maximum period of time for which the ack deadline of a pending message is automatically extended. By default the client library extends deadlines for up to 60 minutes.").
+				Example("10m").
+				Optional().
+				Advanced().
+				Version("4.28.0"),
 			service.NewObjectField(pbiFieldCreateSub,
 				service.NewBoolField(pbiFieldCreateSubEnabled).
 					Description("Whether to configure subscription or not.").Default(false),
@@ -205,6 +224,9 @@ func (c *gcpPubSubReader) Connect(ignored context.Context) error {
 	sub.ReceiveSettings.MaxOutstandingMessages = c.conf.MaxOutstandingMessages
 	sub.ReceiveSettings.MaxOutstandingBytes = c.conf.MaxOutstandingBytes
 	sub.ReceiveSettings.Synchronous = c.conf.Sync
+	if c.conf.MaxExtension > 0 {
+		sub.ReceiveSettings.MaxExtension = c.conf.MaxExtension
+	}
 
 	subCtx, cancel := context.WithCancel(context.Background())
 	msgsChan := make(chan *pubsub.Message, 1)
@@ -261,6 +283,10 @@ func (c *gcpPubSubReader) Read(ctx context.Context) (*service.Message, service.A
 	}
 	part.MetaSetMut("gcp_pubsub_publish_time_unix", gmsg.PublishTime.Unix())
 
+	if gmsg.OrderingKey != "" {
+		part.MetaSetMut("gcp_pubsub_ordering_key", gmsg.OrderingKey)
+	}
+
 	if gmsg.DeliveryAttempt != nil {
 		part.MetaSetMut("gcp_pubsub_delivery_attempt", *gmsg.DeliveryAttempt)
 	}
@@ -0,0 +1,123 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestPubSubInputConfigMaxExtension(t *testing.T) {
+	pConf, err := pbiSpec().ParseYAML(`
+project: foo
+subscription: bar
+max_extension: 10m
+`, nil)
+	require.NoError(t, err)
+
+	conf, err := pbiConfigFromParsed(pConf)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute*10, conf.MaxExtension)
+
+	pConf, err = pbiSpec().ParseYAML(`
+project: foo
+subscription: bar
+`, nil)
+	require.NoError(t, err)
+
+	// Without a max extension the client library default is kept.
+	conf, err = pbiConfigFromParsed(pConf)
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), conf.MaxExtension)
+
+	pConf, err = pbiSpec().ParseYAML(`
+project: foo
+subscription: bar
+max_extension: nope
+`, nil)
+	require.NoError(t, err)
+
+	_, err = pbiConfigFromParsed(pConf)
+	require.Error(t, err)
+}
+
+func TestPubSubInputReadMetadata(t *testing.T) {
+	msgsChan := make(chan *pubsub.Message, 2)
+	r := &gcpPubSubReader{
+		msgsChan: msgsChan,
+		log:      service.MockResources().Logger(),
+	}
+
+	publishTime := time.Unix(1674567890, 0)
+	deliveryAttempt := 3
+
+	msgsChan <- &pubsub.Message{
+		Data:            []byte("hello world"),
+		Attributes:      map[string]string{"meow": "woof"},
+		PublishTime:     publishTime,
+		OrderingKey:     "key1",
+		DeliveryAttempt: &deliveryAttempt,
+	}
+	msgsChan <- &pubsub.Message{
+		Data:        []byte("unordered"),
+		PublishTime: publishTime,
+	}
+
+	ctx := context.Background()
+
+	msg, ackFn, err := r.Read(ctx)
+	require.NoError(t, err)
+
+	mBytes, err := msg.AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(mBytes))
+
+	for k, exp := range map[string]any{
+		"meow":                         "woof",
+		"gcp_pubsub_publish_time_unix": int64(1674567890),
+		"gcp_pubsub_ordering_key":      "key1",
+		"gcp_pubsub_delivery_attempt":  3,
+	} {
+		v, exists := msg.MetaGetMut(k)
+		assert.True(t, exists, k)
+		assert.Equal(t, exp, v, k)
+	}
+	require.NoError(t, ackFn(ctx, errors.New("nope")))
+
+	msg, ackFn, err = r.Read(ctx)
+	require.NoError(t, err)
+
+	_, exists := msg.MetaGetMut("gcp_pubsub_ordering_key")
+	assert.False(t, exists)
+	_, exists = msg.MetaGetMut("gcp_pubsub_delivery_attempt")
+	assert.False(t, exists)
+	require.NoError(t, ackFn(ctx, nil))
+
+	close(msgsChan)
+	_, _, err = r.Read(ctx)
+	require.ErrorIs(t, err, service.ErrNotConnected)
+}
+
+func TestPubSubInputCreateSubscriptionWithoutTopic(t *testing.T) {
+	t.Setenv("PUBSUB_EMULATOR_HOST", "localhost:1")
+
+	pConf, err := pbiSpec().ParseYAML(`
+project: foo
+subscription: bar
+create_subscription:
+  enabled: true
+`, nil)
+	require.NoError(t, err)
+
+	conf, err := pbiConfigFromParsed(pConf)
+	require.NoError(t, err)
+
+	_, err = newGCPPubSubReader(conf, service.MockResources())
+	require.EqualError(t, err, "must specify a topic_id when create_subscription is enabled")
+}
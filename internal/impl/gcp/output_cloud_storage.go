@@ -11,6 +11,7 @@ import (
 	"cloud.google.com/go/storage"
 	"github.com/gofrs/uuid"
 	"go.uber.org/multierr"
+	"google.golang.org/api/option"
 
 	"github.com/benthosdev/benthos/v4/public/service"
 )
@@ -26,6 +27,7 @@ const (
 	csoFieldBatching        = "batching"
 	csoFieldCollisionMode   = "collision_mode"
 	csoFieldTimeout         = "timeout"
+	csoFieldCredentialsFile = "credentials_file"
 
 	// GCPCloudStorageErrorIfExistsCollisionMode - error-if-exists.
 	GCPCloudStorageErrorIfExistsCollisionMode = "error-if-exists"
@@ -48,6 +50,7 @@ type csoConfig struct {
 	ChunkSize       int
 	CollisionMode   string
 	Timeout         time.Duration
+	CredentialsFile string
 }
 
 func csoConfigFromParsed(pConf *service.ParsedConfig) (conf csoConfig, err error) {
@@ -72,6 +75,11 @@ func csoConfigFromParsed(pConf *service.ParsedConfig) (conf csoConfig, err error
 	if conf.Timeout, err = pConf.FieldDuration(csoFieldTimeout); err != nil {
 		return
 	}
+	if pConf.Contains(csoFieldCredentialsFile) {
+		if conf.CredentialsFile, err = pConf.FieldString(csoFieldCredentialsFile); err != nil {
+			return
+		}
+	}
 	return
 }
 
@@ -90,7 +98,7 @@ Metadata fields on messages will be sent as headers, in order to mutate these va
 
 ### Credentials
 
-By default Benthos will use a shared credentials file when connecting to GCP services. You can find out more [in this document](/docs/guides/cloud/gcp).
+By default Benthos will use a shared credentials file when connecting to GCP services. You can find out more [in this document](/docs/guides/cloud/gcp). Alternatively, the path of a service account JSON key file can be specified with the field `+"`credentials_file`"+`.
 
 ### Batching
 
@@ -163,6 +171,11 @@ output:
 			service.NewOutputMaxInFlightField().
 				Description("The maximum number of message batches to have in flight at a given time. Increase this to improve throughput."),
 			service.NewBatchPolicyField(csoFieldBatching),
+			service.NewStringField(csoFieldCredentialsFile).
+				Description("An optional path to a service account JSON key file to authenticate with, overriding the default credentials.").
+				Version("4.28.0").
+				Advanced().
+				Optional(),
 		)
 }
 
@@ -215,8 +228,13 @@ func (g *gcpCloudStorageOutput) Connect(ctx context.Context) error {
 	g.connMut.Lock()
 	defer g.connMut.Unlock()
 
+	var opts []option.ClientOption
+	if g.conf.CredentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(g.conf.CredentialsFile))
+	}
+
 	var err error
-	g.client, err = storage.NewClient(context.Background())
+	g.client, err = storage.NewClient(context.Background(), opts...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,48 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestGCPCloudStorageOutputCredentialsFile(t *testing.T) {
+	ctx := context.Background()
+
+	credsPath := filepath.Join(t.TempDir(), "creds.json")
+	require.NoError(t, os.WriteFile(credsPath, []byte(`{
+  "type": "authorized_user",
+  "client_id": "foo",
+  "client_secret": "bar",
+  "refresh_token": "baz"
+}`), 0o600))
+
+	pConf, err := csoSpec().ParseYAML(fmt.Sprintf(`
+bucket: foo
+credentials_file: %v
+`, credsPath), nil)
+	require.NoError(t, err)
+
+	conf, err := csoConfigFromParsed(pConf)
+	require.NoError(t, err)
+	assert.Equal(t, credsPath, conf.CredentialsFile)
+
+	out, err := newGCPCloudStorageOutput(conf, service.MockResources())
+	require.NoError(t, err)
+
+	require.NoError(t, out.Connect(ctx))
+	require.NoError(t, out.Close(ctx))
+
+	conf.CredentialsFile = filepath.Join(t.TempDir(), "missing.json")
+
+	out, err = newGCPCloudStorageOutput(conf, service.MockResources())
+	require.NoError(t, err)
+	require.Error(t, out.Connect(ctx))
+}
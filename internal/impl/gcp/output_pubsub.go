@@ -20,7 +20,7 @@ func newPubSubOutputConfig() *service.ConfigSpec {
 		Categories("Services", "GCP").
 		Summary("Sends messages to a GCP Cloud Pub/Sub topic. [Metadata](/docs/configuration/metadata) from messages are sent as attributes.").
 		Description(`
-For information on how to set up credentials check out [this guide](https://cloud.google.com/docs/authentication/production).
+For information on how to set up credentials check out [this guide](https://cloud.google.com/docs/authentication/production). Alternatively, the path of a service account JSON key file can be specified with the field `+"`credentials_file`"+`.
 
 ### Troubleshooting
 
@@ -54,6 +54,11 @@ pipeline:
 				Optional().
 				Description("The ordering key to use for publishing messages.").
 				Advanced(),
+			service.NewStringField("credentials_file").
+				Optional().
+				Description("An optional path to a service account JSON key file to authenticate with, overriding the default credentials.").
+				Version("4.28.0").
+				Advanced(),
 			service.NewIntField("max_in_flight").Default(64).Description("The maximum number of messages to have in flight at a given time. Increasing this may improve throughput."),
 			service.NewIntField("count_threshold").
 				Default(defaults.CountThreshold).
@@ -181,6 +186,13 @@ func newPubSubOutput(conf *service.ParsedConfig) (*pubsubOutput, error) {
 	if endpoint != "" {
 		opt = []option.ClientOption{option.WithEndpoint(endpoint)}
 	}
+	if conf.Contains("credentials_file") {
+		credsFile, err := conf.FieldString("credentials_file")
+		if err != nil {
+			return nil, err
+		}
+		opt = append(opt, option.WithCredentialsFile(credsFile))
+	}
 
 	return &pubsubOutput{
 		topics:          make(map[string]pubsubTopic),
@@ -3,6 +3,9 @@ package gcp
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"cloud.google.com/go/pubsub"
@@ -279,3 +282,47 @@ func TestPubSubOutput_PublishErrors(t *testing.T) {
 	})
 	require.ElementsMatch(t, []string{"simulated foo error", "simulated bar error"}, errs)
 }
+
+func TestPubSubOutput_CredentialsFile(t *testing.T) {
+	ctx := context.Background()
+
+	credsPath := filepath.Join(t.TempDir(), "creds.json")
+	require.NoError(t, os.WriteFile(credsPath, []byte(`{
+  "type": "authorized_user",
+  "client_id": "foo",
+  "client_secret": "bar",
+  "refresh_token": "baz"
+}`), 0o600))
+
+	conf, err := newPubSubOutputConfig().ParseYAML(fmt.Sprintf(`
+    project: sample-project
+    topic: test
+    credentials_file: %v
+    `, credsPath),
+		nil,
+	)
+	require.NoError(t, err, "bad output config")
+
+	out, err := newPubSubOutput(conf)
+	require.NoError(t, err, "failed to create output")
+	require.Len(t, out.clientOpts, 1)
+
+	require.NoError(t, out.Connect(ctx))
+	require.NoError(t, out.Close(ctx))
+
+	conf, err = newPubSubOutputConfig().ParseYAML(fmt.Sprintf(`
+    project: sample-project
+    topic: test
+    credentials_file: %v
+    `, filepath.Join(t.TempDir(), "missing.json")),
+		nil,
+	)
+	require.NoError(t, err, "bad output config")
+
+	out, err = newPubSubOutput(conf)
+	require.NoError(t, err, "failed to create output")
+
+	err = out.Connect(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to create pubsub client")
+}
@@ -0,0 +1,339 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/Jeffail/shutdown"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+const (
+	gsiFieldAddress        = "address"
+	gsiFieldCertFile       = "cert_file"
+	gsiFieldKeyFile        = "key_file"
+	gsiFieldMaxMessageSize = "max_message_size"
+)
+
+func grpcServerInputSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Categories("Network").
+		Version("4.28.0").
+		Summary("Receive messages pushed over gRPC.").
+		Description(`
+The server exposes the service `+"`benthos.Input`"+`, which is defined using only well-known protobuf types so that clients can be generated without a dedicated schema:
+
+`+"```protobuf"+`
+syntax = "proto3";
+
+package benthos;
+
+import "google/protobuf/empty.proto";
+import "google/protobuf/wrappers.proto";
+
+service Input {
+  rpc Push(google.protobuf.BytesValue) returns (google.protobuf.Empty);
+  rpc PushStream(stream google.protobuf.BytesValue) returns (google.protobuf.Empty);
+}
+`+"```"+`
+
+Each `+"`BytesValue`"+` received is consumed as an individual message. A response is only returned once the message (or, for `+"`PushStream`"+`, all messages received on the stream) has been delivered by the pipeline, providing end-to-end delivery confirmation. When delivery fails the RPC returns an error with the status code `+"`UNAVAILABLE`"+` and the client should retry. If the deadline of a call is reached before delivery is confirmed the messages may still be delivered.
+
+### Metadata
+
+The gRPC metadata of each call is added to every message consumed from it, where keys with multiple values are joined with a comma.
+
+You can access these metadata fields using [function interpolation](/docs/configuration/interpolation#bloblang-queries).`).
+		Fields(
+			service.NewStringField(gsiFieldAddress).
+				Description("The address to listen on.").
+				Default("0.0.0.0:50051"),
+			service.NewStringField(gsiFieldCertFile).
+				Description("Enable TLS by specifying a certificate and key file.").
+				Advanced().
+				Default(""),
+			service.NewStringField(gsiFieldKeyFile).
+				Description("Enable TLS by specifying a certificate and key file.").
+				Advanced().
+				Default(""),
+			service.NewIntField(gsiFieldMaxMessageSize).
+				Description("The maximum size in bytes of a message that can be received.").
+				Advanced().
+				Default(4194304),
+		)
+}
+
+func init() {
+	err := service.RegisterInput(
+		"grpc_server", grpcServerInputSpec(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.Input, error) {
+			return newGRPCServerInputFromParsed(conf, mgr)
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// grpcServerHandler is the interface implemented by handlers of the
+// benthos.Input service.
+type grpcServerHandler interface {
+	push(ctx context.Context, req *wrapperspb.BytesValue) (*emptypb.Empty, error)
+	pushStream(stream grpc.ServerStream) error
+}
+
+var grpcServerServiceDesc = grpc.ServiceDesc{
+	ServiceName: "benthos.Input",
+	HandlerType: (*grpcServerHandler)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Push",
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				req := &wrapperspb.BytesValue{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				return srv.(grpcServerHandler).push(ctx, req)
+			},
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName: "PushStream",
+			Handler: func(srv any, stream grpc.ServerStream) error {
+				return srv.(grpcServerHandler).pushStream(stream)
+			},
+			ClientStreams: true,
+		},
+	},
+}
+
+type grpcServerMessage struct {
+	msg     *service.Message
+	resChan chan error
+}
+
+type grpcServerInput struct {
+	address        string
+	certFile       string
+	keyFile        string
+	maxMessageSize int
+
+	log *service.Logger
+
+	serverMut sync.Mutex
+	server    *grpc.Server
+	listener  net.Listener
+
+	msgs    chan grpcServerMessage
+	shutSig *shutdown.Signaller
+}
+
+func newGRPCServerInputFromParsed(conf *service.ParsedConfig, mgr *service.Resources) (*grpcServerInput, error) {
+	g := &grpcServerInput{
+		log:     mgr.Logger(),
+		msgs:    make(chan grpcServerMessage),
+		shutSig: shutdown.NewSignaller(),
+	}
+
+	var err error
+	if g.address, err = conf.FieldString(gsiFieldAddress); err != nil {
+		return nil, err
+	}
+	if g.certFile, err = conf.FieldString(gsiFieldCertFile); err != nil {
+		return nil, err
+	}
+	if g.keyFile, err = conf.FieldString(gsiFieldKeyFile); err != nil {
+		return nil, err
+	}
+	if (g.certFile == "") != (g.keyFile == "") {
+		return nil, errors.New("both a cert_file and key_file must be specified in order to enable TLS")
+	}
+	if g.maxMessageSize, err = conf.FieldInt(gsiFieldMaxMessageSize); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (g *grpcServerInput) Connect(ctx context.Context) error {
+	g.serverMut.Lock()
+	defer g.serverMut.Unlock()
+
+	if g.server != nil {
+		return nil
+	}
+	if g.shutSig.IsSoftStopSignalled() {
+		return service.ErrEndOfInput
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(g.maxMessageSize),
+	}
+	if g.certFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(g.certFile, g.keyFile)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	listener, err := net.Listen("tcp", g.address)
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer(opts...)
+	server.RegisterService(&grpcServerServiceDesc, g)
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			g.log.Errorf("gRPC server error: %v", err)
+		}
+	}()
+
+	g.server = server
+	g.listener = listener
+	g.log.Infof("Receiving gRPC messages at: %v", listener.Addr())
+	return nil
+}
+
+func messageFromBytesValue(md metadata.MD, v *wrapperspb.BytesValue) *service.Message {
+	msg := service.NewMessage(v.GetValue())
+	for k, vs := range md {
+		msg.MetaSetMut(k, strings.Join(vs, ","))
+	}
+	return msg
+}
+
+// dispatch sends a message downstream and returns a channel that receives the
+// result of its delivery.
+func (g *grpcServerInput) dispatch(ctx context.Context, msg *service.Message) (<-chan error, error) {
+	resChan := make(chan error, 1)
+	select {
+	case g.msgs <- grpcServerMessage{msg: msg, resChan: resChan}:
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	case <-g.shutSig.SoftStopChan():
+		return nil, status.Error(codes.Unavailable, "server is shutting down")
+	}
+	return resChan, nil
+}
+
+func (g *grpcServerInput) awaitResult(ctx context.Context, resChan <-chan error) error {
+	select {
+	case err := <-resChan:
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "failed to deliver message: %v", err)
+		}
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	case <-g.shutSig.HardStopChan():
+		return status.Error(codes.Unavailable, "server is shutting down")
+	}
+	return nil
+}
+
+func (g *grpcServerInput) push(ctx context.Context, req *wrapperspb.BytesValue) (*emptypb.Empty, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	resChan, err := g.dispatch(ctx, messageFromBytesValue(md, req))
+	if err != nil {
+		return nil, err
+	}
+	if err := g.awaitResult(ctx, resChan); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (g *grpcServerInput) pushStream(stream grpc.ServerStream) error {
+	ctx := stream.Context()
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var results []<-chan error
+	for {
+		req := &wrapperspb.BytesValue{}
+		if err := stream.RecvMsg(req); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+
+		resChan, err := g.dispatch(ctx, messageFromBytesValue(md, req))
+		if err != nil {
+			return err
+		}
+		results = append(results, resChan)
+	}
+
+	for _, resChan := range results {
+		if err := g.awaitResult(ctx, resChan); err != nil {
+			return err
+		}
+	}
+	return stream.SendMsg(&emptypb.Empty{})
+}
+
+func (g *grpcServerInput) Read(ctx context.Context) (*service.Message, service.AckFunc, error) {
+	g.serverMut.Lock()
+	server := g.server
+	g.serverMut.Unlock()
+
+	if server == nil {
+		return nil, nil, service.ErrNotConnected
+	}
+
+	select {
+	case m := <-g.msgs:
+		return m.msg, func(ctx context.Context, err error) error {
+			m.resChan <- err
+			return nil
+		}, nil
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	case <-g.shutSig.SoftStopChan():
+		return nil, nil, service.ErrEndOfInput
+	}
+}
+
+func (g *grpcServerInput) Close(ctx context.Context) error {
+	g.shutSig.TriggerSoftStop()
+
+	g.serverMut.Lock()
+	server := g.server
+	g.serverMut.Unlock()
+
+	if server == nil {
+		return nil
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		g.shutSig.TriggerHardStop()
+		server.Stop()
+		return ctx.Err()
+	}
+	return nil
+}
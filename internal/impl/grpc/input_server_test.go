@@ -0,0 +1,132 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func testGRPCServerInput(t *testing.T) (*grpcServerInput, *grpc.ClientConn) {
+	t.Helper()
+
+	pConf, err := grpcServerInputSpec().ParseYAML(`
+address: 127.0.0.1:0
+`, nil)
+	require.NoError(t, err)
+
+	g, err := newGRPCServerInputFromParsed(pConf, service.MockResources())
+	require.NoError(t, err)
+	require.NoError(t, g.Connect(context.Background()))
+
+	conn, err := grpc.Dial(g.listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+
+		ctx, done := context.WithTimeout(context.Background(), time.Second*5)
+		defer done()
+		require.NoError(t, g.Close(ctx))
+	})
+	return g, conn
+}
+
+func TestGRPCServerPush(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*10)
+	defer done()
+
+	g, conn := testGRPCServerInput(t)
+
+	for _, test := range []struct {
+		content string
+		ackErr  error
+		expCode codes.Code
+	}{
+		{content: "hello world", expCode: codes.OK},
+		{content: "nope", ackErr: errors.New("nah"), expCode: codes.Unavailable},
+	} {
+		callErr := make(chan error, 1)
+		go func() {
+			reqCtx := metadata.AppendToOutgoingContext(ctx, "foo", "bar")
+			callErr <- conn.Invoke(reqCtx, "/benthos.Input/Push", wrapperspb.Bytes([]byte(test.content)), &emptypb.Empty{})
+		}()
+
+		msg, ackFn, err := g.Read(ctx)
+		require.NoError(t, err)
+
+		mBytes, err := msg.AsBytes()
+		require.NoError(t, err)
+		assert.Equal(t, test.content, string(mBytes))
+
+		v, _ := msg.MetaGet("foo")
+		assert.Equal(t, "bar", v)
+
+		select {
+		case err := <-callErr:
+			t.Fatalf("call returned before ack: %v", err)
+		case <-time.After(time.Millisecond * 50):
+		}
+
+		require.NoError(t, ackFn(ctx, test.ackErr))
+		assert.Equal(t, test.expCode, status.Code(<-callErr))
+	}
+}
+
+func TestGRPCServerPushStream(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*10)
+	defer done()
+
+	g, conn := testGRPCServerInput(t)
+
+	stream, err := conn.NewStream(ctx, &grpcServerServiceDesc.Streams[0], "/benthos.Input/PushStream")
+	require.NoError(t, err)
+
+	exp := []string{"foo", "bar", "baz"}
+	for _, v := range exp {
+		require.NoError(t, stream.SendMsg(wrapperspb.Bytes([]byte(v))))
+	}
+	require.NoError(t, stream.CloseSend())
+
+	var ackFns []service.AckFunc
+	for _, v := range exp {
+		msg, ackFn, err := g.Read(ctx)
+		require.NoError(t, err)
+
+		mBytes, err := msg.AsBytes()
+		require.NoError(t, err)
+		assert.Equal(t, v, string(mBytes))
+
+		ackFns = append(ackFns, ackFn)
+	}
+
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- stream.RecvMsg(&emptypb.Empty{})
+	}()
+
+	for _, fn := range ackFns[:2] {
+		require.NoError(t, fn(ctx, nil))
+	}
+
+	select {
+	case err := <-streamErr:
+		t.Fatalf("stream returned before all acks: %v", err)
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	require.NoError(t, ackFns[2](ctx, nil))
+	require.NoError(t, <-streamErr)
+}
@@ -0,0 +1,945 @@
+package io
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/benthosdev/benthos/v4/internal/batch/policy"
+	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/docs"
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+const (
+	dbufFieldDirectory      = "directory"
+	dbufFieldMaxSegmentSize = "max_segment_size"
+	dbufFieldMaxTotalSize   = "max_total_size"
+	dbufFieldTTL            = "ttl"
+	dbufFieldMaxRequeue     = "max_requeue"
+	dbufFieldBatchPolicy    = "batch_policy"
+)
+
+func diskBufferConfig() *service.ConfigSpec {
+	bs := policy.FieldSpec()
+	bs.Name = dbufFieldBatchPolicy
+	bs.Description = "Optionally configure a policy to flush buffered messages in batches."
+	bs.Examples = nil
+	newChildren := []docs.FieldSpec{
+		docs.FieldBool("enabled", "Whether to batch messages as they are flushed.").HasDefault(false),
+	}
+	for _, f := range bs.Children {
+		if f.Name == "count" {
+			f = f.HasDefault(0)
+		}
+		if !f.IsDeprecated {
+			newChildren = append(newChildren, f)
+		}
+	}
+	bs.Children = newChildren
+
+	return service.NewConfigSpec().
+		Beta().
+		Categories("Utility").
+		Summary("Stores messages in an append-only log of segment files within a directory and acknowledges them at the input level.").
+		Description(`
+Each message batch written to this buffer is appended as a record to the newest segment file within the configured directory. Once a segment reaches the `+"`max_segment_size`"+` a new segment is started, and segments are deleted from disk once every record they contain has been successfully delivered at the output level.
+
+If the service is restarted Benthos will scan the directory, recover any records that have not yet been delivered, and replay them in order before consuming new data. A record that was only partially written when the service stopped unexpectedly is detected, truncated from its segment and logged with a warning.
+
+## Delivery Guarantees
+
+Messages are not acknowledged at the input level until they have been written to a segment file, and they are not removed from disk until they have been successfully delivered. This means at-least-once delivery guarantees are preserved in cases where the service is shut down unexpectedly, although records that were delivered after the last graceful shutdown may be delivered again. Since writes are not synchronised to disk these guarantees are not resilient to power loss, disk corruption or disk loss.
+
+Messages that are rejected at the output level are stored in memory and redelivered before consuming any further records from disk. By default rejected messages are redelivered indefinitely, when `+"`max_requeue`"+` is set records that have been rejected more than this number of times are instead deleted, logged at error level and counted with the metric `+"`buffer_requeue_dropped`"+`.
+
+Records that cannot be read from disk, for example because a segment file has been corrupted, are retried a limited number of times before they are deleted, logged at error level and counted with the metric `+"`buffer_read_dropped`"+`.
+
+## Metrics
+
+This buffer emits the gauges `+"`buffer_backlog_bytes`"+` and `+"`buffer_backlog_count`"+`, which track the total size in bytes of the segment files currently stored and the number of messages within them that are yet to be acknowledged.
+
+## Message Expiry
+
+When a `+"`ttl`"+` is configured records that have been stored within the buffer for longer than the TTL are dropped instead of being delivered, and the counter `+"`buffer_ttl_dropped`"+` is incremented for each dropped message. Expiry is checked as records are read from the buffer, and records recovered after a restart retain their original write time.
+
+## Batching
+
+It is possible to batch up messages sent from this buffer using a [batch policy](/docs/configuration/batching#batch-policy). A batch may be formed from multiple stored records, in which case they are only removed from disk once the whole batch has been successfully delivered, and are all redelivered should the batch be rejected.
+
+## Back Pressure
+
+When the total size of the stored segments reaches `+"`max_total_size`"+` consumption will be stopped with back pressure upstream until enough records have been delivered for their segments to be deleted.`).
+		Field(service.NewStringField(dbufFieldDirectory).
+			Description("The directory within which to store segment files, which will be created if it does not already exist.")).
+		Field(service.NewIntField(dbufFieldMaxSegmentSize).
+			Description("The maximum size (in bytes) of each segment file before a new one is started. A single record larger than this value is written to its own segment.").
+			Default(64*1024*1024).
+			Advanced()).
+		Field(service.NewIntField(dbufFieldMaxTotalSize).
+			Description("The maximum total size (in bytes) of all segment files before applying back pressure upstream.").
+			Default(1024*1024*1024)).
+		Field(service.NewDurationField(dbufFieldTTL).
+			Description("An optional maximum period of time that a record may be stored within the buffer, after which it is dropped rather than delivered.").
+			Example("60s").
+			Optional().
+			Advanced()).
+		Field(service.NewIntField(dbufFieldMaxRequeue).
+			Description("The maximum number of times a rejected record is redelivered before it is dropped, where zero means records are redelivered indefinitely.").
+			Default(0).
+			Advanced()).
+		Field(service.NewInternalField(bs)).
+		Example("Buffer to disk", "Buffer messages to a local directory capped at 10GB.", `
+buffer:
+  disk:
+    directory: /var/lib/benthos/buffer
+    max_total_size: 10737418240
+`)
+}
+
+func init() {
+	err := service.RegisterBatchBuffer(
+		"disk", diskBufferConfig(),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.BatchBuffer, error) {
+			return newDiskBufferFromConfig(conf, mgr)
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func newDiskBufferFromConfig(conf *service.ParsedConfig, mgr *service.Resources) (*diskBuffer, error) {
+	dir, err := conf.FieldString(dbufFieldDirectory)
+	if err != nil {
+		return nil, err
+	}
+	maxSegmentSize, err := conf.FieldInt(dbufFieldMaxSegmentSize)
+	if err != nil {
+		return nil, err
+	}
+	maxTotalSize, err := conf.FieldInt(dbufFieldMaxTotalSize)
+	if err != nil {
+		return nil, err
+	}
+	var ttl time.Duration
+	if conf.Contains(dbufFieldTTL) {
+		if ttl, err = conf.FieldDuration(dbufFieldTTL); err != nil {
+			return nil, err
+		}
+	}
+	maxRequeue, err := conf.FieldInt(dbufFieldMaxRequeue)
+	if err != nil {
+		return nil, err
+	}
+	if maxSegmentSize <= 0 {
+		return nil, errors.New("max_segment_size must be greater than zero")
+	}
+	if maxTotalSize <= 0 {
+		return nil, errors.New("max_total_size must be greater than zero")
+	}
+
+	buf, err := newDiskBuffer(dir, int64(maxSegmentSize), int64(maxTotalSize), ttl, mgr)
+	if err != nil {
+		return nil, err
+	}
+	buf.maxRequeue = maxRequeue
+
+	batchingEnabled, err := conf.FieldBool(dbufFieldBatchPolicy, "enabled")
+	if err != nil {
+		_ = buf.Close(context.Background())
+		return nil, err
+	}
+	if batchingEnabled {
+		batchPol, err := conf.FieldBatchPolicy(dbufFieldBatchPolicy)
+		if err != nil {
+			_ = buf.Close(context.Background())
+			return nil, err
+		}
+		if buf.batcher, err = batchPol.NewBatcher(mgr); err != nil {
+			_ = buf.Close(context.Background())
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+//------------------------------------------------------------------------------
+
+const (
+	diskSegmentSuffix   = ".seg"
+	diskOffsetFile      = "consumer_offset"
+	diskRecordHeaderLen = 8
+
+	// The number of times reading a record may fail before it is dropped.
+	diskMaxReadAttempts = 3
+)
+
+var errDiskRecordCorrupt = errors.New("record is corrupt")
+
+type diskSegment struct {
+	id   uint64
+	f    *os.File
+	size int64
+
+	offsets  []int64
+	lengths  []int
+	requeues []int
+	readErrs []int
+	acked    []bool
+	nAcked   int
+
+	// The number of leading records that have all been acknowledged.
+	prefix int
+}
+
+func (s *diskSegment) path(dir string) string {
+	return filepath.Join(dir, fmt.Sprintf("%020d%v", s.id, diskSegmentSuffix))
+}
+
+func (s *diskSegment) fullyAcked() bool {
+	return s.nAcked == len(s.offsets)
+}
+
+type diskRecordRef struct {
+	seg   *diskSegment
+	index int
+}
+
+type diskBuffer struct {
+	dir            string
+	maxSegmentSize int64
+	maxTotalSize   int64
+	ttl            time.Duration
+	maxRequeue     int
+	log            *service.Logger
+
+	mBacklogBytes *service.MetricGauge
+	mBacklogCount *service.MetricGauge
+	mTTLDropped   *service.MetricCounter
+	mReqDropped   *service.MetricCounter
+	mReadDropped  *service.MetricCounter
+
+	cond     *sync.Cond
+	segments []*diskSegment
+	nextID   uint64
+	total    int64
+	count    int
+
+	readID    uint64
+	readIndex int
+	requeued  []diskRecordRef
+
+	batcher *service.Batcher
+
+	endOfInput bool
+	closed     bool
+}
+
+func newDiskBuffer(dir string, maxSegmentSize, maxTotalSize int64, ttl time.Duration, mgr *service.Resources) (*diskBuffer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	d := &diskBuffer{
+		dir:            dir,
+		maxSegmentSize: maxSegmentSize,
+		maxTotalSize:   maxTotalSize,
+		ttl:            ttl,
+		log:            mgr.Logger(),
+		mBacklogBytes:  mgr.Metrics().NewGauge("buffer_backlog_bytes"),
+		mBacklogCount:  mgr.Metrics().NewGauge("buffer_backlog_count"),
+		mTTLDropped:    mgr.Metrics().NewCounter("buffer_ttl_dropped"),
+		mReqDropped:    mgr.Metrics().NewCounter("buffer_requeue_dropped"),
+		mReadDropped:   mgr.Metrics().NewCounter("buffer_read_dropped"),
+		cond:           sync.NewCond(&sync.Mutex{}),
+	}
+	if err := d.recover(); err != nil {
+		d.closeSegments()
+		return nil, err
+	}
+	return d, nil
+}
+
+// recover scans the directory for existing segments, truncating any corrupted
+// records found at the end of them, and restores the consumer offset.
+func (d *diskBuffer) recover() error {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return err
+	}
+
+	var ids []uint64
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, diskSegmentSuffix) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, diskSegmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		seg := &diskSegment{id: id}
+		if err := d.scanSegment(seg); err != nil {
+			if seg.f != nil {
+				_ = seg.f.Close()
+			}
+			return err
+		}
+		d.nextID = id + 1
+		if len(seg.offsets) == 0 {
+			_ = seg.f.Close()
+			_ = os.Remove(seg.path(d.dir))
+			continue
+		}
+		d.segments = append(d.segments, seg)
+		d.total += seg.size
+	}
+
+	offsetID, offsetIndex, hasOffset, err := d.readOffset()
+	if err != nil {
+		return err
+	}
+	if hasOffset && d.nextID <= offsetID {
+		// The segment referenced by the offset has already been deleted, new
+		// segments must not reuse its ID until the offset is rewritten.
+		d.nextID = offsetID + 1
+	}
+	for _, seg := range d.segments {
+		if seg.id > offsetID {
+			break
+		}
+		n := offsetIndex
+		if seg.id < offsetID || n > len(seg.offsets) {
+			n = len(seg.offsets)
+		}
+		for i := 0; i < n; i++ {
+			seg.acked[i] = true
+		}
+		seg.nAcked = n
+		seg.prefix = n
+	}
+	for _, seg := range append([]*diskSegment(nil), d.segments...) {
+		if seg.fullyAcked() {
+			d.deleteSegment(seg)
+			continue
+		}
+		for i, l := range seg.lengths {
+			if !seg.acked[i] {
+				d.count += l
+			}
+		}
+	}
+	d.updateBacklog()
+	if len(d.segments) > 0 {
+		d.readID = d.segments[0].id
+	}
+
+	// The offset may reference segments that have since been deleted, and
+	// therefore it is rewritten in order to reflect the recovered state.
+	return d.writeOffset()
+}
+
+func (d *diskBuffer) scanSegment(seg *diskSegment) error {
+	path := seg.path(d.dir)
+
+	var err error
+	if seg.f, err = os.OpenFile(path, os.O_RDWR|os.O_APPEND, 0o644); err != nil {
+		return err
+	}
+
+	info, err := seg.f.Stat()
+	if err != nil {
+		return err
+	}
+	fileSize := info.Size()
+
+	var offset int64
+	for offset < fileSize {
+		var data []byte
+		recordLen, err := d.readRecordAt(seg.f, offset, fileSize, &data)
+		var batch service.MessageBatch
+		if err == nil {
+			if batch, _, err = diskDecodeBatch(data); err != nil {
+				err = errDiskRecordCorrupt
+			}
+		}
+		if err != nil {
+			if !errors.Is(err, errDiskRecordCorrupt) {
+				return err
+			}
+			d.log.Warnf("Truncating %v bytes of corrupted data from segment %v at offset %v", fileSize-offset, path, offset)
+			if err := seg.f.Truncate(offset); err != nil {
+				return err
+			}
+			break
+		}
+		seg.offsets = append(seg.offsets, offset)
+		seg.lengths = append(seg.lengths, len(batch))
+		seg.requeues = append(seg.requeues, 0)
+		seg.readErrs = append(seg.readErrs, 0)
+		seg.acked = append(seg.acked, false)
+		offset += recordLen
+	}
+	seg.size = offset
+	return nil
+}
+
+// readRecordAt reads the record at a given offset and returns its total length
+// (including the header), records that would extend beyond the provided size
+// limit are considered corrupt. When data is non-nil the payload is written to
+// it.
+func (d *diskBuffer) readRecordAt(f *os.File, offset, limit int64, data *[]byte) (int64, error) {
+	if offset+diskRecordHeaderLen > limit {
+		return 0, errDiskRecordCorrupt
+	}
+
+	var header [diskRecordHeaderLen]byte
+	if _, err := f.ReadAt(header[:], offset); err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, errDiskRecordCorrupt
+		}
+		return 0, err
+	}
+
+	payloadLen := int64(binary.BigEndian.Uint32(header[:4]))
+	checksum := binary.BigEndian.Uint32(header[4:])
+	if offset+diskRecordHeaderLen+payloadLen > limit {
+		return 0, errDiskRecordCorrupt
+	}
+
+	payload := make([]byte, payloadLen)
+	if _, err := f.ReadAt(payload, offset+diskRecordHeaderLen); err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, errDiskRecordCorrupt
+		}
+		return 0, err
+	}
+	if crc32.ChecksumIEEE(payload) != checksum {
+		return 0, errDiskRecordCorrupt
+	}
+	if data != nil {
+		*data = payload
+	}
+	return diskRecordHeaderLen + payloadLen, nil
+}
+
+func (d *diskBuffer) readOffset() (id uint64, index int, exists bool, err error) {
+	b, err := os.ReadFile(filepath.Join(d.dir, diskOffsetFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = nil
+		}
+		return
+	}
+	if _, err = fmt.Sscanf(string(b), "%d %d", &id, &index); err != nil {
+		d.log.Warnf("Ignoring unreadable consumer offset file: %v", err)
+		return 0, 0, false, nil
+	}
+	exists = true
+	return
+}
+
+// writeOffset persists the position of the oldest record that has not yet been
+// acknowledged, must be called with the mutex held. The file is replaced
+// atomically so that a crash never leaves a partially written offset.
+func (d *diskBuffer) writeOffset() error {
+	path := filepath.Join(d.dir, diskOffsetFile)
+	if len(d.segments) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	head := d.segments[0]
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(fmt.Sprintf("%d %d", head.id, head.prefix)), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// deleteSegment must be called with the mutex held.
+func (d *diskBuffer) deleteSegment(seg *diskSegment) {
+	for i, s := range d.segments {
+		if s == seg {
+			d.segments = append(d.segments[:i], d.segments[i+1:]...)
+			break
+		}
+	}
+	d.total -= seg.size
+	_ = seg.f.Close()
+	if err := os.Remove(seg.path(d.dir)); err != nil {
+		d.log.Errorf("Failed to delete segment %v: %v", seg.path(d.dir), err)
+	}
+}
+
+func (d *diskBuffer) closeSegments() {
+	for _, seg := range d.segments {
+		_ = seg.f.Close()
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// nextRecord returns a reference to the next record to deliver, must be called
+// with the mutex held.
+func (d *diskBuffer) nextRecord() (diskRecordRef, bool) {
+	if len(d.requeued) > 0 {
+		ref := d.requeued[0]
+		d.requeued = d.requeued[1:]
+		return ref, true
+	}
+	for _, seg := range d.segments {
+		if seg.id < d.readID {
+			continue
+		}
+		if seg.id > d.readID {
+			d.readID, d.readIndex = seg.id, 0
+		}
+		for d.readIndex < len(seg.offsets) && seg.acked[d.readIndex] {
+			d.readIndex++
+		}
+		if d.readIndex < len(seg.offsets) {
+			ref := diskRecordRef{seg: seg, index: d.readIndex}
+			d.readIndex++
+			return ref, true
+		}
+	}
+	return diskRecordRef{}, false
+}
+
+// updateBacklog must be called with the mutex held.
+func (d *diskBuffer) updateBacklog() {
+	d.mBacklogBytes.Set(d.total)
+	d.mBacklogCount.Set(int64(d.count))
+}
+
+// markAcked must be called with the mutex held.
+func (d *diskBuffer) markAcked(ref diskRecordRef) {
+	seg := ref.seg
+	seg.acked[ref.index] = true
+	seg.nAcked++
+	d.count -= seg.lengths[ref.index]
+
+	prefixMoved := false
+	for seg.prefix < len(seg.acked) && seg.acked[seg.prefix] {
+		seg.prefix++
+		prefixMoved = true
+	}
+	isHead := len(d.segments) > 0 && d.segments[0] == seg
+
+	if seg.fullyAcked() {
+		d.deleteSegment(seg)
+	}
+
+	// The consumer offset only references the head segment, and therefore it
+	// only needs persisting when the acknowledged prefix of the head moves,
+	// which includes the head being deleted.
+	if isHead && prefixMoved {
+		if err := d.writeOffset(); err != nil {
+			d.log.Errorf("Failed to persist consumer offset: %v", err)
+		}
+	}
+	d.updateBacklog()
+}
+
+func (d *diskBuffer) ackFn(refs ...diskRecordRef) service.AckFunc {
+	var once sync.Once
+	return func(ctx context.Context, err error) error {
+		once.Do(func() {
+			d.cond.L.Lock()
+			defer d.cond.L.Unlock()
+
+			if err != nil {
+				for _, ref := range refs {
+					if d.maxRequeue > 0 && ref.seg.requeues[ref.index] >= d.maxRequeue {
+						d.log.Errorf("Dropping message batch after it was rejected %v times: %v", ref.seg.requeues[ref.index]+1, err)
+						d.mReqDropped.Incr(int64(ref.seg.lengths[ref.index]))
+						d.markAcked(ref)
+						continue
+					}
+					ref.seg.requeues[ref.index]++
+					d.requeued = append(d.requeued, ref)
+				}
+			} else {
+				for _, ref := range refs {
+					d.markAcked(ref)
+				}
+			}
+			d.cond.Broadcast()
+		})
+		return nil
+	}
+}
+
+// readRef reads and decodes a referenced record, records that have expired are
+// acknowledged and a nil batch is returned. Must be called with the mutex held.
+func (d *diskBuffer) readRef(ref diskRecordRef) (service.MessageBatch, error) {
+	var data []byte
+	if _, err := d.readRecordAt(ref.seg.f, ref.seg.offsets[ref.index], ref.seg.size, &data); err != nil {
+		return nil, err
+	}
+	batch, writtenAt, err := diskDecodeBatch(data)
+	if err != nil {
+		return nil, err
+	}
+	if d.ttl > 0 && time.Since(writtenAt) > d.ttl {
+		d.log.Debugf("Dropping expired message batch of %v bytes", len(data))
+		d.mTTLDropped.Incr(int64(len(batch)))
+		d.markAcked(ref)
+		d.cond.Broadcast()
+		return nil, nil
+	}
+	return batch, nil
+}
+
+// ReadBatch reads the oldest record that has not yet been delivered, or when a
+// batch policy is configured the oldest records that form a batch.
+func (d *diskBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
+	ctx, done := context.WithCancel(ctx)
+	defer done()
+
+	go func() {
+		<-ctx.Done()
+		d.cond.Broadcast()
+	}()
+
+	var batchReady, timedBatch bool
+
+	triggerTimed := func() {
+		batchReady = false
+		timedBatch = false
+
+		if d.batcher == nil {
+			return
+		}
+		timedDur, exists := d.batcher.UntilNext()
+		if !exists {
+			return
+		}
+
+		timer := time.NewTimer(timedDur)
+		go func() {
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+				d.cond.L.Lock()
+				defer d.cond.L.Unlock()
+				timedBatch = true
+				batchReady = true
+				d.cond.Broadcast()
+			case <-ctx.Done():
+			}
+		}()
+	}
+	triggerTimed()
+
+	d.cond.L.Lock()
+	defer d.cond.L.Unlock()
+
+	// The records that have been added to the batcher
+	var sources []diskRecordRef
+
+	// Returns records that were added to the batcher to the front of the queue
+	// so that they are not lost when exiting early.
+	abandon := func(refs ...diskRecordRef) {
+		if len(sources) > 0 {
+			_, _ = d.batcher.Flush(context.Background())
+		}
+		d.requeued = append(append(sources, refs...), d.requeued...)
+	}
+
+	for {
+		if d.closed {
+			return nil, nil, service.ErrEndOfBuffer
+		}
+		if ctx.Err() != nil {
+			abandon()
+			return nil, nil, ctx.Err()
+		}
+
+		drained := false
+		for !batchReady {
+			ref, ok := d.nextRecord()
+			if !ok {
+				drained = true
+				break
+			}
+
+			batch, err := d.readRef(ref)
+			if err != nil {
+				ref.seg.readErrs[ref.index]++
+				if ref.seg.readErrs[ref.index] >= diskMaxReadAttempts {
+					// Errors such as corrupted data won't resolve themselves,
+					// and so we drop the record rather than stall the buffer.
+					d.log.Errorf("Dropping message batch from segment %v after failing to read it %v times: %v", ref.seg.path(d.dir), ref.seg.readErrs[ref.index], err)
+					d.mReadDropped.Incr(int64(ref.seg.lengths[ref.index]))
+					d.markAcked(ref)
+					d.cond.Broadcast()
+					continue
+				}
+				abandon(ref)
+				return nil, nil, err
+			}
+			if batch == nil {
+				continue
+			}
+			if d.batcher == nil {
+				return batch, d.ackFn(ref), nil
+			}
+
+			sources = append(sources, ref)
+			for _, msg := range batch {
+				batchReady = d.batcher.Add(msg)
+			}
+		}
+
+		if batchReady || (drained && d.endOfInput && len(sources) > 0) {
+			outBatch, err := d.batcher.Flush(ctx)
+			if err != nil {
+				d.requeued = append(sources, d.requeued...)
+				return nil, nil, err
+			}
+			if timedBatch && len(outBatch) == 0 {
+				triggerTimed()
+				continue
+			}
+			return outBatch, d.ackFn(sources...), nil
+		}
+
+		if d.endOfInput && len(d.segments) == 0 {
+			return nil, nil, service.ErrEndOfBuffer
+		}
+
+		d.cond.Wait()
+	}
+}
+
+// WriteBatch appends a message batch to the newest segment.
+func (d *diskBuffer) WriteBatch(ctx context.Context, msgBatch service.MessageBatch, aFn service.AckFunc) error {
+	payload, err := diskEncodeBatch(msgBatch, time.Now())
+	if err != nil {
+		return err
+	}
+
+	record := make([]byte, diskRecordHeaderLen, diskRecordHeaderLen+len(payload))
+	binary.BigEndian.PutUint32(record[:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[4:], crc32.ChecksumIEEE(payload))
+	record = append(record, payload...)
+
+	recordLen := int64(len(record))
+	if recordLen > d.maxTotalSize {
+		return component.ErrMessageTooLarge
+	}
+
+	ctx, done := context.WithCancel(ctx)
+	defer done()
+
+	go func() {
+		<-ctx.Done()
+		d.cond.Broadcast()
+	}()
+
+	if err := d.appendRecord(ctx, record, len(msgBatch)); err != nil {
+		return err
+	}
+
+	// The upstream acknowledgement may block, and so it is called without
+	// holding the lock.
+	return aFn(ctx, nil)
+}
+
+// appendRecord blocks until the buffer has room for a record and then appends
+// it to the newest segment, creating a new segment when the current one is
+// full.
+func (d *diskBuffer) appendRecord(ctx context.Context, record []byte, count int) (err error) {
+	recordLen := int64(len(record))
+
+	d.cond.L.Lock()
+	defer d.cond.L.Unlock()
+
+	for d.total+recordLen > d.maxTotalSize {
+		if d.closed {
+			return component.ErrTypeClosed
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		d.cond.Wait()
+	}
+	if d.closed {
+		return component.ErrTypeClosed
+	}
+
+	var tail *diskSegment
+	if len(d.segments) > 0 {
+		tail = d.segments[len(d.segments)-1]
+	}
+	if tail == nil || (tail.size > 0 && tail.size+recordLen > d.maxSegmentSize) {
+		tail = &diskSegment{id: d.nextID}
+		if tail.f, err = os.OpenFile(tail.path(d.dir), os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_EXCL, 0o644); err != nil {
+			return err
+		}
+		d.nextID++
+		d.segments = append(d.segments, tail)
+	}
+
+	if _, err := tail.f.Write(record); err != nil {
+		// Attempt to remove the partial write so that following records are
+		// still readable.
+		_ = tail.f.Truncate(tail.size)
+		return err
+	}
+
+	tail.offsets = append(tail.offsets, tail.size)
+	tail.lengths = append(tail.lengths, count)
+	tail.requeues = append(tail.requeues, 0)
+	tail.readErrs = append(tail.readErrs, 0)
+	tail.acked = append(tail.acked, false)
+	tail.size += recordLen
+	d.total += recordLen
+	d.count += count
+	d.updateBacklog()
+
+	d.cond.Broadcast()
+	return nil
+}
+
+// EndOfInput signals to the buffer that the input is finished and therefore
+// once all stored records are delivered it should close.
+func (d *diskBuffer) EndOfInput() {
+	go func() {
+		d.cond.L.Lock()
+		defer d.cond.L.Unlock()
+
+		d.endOfInput = true
+		d.cond.Broadcast()
+	}()
+}
+
+// Close persists the consumer offset and closes all segment files.
+func (d *diskBuffer) Close(ctx context.Context) error {
+	d.cond.L.Lock()
+	defer d.cond.L.Unlock()
+
+	if d.closed {
+		return nil
+	}
+	d.closed = true
+	d.cond.Broadcast()
+
+	err := d.writeOffset()
+	d.closeSegments()
+	return err
+}
+
+//------------------------------------------------------------------------------
+
+type diskRecord struct {
+	WrittenAt int64               `msgpack:"t"`
+	Messages  []diskRecordMessage `msgpack:"b"`
+}
+
+type diskRecordMessage struct {
+	Meta    map[string]any `msgpack:"m,omitempty"`
+	Content []byte         `msgpack:"c"`
+}
+
+func diskEncodeBatch(batch service.MessageBatch, writtenAt time.Time) ([]byte, error) {
+	msgs := make([]diskRecordMessage, len(batch))
+	for i, msg := range batch {
+		content, err := msg.AsBytes()
+		if err != nil {
+			return nil, err
+		}
+		msgs[i].Content = content
+		_ = msg.MetaWalkMut(func(key string, value any) error {
+			if msgs[i].Meta == nil {
+				msgs[i].Meta = map[string]any{}
+			}
+			msgs[i].Meta[key] = value
+			return nil
+		})
+	}
+	return msgpack.Marshal(diskRecord{
+		WrittenAt: writtenAt.UnixNano(),
+		Messages:  msgs,
+	})
+}
+
+func diskDecodeBatch(b []byte) (service.MessageBatch, time.Time, error) {
+	var record diskRecord
+	if err := msgpack.Unmarshal(b, &record); err != nil {
+		return nil, time.Time{}, err
+	}
+	batch := make(service.MessageBatch, len(record.Messages))
+	for i, m := range record.Messages {
+		batch[i] = service.NewMessage(m.Content)
+		for k, v := range m.Meta {
+			batch[i].MetaSetMut(k, diskNormaliseValue(v))
+		}
+	}
+	return batch, time.Unix(0, record.WrittenAt), nil
+}
+
+// diskNormaliseValue converts the numeric types produced by msgpack, which
+// encodes integers in their most compact form, into int64 and float64 values
+// (or uint64 for integers too large for an int64).
+func diskNormaliseValue(v any) any {
+	switch t := v.(type) {
+	case int8:
+		return int64(t)
+	case int16:
+		return int64(t)
+	case int32:
+		return int64(t)
+	case int:
+		return int64(t)
+	case uint8:
+		return int64(t)
+	case uint16:
+		return int64(t)
+	case uint32:
+		return int64(t)
+	case uint:
+		if uint64(t) > math.MaxInt64 {
+			return uint64(t)
+		}
+		return int64(t)
+	case uint64:
+		if t > math.MaxInt64 {
+			return t
+		}
+		return int64(t)
+	case float32:
+		return float64(t)
+	case []any:
+		for i, e := range t {
+			t[i] = diskNormaliseValue(e)
+		}
+	case map[string]any:
+		for k, e := range t {
+			t[k] = diskNormaliseValue(e)
+		}
+	}
+	return v
+}
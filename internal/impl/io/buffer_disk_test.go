@@ -0,0 +1,715 @@
+package io
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
+	"github.com/benthosdev/benthos/v4/internal/manager/mock"
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func diskBufFromConf(t *testing.T, conf string) *diskBuffer {
+	t.Helper()
+
+	parsedConf, err := diskBufferConfig().ParseYAML(conf, nil)
+	require.NoError(t, err)
+
+	buf, err := newDiskBufferFromConfig(parsedConf, service.MockResources())
+	require.NoError(t, err)
+
+	return buf
+}
+
+func diskBufMsgEqual(t testing.TB, expected string, m *service.Message) {
+	t.Helper()
+
+	mBytes, err := m.AsBytes()
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, string(mBytes))
+}
+
+func noopAck(ctx context.Context, err error) error { return nil }
+
+func TestDiskBufferBasic(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	dir := t.TempDir()
+	block := diskBufFromConf(t, fmt.Sprintf(`
+directory: %v
+max_segment_size: 100
+`, dir))
+	defer block.Close(ctx)
+
+	n := 50
+	for i := 0; i < n; i++ {
+		msg := service.NewMessage([]byte(fmt.Sprintf("test%v", i)))
+		msg.MetaSetMut("index", i)
+		require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+			service.NewMessage([]byte("hello")),
+			msg,
+		}, noopAck))
+	}
+
+	for i := 0; i < n; i++ {
+		m, ackFunc, err := block.ReadBatch(ctx)
+		require.NoError(t, err)
+		require.Len(t, m, 2)
+		diskBufMsgEqual(t, "hello", m[0])
+		diskBufMsgEqual(t, fmt.Sprintf("test%v", i), m[1])
+
+		v, exists := m[1].MetaGetMut("index")
+		require.True(t, exists)
+		assert.Equal(t, int64(i), v)
+
+		require.NoError(t, ackFunc(ctx, nil))
+	}
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	block.EndOfInput()
+
+	_, _, err = block.ReadBatch(ctx)
+	assert.Equal(t, service.ErrEndOfBuffer, err)
+}
+
+func TestDiskBufferNack(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	block := diskBufFromConf(t, fmt.Sprintf(`
+directory: %v
+`, t.TempDir()))
+	defer block.Close(ctx)
+
+	for _, s := range []string{"1", "2"} {
+		require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+			service.NewMessage([]byte(s)),
+		}, noopAck))
+	}
+
+	m, ackFunc, err := block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	diskBufMsgEqual(t, "1", m[0])
+	require.NoError(t, ackFunc(ctx, errors.New("nope")))
+
+	m, ackFunc, err = block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	diskBufMsgEqual(t, "1", m[0])
+	require.NoError(t, ackFunc(ctx, nil))
+
+	m, ackFunc, err = block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	diskBufMsgEqual(t, "2", m[0])
+
+	block.EndOfInput()
+
+	// Still waiting on the final ack before ending
+	readCtx, readDone := context.WithTimeout(ctx, time.Millisecond*50)
+	_, _, err = block.ReadBatch(readCtx)
+	readDone()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	require.NoError(t, ackFunc(ctx, nil))
+
+	_, _, err = block.ReadBatch(ctx)
+	assert.Equal(t, service.ErrEndOfBuffer, err)
+}
+
+func TestDiskBufferMaxRequeue(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	stats := metrics.NewLocal()
+	parsedConf, err := diskBufferConfig().ParseYAML(fmt.Sprintf(`
+directory: %v
+max_requeue: 1
+`, t.TempDir()), nil)
+	require.NoError(t, err)
+
+	block, err := newDiskBufferFromConfig(parsedConf, service.MockResources(func(m *mock.Manager) {
+		m.M = stats
+	}))
+	require.NoError(t, err)
+	defer block.Close(ctx)
+
+	for _, s := range []string{"foo", "bar"} {
+		require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+			service.NewMessage([]byte(s)),
+		}, noopAck))
+	}
+
+	for i := 0; i < 2; i++ {
+		m, ackFunc, err := block.ReadBatch(ctx)
+		require.NoError(t, err)
+		require.Len(t, m, 1)
+		diskBufMsgEqual(t, "foo", m[0])
+		require.NoError(t, ackFunc(ctx, errors.New("nope")))
+	}
+
+	m, ackFunc, err := block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	diskBufMsgEqual(t, "bar", m[0])
+	require.NoError(t, ackFunc(ctx, nil))
+
+	assert.Equal(t, int64(1), stats.GetCounters()["buffer_requeue_dropped"])
+
+	block.EndOfInput()
+	_, _, err = block.ReadBatch(ctx)
+	assert.Equal(t, service.ErrEndOfBuffer, err)
+}
+
+func TestDiskBufferRecovery(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	dir := t.TempDir()
+	conf := fmt.Sprintf(`
+directory: %v
+max_segment_size: 50
+`, dir)
+
+	block := diskBufFromConf(t, conf)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+			service.NewMessage([]byte(fmt.Sprintf("test%v", i))),
+		}, noopAck))
+	}
+
+	// Deliver the first three messages, and read but do not acknowledge the
+	// fourth.
+	for i := 0; i < 4; i++ {
+		m, ackFunc, err := block.ReadBatch(ctx)
+		require.NoError(t, err)
+		require.Len(t, m, 1)
+		diskBufMsgEqual(t, fmt.Sprintf("test%v", i), m[0])
+		if i < 3 {
+			require.NoError(t, ackFunc(ctx, nil))
+		}
+	}
+	require.NoError(t, block.Close(ctx))
+
+	block = diskBufFromConf(t, conf)
+	defer block.Close(ctx)
+
+	for i := 3; i < 10; i++ {
+		m, ackFunc, err := block.ReadBatch(ctx)
+		require.NoError(t, err)
+		require.Len(t, m, 1)
+		diskBufMsgEqual(t, fmt.Sprintf("test%v", i), m[0])
+		require.NoError(t, ackFunc(ctx, nil))
+	}
+
+	block.EndOfInput()
+	_, _, err := block.ReadBatch(ctx)
+	assert.Equal(t, service.ErrEndOfBuffer, err)
+}
+
+// diskBufCrash simulates the process being killed by closing the segment
+// files without persisting the consumer offset.
+func diskBufCrash(d *diskBuffer) {
+	d.cond.L.Lock()
+	defer d.cond.L.Unlock()
+
+	d.closed = true
+	d.cond.Broadcast()
+	d.closeSegments()
+}
+
+func TestDiskBufferCrashRecovery(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	dir := t.TempDir()
+	conf := fmt.Sprintf(`
+directory: %v
+max_segment_size: 80
+`, dir)
+
+	writeN := func(block *diskBuffer, prefix string, n int) {
+		t.Helper()
+		for i := 0; i < n; i++ {
+			require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+				service.NewMessage([]byte(fmt.Sprintf("%v%v", prefix, i))),
+			}, noopAck))
+		}
+	}
+
+	readN := func(block *diskBuffer, prefix string, from, to int) {
+		t.Helper()
+		for i := from; i < to; i++ {
+			m, ackFunc, err := block.ReadBatch(ctx)
+			require.NoError(t, err)
+			require.Len(t, m, 1)
+			diskBufMsgEqual(t, fmt.Sprintf("%v%v", prefix, i), m[0])
+			require.NoError(t, ackFunc(ctx, nil))
+		}
+	}
+
+	// Consume part of the second segment and shut down cleanly, persisting
+	// the consumer offset.
+	block := diskBufFromConf(t, conf)
+	writeN(block, "first", 6)
+	readN(block, "first", 0, 3)
+	require.NoError(t, block.Close(ctx))
+
+	// Acknowledge everything that remains and crash, leaving no segments
+	// behind.
+	block = diskBufFromConf(t, conf)
+	readN(block, "first", 3, 6)
+	diskBufCrash(block)
+
+	// New segments written after a restart must not be mistaken for those
+	// referenced by an earlier consumer offset, and acknowledging a prefix of
+	// them must survive a crash.
+	block = diskBufFromConf(t, conf)
+	writeN(block, "second", 4)
+	readN(block, "second", 0, 1)
+	diskBufCrash(block)
+
+	block = diskBufFromConf(t, conf)
+	defer block.Close(ctx)
+
+	readN(block, "second", 1, 4)
+
+	block.EndOfInput()
+	_, _, err := block.ReadBatch(ctx)
+	assert.Equal(t, service.ErrEndOfBuffer, err)
+}
+
+func TestDiskBufferCorruptTail(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	dir := t.TempDir()
+	conf := fmt.Sprintf(`
+directory: %v
+`, dir)
+
+	block := diskBufFromConf(t, conf)
+	for _, s := range []string{"foo", "bar"} {
+		require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+			service.NewMessage([]byte(s)),
+		}, noopAck))
+	}
+	require.NoError(t, block.Close(ctx))
+
+	// Simulate a crash part way through writing a record.
+	segPath := filepath.Join(dir, fmt.Sprintf("%020d.seg", 0))
+	f, err := os.OpenFile(segPath, os.O_WRONLY|os.O_APPEND, 0o644)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0, 0, 0, 20, 1, 2, 3, 4, 'b', 'a'})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	block = diskBufFromConf(t, conf)
+	defer block.Close(ctx)
+
+	for _, s := range []string{"foo", "bar"} {
+		m, ackFunc, err := block.ReadBatch(ctx)
+		require.NoError(t, err)
+		require.Len(t, m, 1)
+		diskBufMsgEqual(t, s, m[0])
+		require.NoError(t, ackFunc(ctx, nil))
+	}
+
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("baz")),
+	}, noopAck))
+
+	m, ackFunc, err := block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	diskBufMsgEqual(t, "baz", m[0])
+	require.NoError(t, ackFunc(ctx, nil))
+}
+
+func TestDiskBufferCorruptRecord(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	stats := metrics.NewLocal()
+	dir := t.TempDir()
+	parsedConf, err := diskBufferConfig().ParseYAML(fmt.Sprintf(`
+directory: %v
+`, dir), nil)
+	require.NoError(t, err)
+
+	block, err := newDiskBufferFromConfig(parsedConf, service.MockResources(func(m *mock.Manager) {
+		m.M = stats
+	}))
+	require.NoError(t, err)
+	defer block.Close(ctx)
+
+	for _, s := range []string{"foo", "bar", "baz"} {
+		require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+			service.NewMessage([]byte(s)),
+		}, noopAck))
+	}
+
+	// Corrupt the payload of the second record after it has been written.
+	f, err := os.OpenFile(filepath.Join(dir, fmt.Sprintf("%020d.seg", 0)), os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteAt([]byte{0xff, 0xff}, block.segments[0].offsets[1]+diskRecordHeaderLen)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	m, ackFunc, err := block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	diskBufMsgEqual(t, "foo", m[0])
+	require.NoError(t, ackFunc(ctx, nil))
+
+	for i := 0; i < diskMaxReadAttempts-1; i++ {
+		_, _, err = block.ReadBatch(ctx)
+		require.ErrorIs(t, err, errDiskRecordCorrupt)
+	}
+
+	// The corrupted record is dropped rather than stalling the buffer.
+	m, ackFunc, err = block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	diskBufMsgEqual(t, "baz", m[0])
+	require.NoError(t, ackFunc(ctx, nil))
+
+	counters := stats.GetCounters()
+	assert.Equal(t, int64(1), counters["buffer_read_dropped"])
+	assert.Equal(t, int64(0), counters["buffer_backlog_count"])
+
+	block.EndOfInput()
+	_, _, err = block.ReadBatch(ctx)
+	assert.Equal(t, service.ErrEndOfBuffer, err)
+}
+
+func TestDiskBufferTTL(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	dir := t.TempDir()
+	conf := fmt.Sprintf(`
+directory: %v
+ttl: 50ms
+`, dir)
+
+	block := diskBufFromConf(t, conf)
+	for _, s := range []string{"foo", "bar"} {
+		require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+			service.NewMessage([]byte(s)),
+		}, noopAck))
+	}
+	require.NoError(t, block.Close(ctx))
+
+	<-time.After(time.Millisecond * 100)
+
+	stats := metrics.NewLocal()
+	parsedConf, err := diskBufferConfig().ParseYAML(conf, nil)
+	require.NoError(t, err)
+
+	block, err = newDiskBufferFromConfig(parsedConf, service.MockResources(func(m *mock.Manager) {
+		m.M = stats
+	}))
+	require.NoError(t, err)
+	defer block.Close(ctx)
+
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("baz")),
+	}, noopAck))
+
+	m, ackFunc, err := block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	diskBufMsgEqual(t, "baz", m[0])
+	require.NoError(t, ackFunc(ctx, nil))
+
+	assert.Equal(t, int64(2), stats.GetCounters()["buffer_ttl_dropped"])
+
+	segments, err := filepath.Glob(filepath.Join(dir, "*.seg"))
+	require.NoError(t, err)
+	assert.Empty(t, segments)
+}
+
+func TestDiskBufferBacklogMetrics(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	dir := t.TempDir()
+	parsedConf, err := diskBufferConfig().ParseYAML(fmt.Sprintf(`
+directory: %v
+`, dir), nil)
+	require.NoError(t, err)
+
+	newBlock := func(stats *metrics.Local) *diskBuffer {
+		block, err := newDiskBufferFromConfig(parsedConf, service.MockResources(func(m *mock.Manager) {
+			m.M = stats
+		}))
+		require.NoError(t, err)
+		return block
+	}
+
+	stats := metrics.NewLocal()
+	block := newBlock(stats)
+
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("foo")),
+		service.NewMessage([]byte("bar")),
+	}, noopAck))
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("baz")),
+	}, noopAck))
+
+	counters := stats.GetCounters()
+	assert.Equal(t, int64(3), counters["buffer_backlog_count"])
+	assert.Greater(t, counters["buffer_backlog_bytes"], int64(0))
+
+	m, ackFunc, err := block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 2)
+	require.NoError(t, ackFunc(ctx, nil))
+
+	assert.Equal(t, int64(1), stats.GetCounters()["buffer_backlog_count"])
+	require.NoError(t, block.Close(ctx))
+
+	stats = metrics.NewLocal()
+	block = newBlock(stats)
+	defer block.Close(ctx)
+
+	assert.Equal(t, int64(1), stats.GetCounters()["buffer_backlog_count"])
+
+	m, ackFunc, err = block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	require.NoError(t, ackFunc(ctx, nil))
+
+	counters = stats.GetCounters()
+	assert.Equal(t, int64(0), counters["buffer_backlog_count"])
+	assert.Equal(t, int64(0), counters["buffer_backlog_bytes"])
+}
+
+func TestDiskBufferBatched(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	dir := t.TempDir()
+	block := diskBufFromConf(t, fmt.Sprintf(`
+directory: %v
+max_segment_size: 40
+batch_policy:
+  enabled: true
+  count: 3
+`, dir))
+	defer block.Close(ctx)
+
+	for i := 0; i < 7; i++ {
+		require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+			service.NewMessage([]byte(fmt.Sprintf("test%v", i))),
+		}, noopAck))
+	}
+
+	m, ackFunc, err := block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 3)
+	require.NoError(t, ackFunc(ctx, errors.New("nope")))
+
+	// A rejected batch is redelivered in its entirety.
+	for i := 0; i < 2; i++ {
+		m, ackFunc, err = block.ReadBatch(ctx)
+		require.NoError(t, err)
+		require.Len(t, m, 3)
+		for j := 0; j < 3; j++ {
+			diskBufMsgEqual(t, fmt.Sprintf("test%v", (i*3)+j), m[j])
+		}
+		require.NoError(t, ackFunc(ctx, nil))
+	}
+
+	block.EndOfInput()
+
+	// The final partial batch is flushed at the end of input.
+	m, ackFunc, err = block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	diskBufMsgEqual(t, "test6", m[0])
+	require.NoError(t, ackFunc(ctx, nil))
+
+	_, _, err = block.ReadBatch(ctx)
+	assert.Equal(t, service.ErrEndOfBuffer, err)
+
+	segments, err := filepath.Glob(filepath.Join(dir, "*.seg"))
+	require.NoError(t, err)
+	assert.Empty(t, segments)
+}
+
+func TestDiskBufferBatchedTimed(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	block := diskBufFromConf(t, fmt.Sprintf(`
+directory: %v
+batch_policy:
+  enabled: true
+  count: 10
+  period: 50ms
+`, t.TempDir()))
+	defer block.Close(ctx)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+			service.NewMessage([]byte(fmt.Sprintf("test%v", i))),
+		}, noopAck))
+	}
+
+	m, ackFunc, err := block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 2)
+	diskBufMsgEqual(t, "test0", m[0])
+	diskBufMsgEqual(t, "test1", m[1])
+	require.NoError(t, ackFunc(ctx, nil))
+}
+
+func TestDiskBufferBackPressure(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	block := diskBufFromConf(t, fmt.Sprintf(`
+directory: %v
+max_segment_size: 10
+max_total_size: 120
+`, t.TempDir()))
+	defer block.Close(ctx)
+
+	err := block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage(make([]byte, 200)),
+	}, noopAck)
+	assert.Equal(t, component.ErrMessageTooLarge, err)
+
+	content := make([]byte, 20)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+			service.NewMessage(content),
+		}, noopAck))
+	}
+
+	writeErr := make(chan error, 1)
+	go func() {
+		writeErr <- block.WriteBatch(ctx, service.MessageBatch{
+			service.NewMessage(content),
+		}, noopAck)
+	}()
+
+	select {
+	case err := <-writeErr:
+		t.Fatalf("expected write to block, got: %v", err)
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	_, ackFunc, err := block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.NoError(t, ackFunc(ctx, nil))
+
+	select {
+	case err := <-writeErr:
+		require.NoError(t, err)
+	case <-ctx.Done():
+		t.Fatal("timed out")
+	}
+}
+
+func TestDiskBufferSlowUpstreamAck(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	block := diskBufFromConf(t, fmt.Sprintf(`
+directory: %v
+`, t.TempDir()))
+	defer block.Close(ctx)
+
+	ackStarted, releaseAck := make(chan struct{}), make(chan struct{})
+	writeErr := make(chan error, 1)
+	go func() {
+		writeErr <- block.WriteBatch(ctx, service.MessageBatch{
+			service.NewMessage([]byte("hello")),
+		}, func(ctx context.Context, err error) error {
+			close(ackStarted)
+			<-releaseAck
+			return nil
+		})
+	}()
+
+	select {
+	case <-ackStarted:
+	case <-ctx.Done():
+		t.Fatal("timed out")
+	}
+
+	// Reads and writes must not be blocked by a slow upstream acknowledgement.
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("world")),
+	}, noopAck))
+
+	for _, exp := range []string{"hello", "world"} {
+		msgs, ackFunc, err := block.ReadBatch(ctx)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		diskBufMsgEqual(t, exp, msgs[0])
+		require.NoError(t, ackFunc(ctx, nil))
+	}
+
+	close(releaseAck)
+	require.NoError(t, <-writeErr)
+}
+
+func TestDiskBufferMetadataTypes(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	block := diskBufFromConf(t, fmt.Sprintf(`
+directory: %v
+`, t.TempDir()))
+	defer block.Close(ctx)
+
+	meta := map[string]any{
+		"string":   "foo",
+		"bool":     true,
+		"small":    int64(5),
+		"negative": int64(-300),
+		"large":    int64(1 << 40),
+		"huge":     uint64(math.MaxUint64),
+		"float":    1.5,
+		"array":    []any{int64(1), "two", 3.5},
+		"object":   map[string]any{"nested": int64(7)},
+	}
+
+	msg := service.NewMessage([]byte("hello"))
+	for k, v := range meta {
+		msg.MetaSetMut(k, v)
+	}
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{msg}, noopAck))
+
+	m, ackFunc, err := block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+
+	for k, exp := range meta {
+		act, exists := m[0].MetaGetMut(k)
+		require.True(t, exists, k)
+		assert.Equal(t, exp, act, k)
+	}
+	require.NoError(t, ackFunc(ctx, nil))
+}
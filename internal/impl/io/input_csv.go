@@ -60,13 +60,18 @@ This input adds the following metadata fields to each message:
 `+"```text"+`
 - header
 - path
+- row
 - mod_time_unix
 - mod_time (RFC3339)
 `+"```"+`
 
 You can access these metadata fields using [function interpolation](/docs/configuration/interpolation#bloblang-queries).
 
-Note: The `+"`header`"+` field is only set when `+"`parse_header_row`"+` is `+"`true`"+`.
+Note: The `+"`header`"+` field is only set when `+"`parse_header_row`"+` is `+"`true`"+`. The `+"`row`"+` field is the position of the record within its file starting at 1, and does not count the header row.
+
+### Malformed Rows
+
+Rows that cannot be parsed do not abort the consumption of a file. Instead, they are emitted as messages with an error flag set, which can be detected and handled with [error handling patterns](/docs/configuration/error_handling). When the fields of the row could be parsed (such as when a row has the wrong number of fields in strict mode) the message contains those fields, otherwise the message is empty.
 
 ### Output CSV column order
 
@@ -249,6 +254,7 @@ type csvReader struct {
 	scanner     *csv.Reader
 	scannerInfo csvScannerInfo
 	header      []any
+	row         int
 
 	expectHeader bool
 	comma        rune
@@ -376,6 +382,7 @@ func (r *csvReader) Connect(ctx context.Context) error {
 
 	r.scanner = scanner
 	r.scannerInfo = scannerInfo
+	r.row = 0
 
 	return nil
 }
@@ -398,7 +405,7 @@ func (r *csvReader) readNext(reader *csv.Reader) ([]string, error) {
 			}
 			return nil, service.ErrNotConnected
 		}
-		return nil, err
+		return record, err
 	}
 	return record, nil
 }
@@ -408,23 +415,25 @@ func (r *csvReader) ReadBatch(ctx context.Context) (service.MessageBatch, servic
 	scanner := r.scanner
 	scannerInfo := r.scannerInfo
 	header := r.header
+	row := r.row
 	r.mut.Unlock()
 
 	if scanner == nil {
 		return nil, nil, service.ErrNotConnected
 	}
 
+	defer func() {
+		r.mut.Lock()
+		if r.scanner == scanner {
+			r.row = row
+		}
+		r.mut.Unlock()
+	}()
+
 	msg := service.MessageBatch{}
 	for i := 0; i < r.groupCount; i++ {
 		record, err := r.readNext(scanner)
-		if err != nil {
-			if i == 0 {
-				return nil, nil, err
-			}
-			break
-		}
-
-		if r.expectHeader && header == nil {
+		if err == nil && r.expectHeader && header == nil {
 			header = make([]any, 0, len(record))
 			for _, rec := range record {
 				header = append(header, rec)
@@ -434,12 +443,22 @@ func (r *csvReader) ReadBatch(ctx context.Context) (service.MessageBatch, servic
 			r.header = header
 			r.mut.Unlock()
 
-			if record, err = r.readNext(scanner); err != nil {
+			record, err = r.readNext(scanner)
+		}
+
+		var parseErr *csv.ParseError
+		if err != nil && (!errors.As(err, &parseErr) || (r.expectHeader && header == nil)) {
+			if i == 0 {
 				return nil, nil, err
 			}
+			break
 		}
+		row++
 
 		part := service.NewMessage(nil)
+		if err != nil {
+			part.SetError(fmt.Errorf("malformed row: %w", err))
+		}
 
 		var structured any
 		if len(header) == 0 || len(header) < len(record) {
@@ -461,10 +480,13 @@ func (r *csvReader) ReadBatch(ctx context.Context) (service.MessageBatch, servic
 		}
 
 		part.MetaSetMut("path", scannerInfo.currentPath)
+		part.MetaSetMut("row", row)
 		part.MetaSetMut("mod_time_unix", scannerInfo.modTimeUTC.Unix())
 		part.MetaSetMut("mod_time", scannerInfo.modTimeUTC.Format(time.RFC3339))
 
-		part.SetStructuredMut(structured)
+		if len(record) > 0 || err == nil {
+			part.SetStructuredMut(structured)
+		}
 		msg = append(msg, part)
 	}
 
@@ -3,7 +3,6 @@ package io
 import (
 	"bytes"
 	"context"
-	"errors"
 	"io"
 	"strconv"
 	"testing"
@@ -386,27 +385,32 @@ func TestCSVReaderStrict(t *testing.T) {
 
 	require.NoError(t, f.Connect(context.Background()))
 
-	for _, exp := range []any{
-		`{"header1":"foo1","header2":"foo2","header3":"foo3"}`,
-		errors.New("record on line 3: wrong number of fields"),
-		`{"header1":"baz1","header2":"baz2","header3":"baz3"}`,
-		errors.New("record on line 5: wrong number of fields"),
+	for i, exp := range []struct {
+		content string
+		err     string
+	}{
+		{content: `{"header1":"foo1","header2":"foo2","header3":"foo3"}`},
+		{content: `["bar1","bar2","bar3","bar4"]`, err: "malformed row: record on line 3: wrong number of fields"},
+		{content: `{"header1":"baz1","header2":"baz2","header3":"baz3"}`},
+		{content: `{"header1":"buz1","header2":"buz2"}`, err: "malformed row: record on line 5: wrong number of fields"},
 	} {
 		var resMsg service.MessageBatch
 		resMsg, _, err = f.ReadBatch(context.Background())
+		require.NoError(t, err)
+		require.Len(t, resMsg, 1)
 
-		switch expT := exp.(type) {
-		case string:
-			require.NoError(t, err)
-
-			mBytes, err := resMsg[0].AsBytes()
-			require.NoError(t, err)
-
-			assert.Equal(t, expT, string(mBytes))
+		mBytes, err := resMsg[0].AsBytes()
+		require.NoError(t, err)
+		assert.Equal(t, exp.content, string(mBytes))
 
-		case error:
-			assert.EqualError(t, err, expT.Error())
+		if exp.err != "" {
+			assert.EqualError(t, resMsg[0].GetError(), exp.err)
+		} else {
+			assert.NoError(t, resMsg[0].GetError())
 		}
+
+		row, _ := resMsg[0].MetaGetMut("row")
+		assert.Equal(t, i+1, row)
 	}
 
 	_, _, err = f.ReadBatch(context.Background())
@@ -451,7 +455,7 @@ func TestCSVReaderLazyQuotes(t *testing.T) {
 		{
 			name:       "quotes in unquoted field AND non-doubled quote in quoted field w/ LazyQuotes = true",
 			input:      `f"oo"1,"f"oo2",f"oo"3`,
-			expected:   `[\"f"oo"1\","f"oo2",\"f"oo"3\"]`,
+			expected:   `["f\"oo\"1","f\"oo2","f\"oo\"3"]`,
 			lazyQuotes: true,
 		},
 		{
@@ -484,11 +488,13 @@ func TestCSVReaderLazyQuotes(t *testing.T) {
 		require.NoError(t, f.Connect(context.Background()), test.name)
 
 		resMsg, _, err := f.ReadBatch(context.Background())
+		require.NoError(t, err, test.name)
 		if test.errContains != "" {
-			require.Contains(t, err.Error(), test.errContains, test.name)
-			return
+			require.Error(t, resMsg[0].GetError(), test.name)
+			require.Contains(t, resMsg[0].GetError().Error(), test.errContains, test.name)
+			continue
 		}
-		require.NoError(t, err, test.name)
+		require.NoError(t, resMsg[0].GetError(), test.name)
 
 		mBytes, err := resMsg[0].AsBytes()
 		require.NoError(t, err)
@@ -496,3 +502,46 @@ func TestCSVReaderLazyQuotes(t *testing.T) {
 		assert.Equal(t, test.expected, string(mBytes), test.name)
 	}
 }
+
+func TestCSVReaderMalformedRow(t *testing.T) {
+	var handle bytes.Buffer
+	handle.WriteString("header1,header2\nfoo1,foo2\nb\"ar1,bar2\nbaz1,baz2\n")
+
+	f, err := newCSVReader(
+		func(ctx context.Context) (csvScannerInfo, error) {
+			return csvScannerInfo{handle: &handle, currentPath: "foo.csv"}, nil
+		},
+		func(ctx context.Context) {},
+		optCSVSetGroupCount(3),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+		require.NoError(t, f.Close(ctx))
+		done()
+	})
+
+	require.NoError(t, f.Connect(context.Background()))
+
+	resMsg, _, err := f.ReadBatch(context.Background())
+	require.NoError(t, err)
+	require.Len(t, resMsg, 3)
+
+	for i, exp := range []string{
+		`{"header1":"foo1","header2":"foo2"}`,
+		``,
+		`{"header1":"baz1","header2":"baz2"}`,
+	} {
+		mBytes, err := resMsg[i].AsBytes()
+		require.NoError(t, err)
+		assert.Equal(t, exp, string(mBytes), i)
+
+		row, _ := resMsg[i].MetaGetMut("row")
+		assert.Equal(t, i+1, row)
+	}
+
+	assert.NoError(t, resMsg[0].GetError())
+	require.Error(t, resMsg[1].GetError())
+	assert.Contains(t, resMsg[1].GetError().Error(), `bare " in non-quoted-field`)
+	assert.NoError(t, resMsg[2].GetError())
+}
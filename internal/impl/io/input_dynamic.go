@@ -42,7 +42,7 @@ Creates or updates an input with a configuration provided in the request body (i
 
 ### DELETE `+"`/inputs/{id}`"+`
 
-Stops and removes an input.
+Stops and removes an input. The request blocks until all messages already consumed from the input have been acknowledged.
 
 ### GET `+"`/inputs/{id}/uptime`"+`
 
@@ -2,6 +2,7 @@ package io
 
 import (
 	"context"
+	"sync"
 
 	"github.com/Jeffail/shutdown"
 
@@ -28,6 +29,7 @@ type dynamicFanInInput struct {
 	onRemove func(ctx context.Context, label string)
 
 	newInputChan     chan wrappedInput
+	inputsMut        sync.RWMutex
 	inputs           map[string]input.Streamed
 	inputClosedChans map[string]chan struct{}
 
@@ -98,7 +100,13 @@ func (d *dynamicFanInInput) TransactionChan() <-chan message.Transaction {
 }
 
 func (d *dynamicFanInInput) Connected() bool {
-	// Always return true as this is fuzzy right now.
+	d.inputsMut.RLock()
+	defer d.inputsMut.RUnlock()
+	for _, in := range d.inputs {
+		if !in.Connected() {
+			return false
+		}
+	}
 	return true
 }
 
@@ -106,7 +114,11 @@ func (d *dynamicFanInInput) addInput(ident string, in input.Streamed) error {
 	closedChan := make(chan struct{})
 	// Launch goroutine that async writes input into single channel
 	go func(in input.Streamed, cChan chan struct{}) {
+		// Tracks transactions that have been dispatched but not yet resolved,
+		// an input is only considered closed once these are all acknowledged.
+		var pending sync.WaitGroup
 		defer func() {
+			pending.Wait()
 			d.onRemove(context.Background(), ident)
 			close(cChan)
 		}()
@@ -117,13 +129,22 @@ func (d *dynamicFanInInput) addInput(ident string, in input.Streamed) error {
 				// Race condition: This will be called when shutting down.
 				return
 			}
-			d.transactionChan <- in
+
+			pending.Add(1)
+			var resolveOnce sync.Once
+			tran := message.NewTransactionFunc(in.Payload, func(ctx context.Context, err error) error {
+				defer resolveOnce.Do(pending.Done)
+				return in.Ack(ctx, err)
+			})
+			d.transactionChan <- *tran.WithContext(in.Context())
 		}
 	}(in, closedChan)
 
 	// Add new input to our map
+	d.inputsMut.Lock()
 	d.inputs[ident] = in
 	d.inputClosedChans[ident] = closedChan
+	d.inputsMut.Unlock()
 
 	return nil
 }
@@ -144,8 +165,10 @@ func (d *dynamicFanInInput) removeInput(ctx context.Context, ident string) error
 		return ctx.Err()
 	}
 
+	d.inputsMut.Lock()
 	delete(d.inputs, ident)
 	delete(d.inputClosedChans, ident)
+	d.inputsMut.Unlock()
 
 	return nil
 }
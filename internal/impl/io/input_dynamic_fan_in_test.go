@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -318,3 +319,94 @@ func TestStaticDynamicFanInAsync(t *testing.T) {
 }
 
 //------------------------------------------------------------------------------
+
+func TestDynamicFanInRemoveWaitsForPending(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*5)
+	defer done()
+
+	mockInput := &mock.Input{
+		TChan: make(chan message.Transaction),
+	}
+
+	fanIn, err := newDynamicFanInInput(map[string]input.Streamed{
+		"foo": mockInput,
+	}, log.Noop(), nil, nil)
+	require.NoError(t, err)
+
+	resChan := make(chan error, 1)
+	select {
+	case mockInput.TChan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte("hello world")}), resChan):
+	case <-tCtx.Done():
+		t.Fatal("timed out")
+	}
+
+	var ts message.Transaction
+	select {
+	case ts = <-fanIn.TransactionChan():
+	case <-tCtx.Done():
+		t.Fatal("timed out")
+	}
+
+	removeErr := make(chan error, 1)
+	go func() {
+		removeErr <- fanIn.SetInput(tCtx, "foo", nil)
+	}()
+
+	select {
+	case err := <-removeErr:
+		t.Fatalf("input removed before pending transaction resolved: %v", err)
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	require.NoError(t, ts.Ack(tCtx, nil))
+	require.NoError(t, <-resChan)
+
+	select {
+	case err := <-removeErr:
+		require.NoError(t, err)
+	case <-tCtx.Done():
+		t.Fatal("timed out")
+	}
+
+	fanIn.TriggerStopConsuming()
+	require.NoError(t, fanIn.WaitForClose(tCtx))
+}
+
+type connectableMockInput struct {
+	*mock.Input
+	connected atomic.Bool
+}
+
+func (c *connectableMockInput) Connected() bool {
+	return c.connected.Load()
+}
+
+func TestDynamicFanInConnected(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*5)
+	defer done()
+
+	fooInput := &connectableMockInput{Input: &mock.Input{TChan: make(chan message.Transaction)}}
+	fooInput.connected.Store(true)
+
+	fanIn, err := newDynamicFanInInput(map[string]input.Streamed{
+		"foo": fooInput,
+	}, log.Noop(), nil, nil)
+	require.NoError(t, err)
+	require.True(t, fanIn.Connected())
+
+	barInput := &connectableMockInput{Input: &mock.Input{TChan: make(chan message.Transaction)}}
+	require.NoError(t, fanIn.SetInput(tCtx, "bar", barInput))
+	require.False(t, fanIn.Connected())
+
+	barInput.connected.Store(true)
+	require.True(t, fanIn.Connected())
+
+	fooInput.connected.Store(false)
+	require.False(t, fanIn.Connected())
+
+	require.NoError(t, fanIn.SetInput(tCtx, "foo", nil))
+	require.True(t, fanIn.Connected())
+
+	fanIn.TriggerStopConsuming()
+	require.NoError(t, fanIn.WaitForClose(tCtx))
+}
@@ -0,0 +1,577 @@
+package io
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Jeffail/checkpoint"
+	"github.com/Jeffail/shutdown"
+
+	"github.com/benthosdev/benthos/v4/internal/filepath"
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+const (
+	ftiFieldPaths              = "paths"
+	ftiFieldPollInterval       = "poll_interval"
+	ftiFieldStartFromBeginning = "start_from_beginning"
+	ftiFieldStateFile          = "state_file"
+	ftiFieldMaxBuffer          = "max_buffer"
+)
+
+func fileTailInputSpec() *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Categories("Local").
+		Version("4.28.0").
+		Summary(`Follows files on disk as they are appended to and emits each new line as a message, similar to `+"`tail -F`"+`.`).
+		Description(`
+Each file matched by the configured paths is followed independently. When the end of a file is reached the input waits for more data to be written, and checks at each `+"`poll_interval`"+` whether the file has been truncated or rotated. When a file is truncated it is consumed again from the beginning, and when a file is rotated (the path now refers to a different file) the new file is opened and consumed from the beginning. Glob patterns are re-evaluated at the same interval in order to discover new files.
+
+Lines are only emitted once they are terminated with a newline, a trailing line without a newline is emitted when the file is rotated or removed. Lines larger than `+"`max_buffer`"+` are discarded and logged at error level, and consumption continues from the following line.
+
+### Persisting Positions
+
+When a `+"`state_file`"+` is configured the position of each followed file is written to it periodically, where the position of a file only advances once all prior lines of that file have been acknowledged. After a restart files with a persisted position are resumed from that position, which means that lines that were in flight during the shutdown may be delivered again. If a file is smaller than its persisted position it is assumed to have been replaced and is consumed from the beginning.
+
+### Metadata
+
+This input adds the following metadata fields to each message:
+
+`+"```text"+`
+- path
+`+"```"+`
+
+You can access these metadata fields using [function interpolation](/docs/configuration/interpolation#bloblang-queries).`).
+		Example(
+			"Follow Log Files",
+			"Follow all log files within a directory, persisting positions so that a restart resumes where it left off:",
+			`
+input:
+  file_tail:
+    paths: [ /var/log/myapp/*.log ]
+    state_file: ./myapp_tail_state.json
+`,
+		).
+		Fields(
+			service.NewStringListField(ftiFieldPaths).
+				Description("A list of paths to follow. Glob patterns are supported, including super globs (double star)."),
+			service.NewDurationField(ftiFieldPollInterval).
+				Description("The period at which files are checked for new data, truncation and rotation, and at which glob patterns are re-evaluated.").
+				Advanced().
+				Default("1s"),
+			service.NewBoolField(ftiFieldStartFromBeginning).
+				Description("Whether files that exist when the input starts, and that do not have a persisted position, are consumed from the beginning. When `false` only data appended after the input starts is consumed from these files. Files discovered afterwards are always consumed from the beginning.").
+				Default(false),
+			service.NewStringField(ftiFieldStateFile).
+				Description("An optional path to a file within which the positions of followed files are persisted, allowing the input to resume from where it left off after a restart.").
+				Example("./tail_state.json").
+				Optional(),
+			service.NewIntField(ftiFieldMaxBuffer).
+				Description("The maximum size in bytes of an individual line, lines that exceed this size are discarded.").
+				Advanced().
+				Default(bufio.MaxScanTokenSize),
+		)
+}
+
+func init() {
+	err := service.RegisterInput("file_tail", fileTailInputSpec(),
+		func(pConf *service.ParsedConfig, res *service.Resources) (service.Input, error) {
+			r, err := fileTailInputFromParsed(pConf, res)
+			if err != nil {
+				return nil, err
+			}
+			return service.AutoRetryNacks(r), nil
+		})
+	if err != nil {
+		panic(err)
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type fileTailMessage struct {
+	msg   *service.Message
+	ackFn service.AckFunc
+}
+
+type fileTailInput struct {
+	log *service.Logger
+	nm  *service.Resources
+
+	paths              []string
+	pollInterval       time.Duration
+	startFromBeginning bool
+	stateFile          string
+	maxBuffer          int
+
+	stateMut   sync.Mutex
+	state      map[string]int64
+	stateDirty bool
+	started    bool
+
+	msgChan chan fileTailMessage
+	shutSig *shutdown.Signaller
+}
+
+func fileTailInputFromParsed(conf *service.ParsedConfig, nm *service.Resources) (*fileTailInput, error) {
+	f := &fileTailInput{
+		log:     nm.Logger(),
+		nm:      nm,
+		state:   map[string]int64{},
+		msgChan: make(chan fileTailMessage),
+		shutSig: shutdown.NewSignaller(),
+	}
+
+	var err error
+	if f.paths, err = conf.FieldStringList(ftiFieldPaths); err != nil {
+		return nil, err
+	}
+	if f.pollInterval, err = conf.FieldDuration(ftiFieldPollInterval); err != nil {
+		return nil, err
+	}
+	if f.startFromBeginning, err = conf.FieldBool(ftiFieldStartFromBeginning); err != nil {
+		return nil, err
+	}
+	if conf.Contains(ftiFieldStateFile) {
+		if f.stateFile, err = conf.FieldString(ftiFieldStateFile); err != nil {
+			return nil, err
+		}
+	}
+	if f.maxBuffer, err = conf.FieldInt(ftiFieldMaxBuffer); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *fileTailInput) Connect(ctx context.Context) error {
+	f.stateMut.Lock()
+	defer f.stateMut.Unlock()
+
+	if f.started {
+		return nil
+	}
+	f.loadState()
+	f.started = true
+
+	go f.watchLoop()
+	return nil
+}
+
+// loadState must be called with the state mutex held.
+func (f *fileTailInput) loadState() {
+	if f.stateFile == "" {
+		return
+	}
+
+	file, err := f.nm.FS().Open(f.stateFile)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			f.log.Errorf("Failed to open state file '%v', file positions will not be resumed: %v", f.stateFile, err)
+		}
+		return
+	}
+	defer file.Close()
+
+	b, err := io.ReadAll(file)
+	if err != nil {
+		f.log.Errorf("Failed to read state file '%v', file positions will not be resumed: %v", f.stateFile, err)
+		return
+	}
+
+	state := map[string]int64{}
+	if err := json.Unmarshal(b, &state); err != nil {
+		f.log.Errorf("Failed to parse state file '%v', file positions will not be resumed: %v", f.stateFile, err)
+		return
+	}
+	f.state = state
+}
+
+func (f *fileTailInput) saveState() {
+	f.stateMut.Lock()
+	defer f.stateMut.Unlock()
+
+	if f.stateFile == "" || !f.stateDirty {
+		return
+	}
+
+	b, err := json.Marshal(f.state)
+	if err != nil {
+		f.log.Errorf("Failed to encode file positions: %v", err)
+		return
+	}
+
+	if err := f.writeStateFile(b); err != nil {
+		f.log.Errorf("Failed to write state file '%v': %v", f.stateFile, err)
+		return
+	}
+	f.stateDirty = false
+}
+
+// writeStateFile writes the state to a temporary file within the same
+// directory and renames it over the state file, so that a failed write never
+// leaves a truncated state file behind.
+func (f *fileTailInput) writeStateFile(b []byte) error {
+	tmpPath := f.stateFile + ".tmp"
+
+	file, err := f.nm.FS().OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	w, ok := file.(io.Writer)
+	if !ok {
+		_ = file.Close()
+		return errors.New("file is not writable")
+	}
+	if _, err := w.Write(b); err != nil {
+		_ = file.Close()
+		_ = f.nm.FS().Remove(tmpPath)
+		return err
+	}
+	if s, ok := file.(interface{ Sync() error }); ok {
+		if err := s.Sync(); err != nil {
+			_ = file.Close()
+			_ = f.nm.FS().Remove(tmpPath)
+			return err
+		}
+	}
+	if err := file.Close(); err != nil {
+		_ = f.nm.FS().Remove(tmpPath)
+		return err
+	}
+	return f.nm.FS().Rename(tmpPath, f.stateFile)
+}
+
+func (f *fileTailInput) getOffset(path string) (int64, bool) {
+	f.stateMut.Lock()
+	defer f.stateMut.Unlock()
+
+	offset, exists := f.state[path]
+	return offset, exists
+}
+
+func (f *fileTailInput) setOffset(path string, offset int64) {
+	f.stateMut.Lock()
+	defer f.stateMut.Unlock()
+
+	f.state[path] = offset
+	f.stateDirty = true
+}
+
+func (f *fileTailInput) deleteOffset(path string) {
+	f.stateMut.Lock()
+	defer f.stateMut.Unlock()
+
+	delete(f.state, path)
+	f.stateDirty = true
+}
+
+//------------------------------------------------------------------------------
+
+func (f *fileTailInput) watchLoop() {
+	var wg sync.WaitGroup
+	defer func() {
+		wg.Wait()
+		f.saveState()
+		close(f.msgChan)
+		f.shutSig.TriggerHasStopped()
+	}()
+
+	var activeMut sync.Mutex
+	active := map[string]struct{}{}
+
+	ticker := time.NewTicker(f.pollInterval)
+	defer ticker.Stop()
+
+	initialScan := true
+	for {
+		paths, err := filepath.Globs(f.nm.FS(), f.paths)
+		if err != nil {
+			f.log.Errorf("Failed to expand paths: %v", err)
+		}
+
+		for _, path := range paths {
+			activeMut.Lock()
+			_, exists := active[path]
+			active[path] = struct{}{}
+			activeMut.Unlock()
+			if exists {
+				continue
+			}
+
+			wg.Add(1)
+			go func(path string, fromBeginning bool) {
+				defer wg.Done()
+				f.tailLoop(path, fromBeginning)
+
+				activeMut.Lock()
+				delete(active, path)
+				activeMut.Unlock()
+			}(path, f.startFromBeginning || !initialScan)
+		}
+		initialScan = false
+
+		f.saveState()
+
+		select {
+		case <-ticker.C:
+		case <-f.shutSig.SoftStopChan():
+			return
+		}
+	}
+}
+
+type fileTailer struct {
+	path   string
+	file   fs.File
+	info   fs.FileInfo
+	reader *bufio.Reader
+	offset int64
+
+	// The checkpointer of the currently opened file, which is replaced each
+	// time the file is reopened so that acknowledgements of lines from a prior
+	// file do not modify the persisted position.
+	cpMut sync.Mutex
+	cp    *checkpoint.Uncapped[int64]
+}
+
+func (f *fileTailInput) openTailer(t *fileTailer, offset int64, fromEnd bool) error {
+	file, err := f.nm.FS().Open(t.path)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	if fromEnd {
+		offset = info.Size()
+	} else if offset > info.Size() {
+		offset = 0
+	}
+
+	if offset > 0 {
+		if seeker, ok := file.(io.Seeker); ok {
+			_, err = seeker.Seek(offset, io.SeekStart)
+		} else {
+			_, err = io.CopyN(io.Discard, file, offset)
+		}
+		if err != nil {
+			file.Close()
+			return err
+		}
+	}
+
+	t.file = file
+	t.info = info
+	t.reader = bufio.NewReader(file)
+	t.offset = offset
+
+	t.cpMut.Lock()
+	t.cp = checkpoint.NewUncapped[int64]()
+	t.cpMut.Unlock()
+
+	f.setOffset(t.path, offset)
+	return nil
+}
+
+func (f *fileTailInput) closeTailer(t *fileTailer) {
+	if t.file != nil {
+		t.file.Close()
+		t.file = nil
+	}
+	t.cpMut.Lock()
+	t.cp = nil
+	t.cpMut.Unlock()
+}
+
+// sameFile returns whether two file infos refer to the same file. If the
+// underlying filesystem does not expose file identities then they are assumed
+// to be the same.
+func sameFile(a, b fs.FileInfo) bool {
+	if a.Sys() == nil || b.Sys() == nil {
+		return true
+	}
+	return os.SameFile(a, b)
+}
+
+func (f *fileTailInput) emitLine(t *fileTailer, line []byte) bool {
+	t.cpMut.Lock()
+	cp := t.cp
+	resolveFn := cp.Track(t.offset, 1)
+	t.cpMut.Unlock()
+
+	msg := service.NewMessage(line)
+	msg.MetaSetMut("path", t.path)
+
+	select {
+	case f.msgChan <- fileTailMessage{
+		msg: msg,
+		ackFn: func(ctx context.Context, err error) error {
+			t.cpMut.Lock()
+			highest := resolveFn()
+			current := cp == t.cp
+			t.cpMut.Unlock()
+
+			if current && highest != nil {
+				f.setOffset(t.path, *highest)
+			}
+			return nil
+		},
+	}:
+	case <-f.shutSig.SoftStopChan():
+		return false
+	}
+	return true
+}
+
+func (f *fileTailInput) tailLoop(path string, fromBeginning bool) {
+	t := &fileTailer{path: path}
+
+	offset, hasOffset := f.getOffset(path)
+	if err := f.openTailer(t, offset, !hasOffset && !fromBeginning); err != nil {
+		f.log.Errorf("Failed to open file '%v': %v", path, err)
+		return
+	}
+	defer f.closeTailer(t)
+
+	f.log.Debugf("Following file '%v' from offset %v", path, t.offset)
+
+	// When a line exceeds the max buffer the remainder of it is discarded
+	// until its terminating newline is reached.
+	var partial []byte
+	var discarding bool
+	flushPartial := func() bool {
+		if len(partial) == 0 {
+			return true
+		}
+		line := partial
+		partial = nil
+		return f.emitLine(t, line)
+	}
+
+	for {
+		chunk, err := t.reader.ReadSlice('\n')
+		if len(chunk) > 0 {
+			t.offset += int64(len(chunk))
+			if !discarding {
+				partial = append(partial, chunk...)
+				if len(bytes.TrimSuffix(partial, []byte("\n"))) > f.maxBuffer {
+					f.log.Errorf("Discarding line of file '%v' that exceeds the max buffer size of %v bytes", path, f.maxBuffer)
+					partial = nil
+					discarding = true
+				}
+			}
+		}
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		}
+		if err == nil {
+			if discarding {
+				discarding = false
+				continue
+			}
+			line := bytes.TrimSuffix(partial, []byte("\n"))
+			partial = nil
+			if !f.emitLine(t, line) {
+				return
+			}
+			continue
+		}
+		if !errors.Is(err, io.EOF) {
+			f.log.Errorf("Failed to read file '%v': %v", path, err)
+			return
+		}
+
+		info, err := f.nm.FS().Stat(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				f.log.Debugf("File '%v' has been removed", path)
+				_ = flushPartial()
+				f.deleteOffset(path)
+			} else {
+				f.log.Errorf("Failed to stat file '%v': %v", path, err)
+			}
+			return
+		}
+
+		if !sameFile(info, t.info) {
+			f.log.Debugf("File '%v' has been rotated, reopening", path)
+			if !flushPartial() {
+				return
+			}
+			discarding = false
+			f.closeTailer(t)
+			if err := f.openTailer(t, 0, false); err != nil {
+				f.log.Errorf("Failed to open file '%v': %v", path, err)
+				return
+			}
+			continue
+		}
+
+		if info.Size() < t.offset {
+			f.log.Debugf("File '%v' has been truncated, consuming from the beginning", path)
+			partial = nil
+			discarding = false
+			f.closeTailer(t)
+			if err := f.openTailer(t, 0, false); err != nil {
+				f.log.Errorf("Failed to open file '%v': %v", path, err)
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-time.After(f.pollInterval):
+		case <-f.shutSig.SoftStopChan():
+			return
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+func (f *fileTailInput) Read(ctx context.Context) (*service.Message, service.AckFunc, error) {
+	select {
+	case m, open := <-f.msgChan:
+		if !open {
+			return nil, nil, service.ErrEndOfInput
+		}
+		return m.msg, m.ackFn, nil
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
+
+func (f *fileTailInput) Close(ctx context.Context) error {
+	f.shutSig.TriggerSoftStop()
+
+	f.stateMut.Lock()
+	started := f.started
+	f.stateMut.Unlock()
+	if !started {
+		return nil
+	}
+
+	select {
+	case <-f.shutSig.HasStoppedChan():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	// Persist any positions resolved since the watch loop finished.
+	f.saveState()
+	return nil
+}
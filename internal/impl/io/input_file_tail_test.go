@@ -0,0 +1,241 @@
+package io
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func fileTailFromConf(t testing.TB, confStr string, bits ...any) *fileTailInput {
+	t.Helper()
+
+	pConf, err := fileTailInputSpec().ParseYAML(fmt.Sprintf(confStr, bits...), nil)
+	require.NoError(t, err)
+
+	f, err := fileTailInputFromParsed(pConf, service.MockResources())
+	require.NoError(t, err)
+
+	require.NoError(t, f.Connect(context.Background()))
+	t.Cleanup(func() {
+		ctx, done := context.WithTimeout(context.Background(), time.Second*5)
+		defer done()
+		require.NoError(t, f.Close(ctx))
+	})
+	return f
+}
+
+func fileTailRead(t testing.TB, f *fileTailInput) (string, string, service.AckFunc) {
+	t.Helper()
+
+	ctx, done := context.WithTimeout(context.Background(), time.Second*5)
+	defer done()
+
+	msg, ackFn, err := f.Read(ctx)
+	require.NoError(t, err)
+
+	mBytes, err := msg.AsBytes()
+	require.NoError(t, err)
+
+	path, _ := msg.MetaGet("path")
+	return string(mBytes), path, ackFn
+}
+
+func fileTailAppend(t testing.TB, path, data string) {
+	t.Helper()
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	require.NoError(t, err)
+
+	_, err = file.WriteString(data)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+}
+
+func TestFileTailFollow(t *testing.T) {
+	dir := t.TempDir()
+	fooPath := filepath.Join(dir, "foo.log")
+
+	fileTailAppend(t, fooPath, "ignored\n")
+
+	f := fileTailFromConf(t, `
+paths: [ "%v" ]
+poll_interval: 10ms
+`, filepath.Join(dir, "*.log"))
+
+	// Give the input a chance to open the file from the end.
+	time.Sleep(time.Millisecond * 100)
+	fileTailAppend(t, fooPath, "first\nsec")
+
+	content, path, _ := fileTailRead(t, f)
+	assert.Equal(t, "first", content)
+	assert.Equal(t, fooPath, path)
+
+	fileTailAppend(t, fooPath, "ond\n")
+	content, _, _ = fileTailRead(t, f)
+	assert.Equal(t, "second", content)
+
+	// New files are consumed from the beginning.
+	barPath := filepath.Join(dir, "bar.log")
+	fileTailAppend(t, barPath, "third\n")
+
+	content, path, _ = fileTailRead(t, f)
+	assert.Equal(t, "third", content)
+	assert.Equal(t, barPath, path)
+}
+
+func TestFileTailTruncateAndRotate(t *testing.T) {
+	dir := t.TempDir()
+	fooPath := filepath.Join(dir, "foo.log")
+
+	fileTailAppend(t, fooPath, "first\nsecond\n")
+
+	f := fileTailFromConf(t, `
+paths: [ "%v" ]
+poll_interval: 10ms
+start_from_beginning: true
+`, fooPath)
+
+	content, _, _ := fileTailRead(t, f)
+	assert.Equal(t, "first", content)
+	content, _, _ = fileTailRead(t, f)
+	assert.Equal(t, "second", content)
+
+	require.NoError(t, os.Truncate(fooPath, 0))
+	time.Sleep(time.Millisecond * 100)
+	fileTailAppend(t, fooPath, "third\n")
+
+	content, _, _ = fileTailRead(t, f)
+	assert.Equal(t, "third", content)
+
+	require.NoError(t, os.Rename(fooPath, filepath.Join(dir, "foo.log.1")))
+	fileTailAppend(t, fooPath, "fourth\n")
+
+	content, _, _ = fileTailRead(t, f)
+	assert.Equal(t, "fourth", content)
+}
+
+func TestFileTailMaxBuffer(t *testing.T) {
+	dir := t.TempDir()
+	fooPath := filepath.Join(dir, "foo.log")
+
+	// The long line exceeds the read buffer as well as the max buffer.
+	fileTailAppend(t, fooPath, "first\n"+strings.Repeat("x", 10000)+"\n0123456789\n")
+
+	f := fileTailFromConf(t, `
+paths: [ "%v" ]
+poll_interval: 10ms
+start_from_beginning: true
+max_buffer: 10
+`, fooPath)
+
+	content, _, _ := fileTailRead(t, f)
+	assert.Equal(t, "first", content)
+	content, _, _ = fileTailRead(t, f)
+	assert.Equal(t, "0123456789", content)
+
+	// A long line that is written across multiple appends is also discarded.
+	fileTailAppend(t, fooPath, "abcdefgh")
+	time.Sleep(time.Millisecond * 50)
+	fileTailAppend(t, fooPath, "ijklmnop\nsecond\n")
+
+	content, _, _ = fileTailRead(t, f)
+	assert.Equal(t, "second", content)
+}
+
+func TestFileTailStateFile(t *testing.T) {
+	dir := t.TempDir()
+	fooPath := filepath.Join(dir, "foo.log")
+	statePath := filepath.Join(dir, "state.json")
+
+	fileTailAppend(t, fooPath, "first\nsecond\nthird\n")
+
+	conf := `
+paths: [ "%v" ]
+poll_interval: 10ms
+start_from_beginning: true
+state_file: "%v"
+`
+
+	f, err := fileTailInputFromParsed(func() *service.ParsedConfig {
+		pConf, err := fileTailInputSpec().ParseYAML(fmt.Sprintf(conf, fooPath, statePath), nil)
+		require.NoError(t, err)
+		return pConf
+	}(), service.MockResources())
+	require.NoError(t, err)
+	require.NoError(t, f.Connect(context.Background()))
+
+	content, _, ackFn := fileTailRead(t, f)
+	assert.Equal(t, "first", content)
+	require.NoError(t, ackFn(context.Background(), nil))
+
+	// The second line is read but never acknowledged.
+	content, _, _ = fileTailRead(t, f)
+	assert.Equal(t, "second", content)
+
+	ctx, done := context.WithTimeout(context.Background(), time.Second*5)
+	require.NoError(t, f.Close(ctx))
+	done()
+
+	stateBytes, err := os.ReadFile(statePath)
+	require.NoError(t, err)
+	assert.JSONEq(t, fmt.Sprintf(`{%q:6}`, fooPath), string(stateBytes))
+
+	_, err = os.Stat(statePath + ".tmp")
+	assert.True(t, os.IsNotExist(err), "temporary state file should not remain")
+
+	f = fileTailFromConf(t, conf, fooPath, statePath)
+
+	content, _, _ = fileTailRead(t, f)
+	assert.Equal(t, "second", content)
+	content, _, _ = fileTailRead(t, f)
+	assert.Equal(t, "third", content)
+}
+
+func TestFileTailStateFileWriteFailure(t *testing.T) {
+	dir := t.TempDir()
+	fooPath := filepath.Join(dir, "foo.log")
+	statePath := filepath.Join(dir, "state.json")
+
+	fileTailAppend(t, fooPath, "first\nsecond\nthird\n")
+
+	existingState := fmt.Sprintf(`{%q:6}`, fooPath)
+	require.NoError(t, os.WriteFile(statePath, []byte(existingState), 0o644))
+
+	// Writing the temporary state file fails as a directory is in its place.
+	require.NoError(t, os.Mkdir(statePath+".tmp", 0o755))
+
+	f, err := fileTailInputFromParsed(func() *service.ParsedConfig {
+		pConf, err := fileTailInputSpec().ParseYAML(fmt.Sprintf(`
+paths: [ "%v" ]
+poll_interval: 10ms
+start_from_beginning: true
+state_file: "%v"
+`, fooPath, statePath), nil)
+		require.NoError(t, err)
+		return pConf
+	}(), service.MockResources())
+	require.NoError(t, err)
+	require.NoError(t, f.Connect(context.Background()))
+
+	content, _, ackFn := fileTailRead(t, f)
+	assert.Equal(t, "second", content)
+	require.NoError(t, ackFn(context.Background(), nil))
+
+	ctx, done := context.WithTimeout(context.Background(), time.Second*5)
+	defer done()
+	require.NoError(t, f.Close(ctx))
+
+	// The previous state is left intact rather than truncated.
+	stateBytes, err := os.ReadFile(statePath)
+	require.NoError(t, err)
+	assert.JSONEq(t, existingState, string(stateBytes))
+}
@@ -238,7 +238,7 @@ You can access these metadata fields using [function interpolation](/docs/config
 				Version("3.33.0").
 				Default([]any{"POST"}),
 			service.NewDurationField(hsiFieldTimeout).
-				Description("Timeout for requests. If a consumed messages takes longer than this to be delivered the connection is closed, but the message may still be delivered.").
+				Description("Timeout for requests. If a consumed messages takes longer than this to be delivered a 504 response is returned and the connection is closed, but the message may still be delivered.").
 				Default("5s"),
 			service.NewStringField(hsiFieldRateLimit).
 				Description("An optional [rate limit](/docs/components/rate_limits/about) to throttle requests by.").
@@ -578,7 +578,7 @@ func (h *httpServerInput) postHandler(w http.ResponseWriter, r *http.Request) {
 	select {
 	case h.transactions <- message.NewTransaction(msg, resChan):
 	case <-time.After(h.conf.Timeout):
-		http.Error(w, "Request timed out", http.StatusRequestTimeout)
+		http.Error(w, "Request timed out", http.StatusGatewayTimeout)
 		return
 	case <-r.Context().Done():
 		http.Error(w, "Request timed out", http.StatusRequestTimeout)
@@ -600,7 +600,7 @@ func (h *httpServerInput) postHandler(w http.ResponseWriter, r *http.Request) {
 		tTaken := time.Since(startedAt).Nanoseconds()
 		h.mLatency.Timing(tTaken)
 	case <-time.After(h.conf.Timeout):
-		http.Error(w, "Request timed out", http.StatusRequestTimeout)
+		http.Error(w, "Request timed out", http.StatusGatewayTimeout)
 		return
 	case <-r.Context().Done():
 		http.Error(w, "Request timed out", http.StatusRequestTimeout)
@@ -694,7 +694,7 @@ http_server:
 	if err != nil {
 		t.Fatal(err)
 	}
-	if exp, act := http.StatusRequestTimeout, res.StatusCode; exp != act {
+	if exp, act := http.StatusGatewayTimeout, res.StatusCode; exp != act {
 		t.Errorf("Unexpected status code: %v != %v", exp, act)
 	}
 
@@ -39,6 +39,18 @@ func socketServerInputSpec() *service.ConfigSpec {
 	return service.NewConfigSpec().
 		Stable().
 		Summary(`Creates a server that receives a stream of messages over a tcp, udp or unix socket.`).
+		Description(`
+### Metadata
+
+This input adds the following metadata fields to each message:
+
+`+"``` text"+`
+- socket_server_remote_addr
+`+"```"+`
+
+For udp sockets the remote address is that of the most recently received datagram from which the message was read.
+
+You can access these metadata fields using [function interpolation](/docs/configuration/interpolation#bloblang-queries).`).
 		Categories("Network").
 		Fields(
 			service.NewStringEnumField(issFieldNetwork, "unix", "tcp", "udp", "tls").
@@ -83,10 +95,14 @@ func init() {
 
 type wrapPacketConn struct {
 	net.PacketConn
+	lastAddr net.Addr
 }
 
 func (w *wrapPacketConn) Read(p []byte) (n int, err error) {
-	n, _, err = w.ReadFrom(p)
+	var addr net.Addr
+	if n, addr, err = w.ReadFrom(p); addr != nil {
+		w.lastAddr = addr
+	}
 	return
 }
 
@@ -254,6 +270,11 @@ acceptLoop:
 				return
 			}
 
+			var remoteAddr string
+			if addr := c.RemoteAddr(); addr != nil {
+				remoteAddr = addr.String()
+			}
+
 			for {
 				parts, ackFn, err := codec.NextBatch(closeCtx)
 				if err != nil {
@@ -267,6 +288,10 @@ acceptLoop:
 				// there's no benefit to aggregating acks.
 				_ = ackFn(closeCtx, nil)
 
+				for _, p := range parts {
+					p.MetaSetMut("socket_server_remote_addr", remoteAddr)
+				}
+
 				select {
 				case t.messages <- parts:
 				case <-t.shutSig.SoftStopChan():
@@ -292,7 +317,8 @@ func (t *socketServerInput) udpLoop(conn net.PacketConn) {
 	closeCtx, done := t.shutSig.SoftStopCtx(context.Background())
 	defer done()
 
-	codec, err := t.codecCtor.Create(&wrapPacketConn{PacketConn: conn}, func(ctx context.Context, err error) error {
+	pConn := &wrapPacketConn{PacketConn: conn}
+	codec, err := t.codecCtor.Create(pConn, func(ctx context.Context, err error) error {
 		return nil
 	}, scanner.SourceDetails{})
 	if err != nil {
@@ -313,6 +339,13 @@ func (t *socketServerInput) udpLoop(conn net.PacketConn) {
 		// there's no benefit to aggregating acks.
 		_ = ackFn(closeCtx, nil)
 
+		if pConn.lastAddr != nil {
+			remoteAddr := pConn.lastAddr.String()
+			for _, p := range parts {
+				p.MetaSetMut("socket_server_remote_addr", remoteAddr)
+			}
+		}
+
 		select {
 		case t.messages <- parts:
 		case <-t.shutSig.SoftStopChan():
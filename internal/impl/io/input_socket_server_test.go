@@ -537,6 +537,7 @@ socket_server:
 	msg, err := readNextMsg()
 	require.NoError(t, err)
 	assert.Equal(t, exp, message.GetAllBytes(msg))
+	assert.Equal(t, conn.LocalAddr().String(), msg.Get(0).MetaGetStr("socket_server_remote_addr"))
 
 	exp = [][]byte{[]byte("bar")}
 	msg, err = readNextMsg()
@@ -925,6 +926,7 @@ socket_server:
 	msg, err := readNextMsg()
 	require.NoError(t, err)
 	assert.Equal(t, exp, message.GetAllBytes(msg))
+	assert.Equal(t, conn.LocalAddr().String(), msg.Get(0).MetaGetStr("socket_server_remote_addr"))
 
 	exp = [][]byte{[]byte("bar")}
 	msg, err = readNextMsg()
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 
@@ -35,7 +36,9 @@ func websocketInputSpec() *service.ConfigSpec {
 		Stable().
 		Categories("Network").
 		Summary("Connects to a websocket server and continuously receives messages.").
-		Description(`It is possible to configure an `+"`open_message`"+`, which when set to a non-empty string will be sent to the websocket server each time a connection is first established.`).
+		Description(`It is possible to configure an `+"`open_message`"+`, which when set to a non-empty string will be sent to the websocket server each time a connection is first established.
+
+Both text and binary frames are emitted as messages. When the connection is closed by the server, or is otherwise lost, the input will attempt to reconnect.`).
 		Fields(
 			service.NewURLField("url").
 				Description("The URL to connect to.").
@@ -48,6 +51,16 @@ func websocketInputSpec() *service.ConfigSpec {
 				string(wsOpenMsgTypeText):   "Text data open_message. The text message payload is interpreted as UTF-8 encoded text data.",
 			}).Description("An optional flag to indicate the data type of open_message.").
 				Advanced().Default(string(wsOpenMsgTypeBinary)),
+			service.NewStringMapField("headers").
+				Description("A map of headers to add to the websocket handshake request.").
+				Example(map[string]any{
+					"Authorization": "Bearer abc123",
+				}).
+				Advanced().Default(map[string]any{}),
+			service.NewDurationField("ping_period").
+				Description("An optional period at which ping frames are sent to the server in order to keep the connection alive.").
+				Example("30s").
+				Advanced().Optional(),
 			service.NewAutoRetryNacksToggleField(),
 			service.NewTLSToggledField("tls"),
 		).
@@ -101,6 +114,8 @@ type websocketReader struct {
 
 	openMsgType wsOpenMsgType
 	openMsg     []byte
+	headers     map[string]string
+	pingPeriod  time.Duration
 }
 
 func newWebsocketReaderFromParsed(conf *service.ParsedConfig, mgr bundle.NewManagement) (*websocketReader, error) {
@@ -130,6 +145,14 @@ func newWebsocketReaderFromParsed(conf *service.ParsedConfig, mgr bundle.NewMana
 	if openMsgStr, _ = conf.FieldString("open_message"); openMsgStr != "" {
 		ws.openMsg = []byte(openMsgStr)
 	}
+	if ws.headers, err = conf.FieldStringMap("headers"); err != nil {
+		return nil, err
+	}
+	if conf.Contains("ping_period") {
+		if ws.pingPeriod, err = conf.FieldDuration("ping_period"); err != nil {
+			return nil, err
+		}
+	}
 	return ws, nil
 }
 
@@ -149,6 +172,9 @@ func (w *websocketReader) Connect(ctx context.Context) error {
 	}
 
 	headers := http.Header{}
+	for k, v := range w.headers {
+		headers.Set(k, v)
+	}
 
 	err := w.reqSigner(w.mgr.FS(), &http.Request{
 		URL:    w.urlParsed,
@@ -192,14 +218,33 @@ func (w *websocketReader) Connect(ctx context.Context) error {
 
 	if len(w.openMsg) > 0 {
 		if err := client.WriteMessage(openMsgType, w.openMsg); err != nil {
+			_ = client.Close()
 			return err
 		}
 	}
 
+	if w.pingPeriod > 0 {
+		go w.pingLoop(client)
+	}
+
 	w.client = client
 	return nil
 }
 
+// pingLoop sends ping frames to the server periodically until the connection
+// is closed.
+func (w *websocketReader) pingLoop(client *websocket.Conn) {
+	ticker := time.NewTicker(w.pingPeriod)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := client.WriteControl(websocket.PingMessage, nil, time.Now().Add(w.pingPeriod)); err != nil {
+			w.log.Debug("Stopping websocket pings: %v\n", err)
+			return
+		}
+	}
+}
+
 func (w *websocketReader) ReadBatch(ctx context.Context) (message.Batch, input.AsyncAckFn, error) {
 	client := w.getWS()
 	if client == nil {
@@ -209,7 +254,10 @@ func (w *websocketReader) ReadBatch(ctx context.Context) (message.Batch, input.A
 	_, data, err := client.ReadMessage()
 	if err != nil {
 		w.lock.Lock()
-		w.client = nil
+		if w.client == client {
+			_ = client.Close()
+			w.client = nil
+		}
 		w.lock.Unlock()
 		err = component.ErrNotConnected
 		return nil, nil, err
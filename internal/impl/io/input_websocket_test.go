@@ -240,3 +240,69 @@ url: %v
 	wg.Wait()
 	close(closeChan)
 }
+
+func TestWebsocketHeadersAndPing(t *testing.T) {
+	headerChan := make(chan string, 1)
+	pingChan := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headerChan <- r.Header.Get("X-Foo")
+
+		upgrader := websocket.Upgrader{}
+
+		var ws *websocket.Conn
+		var err error
+		if ws, err = upgrader.Upgrade(w, r, nil); err != nil {
+			return
+		}
+
+		defer ws.Close()
+
+		ws.SetPingHandler(func(string) error {
+			select {
+			case pingChan <- struct{}{}:
+			default:
+			}
+			return nil
+		})
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	wsURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	wsURL.Scheme = "ws"
+
+	pConf, err := websocketInputSpec().ParseYAML(fmt.Sprintf(`
+url: %v
+headers:
+  X-Foo: bar
+ping_period: 10ms
+`, wsURL.String()), nil)
+	require.NoError(t, err)
+
+	m, err := newWebsocketReaderFromParsed(pConf, mock.NewManager())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, m.Connect(ctx))
+
+	select {
+	case v := <-headerChan:
+		require.Equal(t, "bar", v)
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for handshake")
+	}
+
+	select {
+	case <-pingChan:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for ping")
+	}
+
+	require.NoError(t, m.Close(ctx))
+}
@@ -44,7 +44,7 @@ Creates or updates an output with a configuration provided in the request body (
 
 ### DELETE `+"`/outputs/{id}`"+`
 
-Stops and removes an output.
+Stops and removes an output. The request blocks until all messages already sent to the output have been acknowledged.
 
 ### GET `+"`/outputs/{id}/uptime`"+`
 
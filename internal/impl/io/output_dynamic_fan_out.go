@@ -31,6 +31,10 @@ type outputWithTSChan struct {
 	output output.Streamed
 	ctx    context.Context
 	done   func()
+
+	// Tracks transactions that have been sent to the output but not yet
+	// resolved.
+	pending *sync.WaitGroup
 }
 
 type dynamicFanOutOutputBroker struct {
@@ -122,8 +126,9 @@ func (d *dynamicFanOutOutputBroker) addOutput(ident string, output output.Stream
 	}
 
 	ow := outputWithTSChan{
-		tsChan: make(chan message.Transaction),
-		output: output,
+		tsChan:  make(chan message.Transaction),
+		output:  output,
+		pending: &sync.WaitGroup{},
 	}
 
 	if err := output.Consume(ow.tsChan); err != nil {
@@ -136,19 +141,45 @@ func (d *dynamicFanOutOutputBroker) addOutput(ident string, output output.Stream
 	return nil
 }
 
-func (d *dynamicFanOutOutputBroker) removeOutput(ctx context.Context, ident string) error {
+// detachOutput removes an output from the broker so that no further
+// transactions are routed to it, and must be called whilst holding a write
+// lock on outputsMut.
+func (d *dynamicFanOutOutputBroker) detachOutput(ident string) (outputWithTSChan, bool) {
 	ow, exists := d.outputs[ident]
 	if !exists {
-		return nil
+		return ow, false
 	}
 
-	ow.output.TriggerCloseNow()
-	err := ow.output.WaitForClose(ctx)
-
-	ow.done()
+	// Closing the transaction channel allows the output to gracefully finish
+	// sending pending transactions before it shuts down.
 	close(ow.tsChan)
 	delete(d.outputs, ident)
+	return ow, true
+}
+
+// closeDetachedOutput waits for the pending transactions of a detached output
+// to be resolved and for it to shut down. This can take a long time and so it
+// should be called without holding a lock on outputsMut.
+func (d *dynamicFanOutOutputBroker) closeDetachedOutput(ctx context.Context, ow outputWithTSChan) error {
+	defer ow.done()
 
+	pendingDone := make(chan struct{})
+	go func() {
+		ow.pending.Wait()
+		close(pendingDone)
+	}()
+
+	select {
+	case <-pendingDone:
+	case <-ctx.Done():
+		ow.output.TriggerCloseNow()
+		return ctx.Err()
+	}
+
+	err := ow.output.WaitForClose(ctx)
+	if err != nil {
+		ow.output.TriggerCloseNow()
+	}
 	return err
 }
 
@@ -198,31 +229,35 @@ func (d *dynamicFanOutOutputBroker) loop() {
 				if !open {
 					return
 				}
-				func() {
-					d.outputsMut.Lock()
-					defer d.outputsMut.Unlock()
-
-					// First, always remove the previous output if it exists.
-					if _, exists := d.outputs[wrappedOutput.Name]; exists {
-						if err := d.removeOutput(wrappedOutput.Ctx, wrappedOutput.Name); err != nil {
-							d.log.Error("Failed to stop old copy of dynamic output '%v' in time: %v, the output will continue to shut down in the background.\n", wrappedOutput.Name, err)
-						}
-						d.onRemove(wrappedOutput.Name)
+				// First, always remove the previous output if it exists. The
+				// lock is only held whilst detaching the output so that
+				// waiting for it to shut down does not block routing to the
+				// other outputs.
+				d.outputsMut.Lock()
+				ow, exists := d.detachOutput(wrappedOutput.Name)
+				d.outputsMut.Unlock()
+
+				if exists {
+					if err := d.closeDetachedOutput(wrappedOutput.Ctx, ow); err != nil {
+						d.log.Error("Failed to stop old copy of dynamic output '%v' in time: %v, the output will continue to shut down in the background.\n", wrappedOutput.Name, err)
 					}
+					d.onRemove(wrappedOutput.Name)
+				}
 
-					// Next, attempt to create a new output (if specified).
-					if wrappedOutput.Output == nil {
-						wrappedOutput.ResChan <- nil
+				// Next, attempt to create a new output (if specified).
+				if wrappedOutput.Output == nil {
+					wrappedOutput.ResChan <- nil
+				} else {
+					d.outputsMut.Lock()
+					err := d.addOutput(wrappedOutput.Name, wrappedOutput.Output)
+					d.outputsMut.Unlock()
+					if err != nil {
+						d.log.Error("Failed to start new dynamic output '%v': %v\n", wrappedOutput.Name, err)
 					} else {
-						err := d.addOutput(wrappedOutput.Name, wrappedOutput.Output)
-						if err != nil {
-							d.log.Error("Failed to start new dynamic output '%v': %v\n", wrappedOutput.Name, err)
-						} else {
-							d.onAdd(wrappedOutput.Name)
-						}
-						wrappedOutput.ResChan <- err
+						d.onAdd(wrappedOutput.Name)
 					}
-				}()
+					wrappedOutput.ResChan <- err
+				}
 			case <-d.shutSig.SoftStopChan():
 				return
 			}
@@ -260,8 +295,12 @@ func (d *dynamicFanOutOutputBroker) loop() {
 
 	outputsLoop:
 		for _, output := range d.outputs {
+			pending := output.pending
+			pending.Add(1)
+			var resolveOnce sync.Once
 			select {
 			case output.tsChan <- message.NewTransactionFunc(ts.Payload.ShallowCopy(), func(ctx context.Context, err error) error {
+				defer resolveOnce.Do(pending.Done)
 				if atomic.AddInt64(&pendingResponses, -1) == 0 || err != nil {
 					atomic.StoreInt64(&pendingResponses, 0)
 					ackErr := ts.Ack(ctx, err)
@@ -275,6 +314,7 @@ func (d *dynamicFanOutOutputBroker) loop() {
 				return nil
 			}):
 			case <-d.shutSig.SoftStopChan():
+				pending.Done()
 				break outputsLoop // This signal will be caught again in the next loop
 			}
 		}
@@ -435,3 +435,128 @@ func TestDynamicFanOutShutDownFromSend(t *testing.T) {
 		t.Error("Timed out waiting for msg rcv")
 	}
 }
+
+func TestDynamicFanOutRemoveWaitsForPending(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*5)
+	defer done()
+
+	mOut := &mock.OutputChanneled{}
+
+	readChan := make(chan message.Transaction)
+	resChan := make(chan error, 1)
+
+	oTM, err := newDynamicFanOutOutputBroker(map[string]output.Streamed{
+		"foo": mOut,
+	}, log.Noop(), nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, oTM.Consume(readChan))
+
+	select {
+	case readChan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte("hello world")}), resChan):
+	case <-tCtx.Done():
+		t.Fatal("timed out")
+	}
+
+	var ts message.Transaction
+	select {
+	case ts = <-mOut.TChan:
+	case <-tCtx.Done():
+		t.Fatal("timed out")
+	}
+
+	removed := make(chan error, 1)
+	go func() {
+		removed <- oTM.SetOutput(tCtx, "foo", nil)
+	}()
+
+	select {
+	case err := <-removed:
+		t.Fatalf("output removed before pending transaction was resolved: %v", err)
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	require.NoError(t, ts.Ack(tCtx, nil))
+	require.NoError(t, <-removed)
+	require.NoError(t, <-resChan)
+
+	oTM.TriggerCloseNow()
+	require.NoError(t, oTM.WaitForClose(tCtx))
+}
+
+func TestDynamicFanOutRemoveDoesNotBlockRouting(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*5)
+	defer done()
+
+	mSlow, mOther := &mock.OutputChanneled{}, &mock.OutputChanneled{}
+
+	readChan := make(chan message.Transaction)
+	resChan := make(chan error, 1)
+
+	oTM, err := newDynamicFanOutOutputBroker(map[string]output.Streamed{
+		"slow":  mSlow,
+		"other": mOther,
+	}, log.Noop(), nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, oTM.Consume(readChan))
+
+	select {
+	case readChan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte("first")}), resChan):
+	case <-tCtx.Done():
+		t.Fatal("timed out")
+	}
+
+	var slowTS message.Transaction
+	for i := 0; i < 2; i++ {
+		select {
+		case slowTS = <-mSlow.TChan:
+		case ts := <-mOther.TChan:
+			require.NoError(t, ts.Ack(tCtx, nil))
+		case <-tCtx.Done():
+			t.Fatal("timed out")
+		}
+	}
+
+	removed := make(chan error, 1)
+	go func() {
+		removed <- oTM.SetOutput(tCtx, "slow", nil)
+	}()
+
+	// Wait until the slow output has been detached.
+	assert.Eventually(t, func() bool {
+		oTM.outputsMut.RLock()
+		defer oTM.outputsMut.RUnlock()
+		_, exists := oTM.outputs["slow"]
+		return !exists
+	}, time.Second, time.Millisecond*5)
+
+	// Whilst the slow output is still shutting down messages continue to be
+	// routed to the remaining outputs.
+	secondResChan := make(chan error, 1)
+	select {
+	case readChan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte("second")}), secondResChan):
+	case <-tCtx.Done():
+		t.Fatal("timed out")
+	}
+
+	select {
+	case ts := <-mOther.TChan:
+		assert.Equal(t, "second", string(ts.Payload.Get(0).AsBytes()))
+		require.NoError(t, ts.Ack(tCtx, nil))
+	case <-tCtx.Done():
+		t.Fatal("timed out")
+	}
+	require.NoError(t, <-secondResChan)
+
+	select {
+	case err := <-removed:
+		t.Fatalf("output removed before pending transaction was resolved: %v", err)
+	default:
+	}
+
+	require.NoError(t, slowTS.Ack(tCtx, nil))
+	require.NoError(t, <-removed)
+	require.NoError(t, <-resChan)
+
+	oTM.TriggerCloseNow()
+	require.NoError(t, oTM.WaitForClose(tCtx))
+}
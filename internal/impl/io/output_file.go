@@ -8,15 +8,30 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
+	"time"
+
+	"github.com/Jeffail/shutdown"
 
 	"github.com/benthosdev/benthos/v4/internal/codec"
 	"github.com/benthosdev/benthos/v4/public/service"
 )
 
 const (
-	fileOutputFieldPath  = "path"
-	fileOutputFieldCodec = "codec"
+	fileOutputFieldPath         = "path"
+	fileOutputFieldCodec        = "codec"
+	fileOutputFieldMaxOpenFiles = "max_open_files"
+	fileOutputFieldIdleTimeout  = "idle_timeout"
+	fileOutputFieldRotation     = "rotation"
+	fileOutputFieldRotMaxSize   = "max_size"
+	fileOutputFieldRotInterval  = "interval"
+	fileOutputFieldRotMaxFiles  = "max_files"
+
+	// The layout of the timestamp appended to the path of rotated files, which
+	// is fixed width so that rotated files sort chronologically.
+	fileRotatedTimeLayout = "20060102T150405.000000000"
 )
 
 func fileOutputSpec() *service.ConfigSpec {
@@ -24,7 +39,19 @@ func fileOutputSpec() *service.ConfigSpec {
 		Stable().
 		Categories("Local").
 		Summary(`Writes messages to files on disk based on a chosen codec.`).
-		Description(`Messages can be written to different files by using [interpolation functions](/docs/configuration/interpolation#bloblang-queries) in the path field. However, only one file is ever open at a given time, and therefore when the path changes the previously open file is closed.`).
+		Description(`Messages can be written to different files by using [interpolation functions](/docs/configuration/interpolation#bloblang-queries) in the path field, and any directories within the path that do not exist are created. By default only one file is ever open at a given time, and therefore when the path changes the previously open file is closed.
+
+### Keeping Files Open
+
+When writing to many files at once, such as when partitioning data by a field of each message, reopening a file for each message can become expensive. Setting the field `+"`max_open_files`"+` to a value greater than one allows multiple files to remain open at the same time, where the least recently written file is closed whenever the limit is reached. Files can also be closed after a period of inactivity by setting the field `+"`idle_timeout`"+`, which prevents file descriptors from being held for files that are no longer written to.
+
+Only codecs that append to files keep them open between writes.
+
+### Rotation
+
+Files can be rotated once they reach a size in bytes with the field `+"`rotation.max_size`"+`, or once a period of time has passed since they were first written to with the field `+"`rotation.interval`"+`, both of which are checked each time a message is written. The age of a file is tracked across it being closed and reopened, and files that have not been written to by this output are aged from their last modification time. Rotating a file renames it by appending the current UTC time to its path, for example `+"`/tmp/data.txt`"+` becomes `+"`/tmp/data.txt.20240102T150405.000000000`"+`, and the next message is written to a new file at the original path. The number of rotated files retained for each path can be limited with the field `+"`rotation.max_files`"+`, where the oldest are deleted first.
+
+Rotation is only supported by codecs that append to files.`).
 		Fields(
 			service.NewInterpolatedStringField(fileOutputFieldPath).
 				Description("The file to write to, if the file does not yet exist it will be created.").
@@ -35,12 +62,46 @@ func fileOutputSpec() *service.ConfigSpec {
 				).
 				Version("3.33.0"),
 			service.NewInternalField(codec.NewWriterDocs(fileOutputFieldCodec)).Version("3.33.0").Default("lines"),
+			service.NewIntField(fileOutputFieldMaxOpenFiles).
+				Description("The maximum number of files to keep open at any given time.").
+				Advanced().
+				Version("4.28.0").
+				Default(1),
+			service.NewDurationField(fileOutputFieldIdleTimeout).
+				Description("An optional period of inactivity after which an open file is closed.").
+				Example("30s").
+				Advanced().
+				Version("4.28.0").
+				Optional(),
+			service.NewObjectField(fileOutputFieldRotation,
+				service.NewIntField(fileOutputFieldRotMaxSize).
+					Description("The size in bytes that a file may reach before it is rotated, a message that would take the file beyond this size is written to a new file instead. Set to zero to disable size based rotation.").
+					Example(10_000_000).
+					Default(0),
+				service.NewDurationField(fileOutputFieldRotInterval).
+					Description("An optional period of time after the first write to a file after which it is rotated.").
+					Example("1h").
+					Optional(),
+				service.NewIntField(fileOutputFieldRotMaxFiles).
+					Description("The maximum number of rotated files to retain for each path, where the oldest are deleted first. Set to zero to retain all rotated files.").
+					Example(10).
+					Default(0),
+			).
+				Description("Optionally rotate files based on their size or age.").
+				Advanced().
+				Version("4.28.0"),
 		)
 }
 
 type fileOutputConfig struct {
-	Path  *service.InterpolatedString
-	Codec string
+	Path         *service.InterpolatedString
+	Codec        string
+	MaxOpenFiles int
+	IdleTimeout  time.Duration
+
+	RotateMaxSize  int64
+	RotateInterval time.Duration
+	RotateMaxFiles int
 }
 
 func fileOutputConfigFromParsed(pConf *service.ParsedConfig) (conf fileOutputConfig, err error) {
@@ -50,6 +111,33 @@ func fileOutputConfigFromParsed(pConf *service.ParsedConfig) (conf fileOutputCon
 	if conf.Codec, err = pConf.FieldString(fileOutputFieldCodec); err != nil {
 		return
 	}
+	if conf.MaxOpenFiles, err = pConf.FieldInt(fileOutputFieldMaxOpenFiles); err != nil {
+		return
+	}
+	if conf.MaxOpenFiles < 1 {
+		err = fmt.Errorf("field %v must be at least 1", fileOutputFieldMaxOpenFiles)
+		return
+	}
+	if pConf.Contains(fileOutputFieldIdleTimeout) {
+		if conf.IdleTimeout, err = pConf.FieldDuration(fileOutputFieldIdleTimeout); err != nil {
+			return
+		}
+	}
+
+	rConf := pConf.Namespace(fileOutputFieldRotation)
+	var maxSize int
+	if maxSize, err = rConf.FieldInt(fileOutputFieldRotMaxSize); err != nil {
+		return
+	}
+	conf.RotateMaxSize = int64(maxSize)
+	if rConf.Contains(fileOutputFieldRotInterval) {
+		if conf.RotateInterval, err = rConf.FieldDuration(fileOutputFieldRotInterval); err != nil {
+			return
+		}
+	}
+	if conf.RotateMaxFiles, err = rConf.FieldInt(fileOutputFieldRotMaxFiles); err != nil {
+		return
+	}
 	return
 }
 
@@ -62,7 +150,7 @@ func init() {
 			}
 
 			mif = 1
-			out, err = newFileWriter(conf.Path, conf.Codec, res)
+			out, err = newFileWriter(conf, res)
 			return
 		})
 	if err != nil {
@@ -72,77 +160,138 @@ func init() {
 
 //------------------------------------------------------------------------------
 
+type fileHandle struct {
+	wtr      io.WriteCloser
+	lastUsed time.Time
+	created  time.Time
+	size     int64
+}
+
 type fileWriter struct {
 	log *service.Logger
 	nm  *service.Resources
 
-	path       *service.InterpolatedString
-	suffixFn   codec.SuffixFn
-	appendMode bool
+	path         *service.InterpolatedString
+	suffixFn     codec.SuffixFn
+	appendMode   bool
+	maxOpenFiles int
+	idleTimeout  time.Duration
+
+	rotateMaxSize  int64
+	rotateInterval time.Duration
+	rotateMaxFiles int
+
+	handlesMut sync.Mutex
+	handles    map[string]*fileHandle
 
-	handleMut  sync.Mutex
-	handlePath string
-	handle     io.WriteCloser
+	// The time of the first write to each file that has not yet been rotated,
+	// which outlives the handles of files that are closed and later reopened.
+	created map[string]time.Time
+	now     func() time.Time
+
+	closeIdleOnce sync.Once
+	shutSig       *shutdown.Signaller
 }
 
-func newFileWriter(path *service.InterpolatedString, codecStr string, mgr *service.Resources) (*fileWriter, error) {
-	codec, appendMode, err := codec.GetWriter(codecStr)
+func newFileWriter(conf fileOutputConfig, mgr *service.Resources) (*fileWriter, error) {
+	codec, appendMode, err := codec.GetWriter(conf.Codec)
 	if err != nil {
 		return nil, err
 	}
+	if !appendMode && (conf.RotateMaxSize > 0 || conf.RotateInterval > 0) {
+		return nil, fmt.Errorf("codec %v does not append to files and therefore cannot be used with rotation", conf.Codec)
+	}
+	maxOpenFiles := conf.MaxOpenFiles
+	if maxOpenFiles < 1 {
+		maxOpenFiles = 1
+	}
 	return &fileWriter{
-		suffixFn:   codec,
-		appendMode: appendMode,
-		path:       path,
-		log:        mgr.Logger(),
-		nm:         mgr,
+		suffixFn:       codec,
+		appendMode:     appendMode,
+		path:           conf.Path,
+		maxOpenFiles:   maxOpenFiles,
+		idleTimeout:    conf.IdleTimeout,
+		rotateMaxSize:  conf.RotateMaxSize,
+		rotateInterval: conf.RotateInterval,
+		rotateMaxFiles: conf.RotateMaxFiles,
+		log:            mgr.Logger(),
+		nm:             mgr,
+		handles:        map[string]*fileHandle{},
+		created:        map[string]time.Time{},
+		now:            time.Now,
+		shutSig:        shutdown.NewSignaller(),
 	}, nil
 }
 
 //------------------------------------------------------------------------------
 
 func (w *fileWriter) Connect(ctx context.Context) error {
+	if w.idleTimeout > 0 && w.appendMode {
+		w.closeIdleOnce.Do(func() {
+			go w.closeIdleLoop()
+		})
+	}
 	return nil
 }
 
-func (w *fileWriter) writeTo(wtr io.Writer, p *service.Message) error {
-	mBytes, err := p.AsBytes()
-	if err != nil {
-		return err
-	}
+func (w *fileWriter) closeIdleLoop() {
+	ticker := time.NewTicker(w.idleTimeout / 2)
+	defer ticker.Stop()
 
-	suffix, addSuffix := w.suffixFn(mBytes)
+	for {
+		select {
+		case <-ticker.C:
+		case <-w.shutSig.SoftStopChan():
+			return
+		}
 
-	if _, err := wtr.Write(mBytes); err != nil {
-		return err
-	}
-	if addSuffix {
-		if _, err := wtr.Write(suffix); err != nil {
-			return err
+		w.handlesMut.Lock()
+		for path, h := range w.handles {
+			if w.now().Sub(h.lastUsed) < w.idleTimeout {
+				continue
+			}
+			if err := h.wtr.Close(); err != nil {
+				w.log.Errorf("Failed to close idle file '%v': %v", path, err)
+			}
+			delete(w.handles, path)
 		}
+		w.handlesMut.Unlock()
 	}
-	return nil
 }
 
-func (w *fileWriter) Write(ctx context.Context, msg *service.Message) error {
-	path, err := w.path.TryString(msg)
+func (w *fileWriter) writeTo(h *fileHandle, mBytes, suffix []byte) error {
+	n, err := h.wtr.Write(mBytes)
+	h.size += int64(n)
 	if err != nil {
-		return fmt.Errorf("path interpolation error: %w", err)
+		return err
 	}
-	path = filepath.Clean(path)
-
-	w.handleMut.Lock()
-	defer w.handleMut.Unlock()
-
-	if w.handle != nil && path == w.handlePath {
-		return w.writeTo(w.handle, msg)
+	if len(suffix) > 0 {
+		n, err = h.wtr.Write(suffix)
+		h.size += int64(n)
 	}
-	if w.handle != nil {
-		if err := w.handle.Close(); err != nil {
-			return err
+	return err
+}
+
+// evictHandle closes the least recently used open file, must be called with
+// the handles mutex held.
+func (w *fileWriter) evictHandle() error {
+	var oldestPath string
+	var oldest *fileHandle
+	for path, h := range w.handles {
+		if oldest == nil || h.lastUsed.Before(oldest.lastUsed) {
+			oldestPath, oldest = path, h
 		}
 	}
+	if oldest == nil {
+		return nil
+	}
+	delete(w.handles, oldestPath)
+	return oldest.wtr.Close()
+}
 
+// openHandle opens the file at a given path, creating it and any missing
+// directories if necessary.
+func (w *fileWriter) openHandle(path string) (*fileHandle, error) {
 	flag := os.O_CREATE | os.O_RDWR
 	if w.appendMode {
 		flag |= os.O_APPEND
@@ -151,42 +300,185 @@ func (w *fileWriter) Write(ctx context.Context, msg *service.Message) error {
 	}
 
 	if err := w.nm.FS().MkdirAll(filepath.Dir(path), fs.FileMode(0o777)); err != nil {
-		return err
+		return nil, err
 	}
 
 	file, err := w.nm.FS().OpenFile(path, flag, fs.FileMode(0o666))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	handle, ok := file.(io.WriteCloser)
+	wtr, ok := file.(io.WriteCloser)
 	if !ok {
 		_ = file.Close()
-		return errors.New("failed to open file for writing")
+		return nil, errors.New("failed to open file for writing")
 	}
 
-	w.handlePath = path
-	if err := w.writeTo(handle, msg); err != nil {
-		_ = handle.Close()
+	h := &fileHandle{wtr: wtr}
+	if w.appendMode {
+		if info, err := file.Stat(); err == nil {
+			h.size = info.Size()
+			h.created = info.ModTime()
+		}
+		if created, exists := w.created[path]; exists && h.size > 0 {
+			h.created = created
+		}
+	}
+	return h, nil
+}
+
+// shouldRotate returns true if writing n bytes to a file should first trigger
+// a rotation. Empty files are never rotated.
+func (w *fileWriter) shouldRotate(h *fileHandle, n int64) bool {
+	if h.size == 0 {
+		return false
+	}
+	if w.rotateMaxSize > 0 && h.size+n > w.rotateMaxSize {
+		return true
+	}
+	return w.rotateInterval > 0 && w.now().Sub(h.created) >= w.rotateInterval
+}
+
+// trackCreated records the time of the first write to a file so that its age
+// survives the file being closed. Closed files that have already reached the
+// rotation interval are rotated rather than tracked indefinitely. Must be
+// called with the handles mutex held.
+func (w *fileWriter) trackCreated(path string, created time.Time) {
+	if w.rotateInterval <= 0 {
+		return
+	}
+	if _, exists := w.created[path]; !exists {
+		for p, t := range w.created {
+			if _, open := w.handles[p]; open || w.now().Sub(t) < w.rotateInterval {
+				continue
+			}
+			delete(w.created, p)
+			if err := w.rotate(p); err != nil {
+				w.log.Errorf("Failed to rotate closed file '%v': %v", p, err)
+			}
+		}
+	}
+	w.created[path] = created
+}
+
+// rotate renames the file at a given path, which must be closed, and removes
+// the oldest rotated files of that path beyond the retention limit.
+func (w *fileWriter) rotate(path string) error {
+	rotatedPath := path + "." + w.now().UTC().Format(fileRotatedTimeLayout)
+	if err := w.nm.FS().Rename(path, rotatedPath); err != nil {
+		return fmt.Errorf("failed to rotate file: %w", err)
+	}
+	if w.rotateMaxFiles <= 0 {
+		return nil
+	}
+
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	entries, err := fs.ReadDir(w.nm.FS(), dir)
+	if err != nil {
+		return fmt.Errorf("failed to list rotated files: %w", err)
+	}
+
+	var rotated []string
+	for _, e := range entries {
+		suffix, ok := strings.CutPrefix(e.Name(), base+".")
+		if !ok || e.IsDir() {
+			continue
+		}
+		if _, err := time.Parse(fileRotatedTimeLayout, suffix); err != nil {
+			continue
+		}
+		rotated = append(rotated, e.Name())
+	}
+	sort.Strings(rotated)
+
+	for len(rotated) > w.rotateMaxFiles {
+		if err := w.nm.FS().Remove(filepath.Join(dir, rotated[0])); err != nil {
+			w.log.Errorf("Failed to remove rotated file '%v': %v", rotated[0], err)
+		}
+		rotated = rotated[1:]
+	}
+	return nil
+}
+
+func (w *fileWriter) Write(ctx context.Context, msg *service.Message) error {
+	path, err := w.path.TryString(msg)
+	if err != nil {
+		return fmt.Errorf("path interpolation error: %w", err)
+	}
+	path = filepath.Clean(path)
+
+	mBytes, err := msg.AsBytes()
+	if err != nil {
+		return err
+	}
+	suffix, addSuffix := w.suffixFn(mBytes)
+	if !addSuffix {
+		suffix = nil
+	}
+
+	w.handlesMut.Lock()
+	defer w.handlesMut.Unlock()
+
+	h, exists := w.handles[path]
+	if !exists {
+		if len(w.handles) >= w.maxOpenFiles {
+			if err := w.evictHandle(); err != nil {
+				return err
+			}
+		}
+		if h, err = w.openHandle(path); err != nil {
+			return err
+		}
+	}
+
+	if w.shouldRotate(h, int64(len(mBytes)+len(suffix))) {
+		delete(w.handles, path)
+		if err := h.wtr.Close(); err != nil {
+			return err
+		}
+		delete(w.created, path)
+		if err := w.rotate(path); err != nil {
+			return err
+		}
+		if h, err = w.openHandle(path); err != nil {
+			return err
+		}
+	}
+
+	if h.size == 0 {
+		h.created = w.now()
+	}
+	if err := w.writeTo(h, mBytes, suffix); err != nil {
+		delete(w.handles, path)
+		_ = h.wtr.Close()
 		return err
 	}
 
 	if w.appendMode {
-		w.handle = handle
+		h.lastUsed = w.now()
+		w.handles[path] = h
+		w.trackCreated(path, h.created)
 	} else {
-		_ = handle.Close()
+		_ = h.wtr.Close()
 	}
 	return nil
 }
 
 func (w *fileWriter) Close(ctx context.Context) error {
-	w.handleMut.Lock()
-	defer w.handleMut.Unlock()
+	w.shutSig.TriggerSoftStop()
+
+	w.handlesMut.Lock()
+	defer w.handlesMut.Unlock()
 
 	var err error
-	if w.handle != nil {
-		err = w.handle.Close()
-		w.handle = nil
+	for path, h := range w.handles {
+		if cErr := h.wtr.Close(); cErr != nil && err == nil {
+			err = cErr
+		}
+		delete(w.handles, path)
 	}
 	return err
 }
@@ -0,0 +1,316 @@
+package io
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func fileWriterFromConf(t testing.TB, confStr string, bits ...any) *fileWriter {
+	t.Helper()
+
+	pConf, err := fileOutputSpec().ParseYAML(fmt.Sprintf(confStr, bits...), nil)
+	require.NoError(t, err)
+
+	conf, err := fileOutputConfigFromParsed(pConf)
+	require.NoError(t, err)
+
+	w, err := newFileWriter(conf, service.MockResources())
+	require.NoError(t, err)
+
+	require.NoError(t, w.Connect(context.Background()))
+	t.Cleanup(func() {
+		require.NoError(t, w.Close(context.Background()))
+	})
+	return w
+}
+
+func TestFileOutputMaxOpenFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	w := fileWriterFromConf(t, `
+path: '%v/${! json("dir") }/${! json("id") }.txt'
+max_open_files: 2
+`, dir)
+
+	for _, doc := range []string{
+		`{"dir":"a","id":"foo"}`,
+		`{"dir":"b","id":"bar"}`,
+		`{"dir":"a","id":"foo"}`,
+		`{"dir":"c","id":"baz"}`,
+		`{"dir":"b","id":"bar"}`,
+	} {
+		require.NoError(t, w.Write(context.Background(), service.NewMessage([]byte(doc))))
+		assert.LessOrEqual(t, len(w.handles), 2)
+	}
+
+	require.NoError(t, w.Close(context.Background()))
+
+	for path, exp := range map[string]string{
+		"a/foo.txt": "{\"dir\":\"a\",\"id\":\"foo\"}\n{\"dir\":\"a\",\"id\":\"foo\"}\n",
+		"b/bar.txt": "{\"dir\":\"b\",\"id\":\"bar\"}\n{\"dir\":\"b\",\"id\":\"bar\"}\n",
+		"c/baz.txt": "{\"dir\":\"c\",\"id\":\"baz\"}\n",
+	} {
+		b, err := os.ReadFile(filepath.Join(dir, path))
+		require.NoError(t, err)
+		assert.Equal(t, exp, string(b), path)
+	}
+}
+
+func TestFileOutputIdleTimeout(t *testing.T) {
+	dir := t.TempDir()
+
+	w := fileWriterFromConf(t, `
+path: '%v/${! content() }.txt'
+max_open_files: 10
+idle_timeout: 10ms
+`, dir)
+
+	require.NoError(t, w.Write(context.Background(), service.NewMessage([]byte("foo"))))
+
+	assert.Eventually(t, func() bool {
+		w.handlesMut.Lock()
+		defer w.handlesMut.Unlock()
+		return len(w.handles) == 0
+	}, time.Second, time.Millisecond*5)
+
+	require.NoError(t, w.Write(context.Background(), service.NewMessage([]byte("foo"))))
+
+	b, err := os.ReadFile(filepath.Join(dir, "foo.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "foo\nfoo\n", string(b))
+}
+
+// fileOutputContents returns the name and contents of each file within a
+// directory, sorted by name, and therefore rotated files are listed
+// chronologically after the file they were rotated from.
+func fileOutputContents(t testing.TB, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	var contents []string
+	for _, e := range entries {
+		b, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		require.NoError(t, err)
+		contents = append(contents, e.Name()+": "+string(b))
+	}
+	return contents
+}
+
+func TestFileOutputRotateSize(t *testing.T) {
+	dir := t.TempDir()
+
+	w := fileWriterFromConf(t, `
+path: '%v/data.txt'
+rotation:
+  max_size: 10
+  max_files: 2
+`, dir)
+
+	// Each file fits two messages.
+	for i := 0; i < 7; i++ {
+		require.NoError(t, w.Write(context.Background(), service.NewMessage([]byte(fmt.Sprintf("foo%v", i)))))
+	}
+	require.NoError(t, w.Close(context.Background()))
+
+	contents := fileOutputContents(t, dir)
+	require.Len(t, contents, 3)
+
+	// The oldest rotated file containing foo0 and foo1 is removed.
+	assert.Equal(t, "data.txt: foo6\n", contents[0])
+	assert.Regexp(t, `^data\.txt\.\d{8}T\d{6}\.\d{9}: foo2\nfoo3\n$`, contents[1])
+	assert.Regexp(t, `^data\.txt\.\d{8}T\d{6}\.\d{9}: foo4\nfoo5\n$`, contents[2])
+}
+
+func TestFileOutputRotateInterval(t *testing.T) {
+	dir := t.TempDir()
+
+	w := fileWriterFromConf(t, `
+path: '%v/data.txt'
+rotation:
+  interval: 50ms
+`, dir)
+
+	require.NoError(t, w.Write(context.Background(), service.NewMessage([]byte("foo"))))
+	require.NoError(t, w.Write(context.Background(), service.NewMessage([]byte("bar"))))
+
+	<-time.After(time.Millisecond * 100)
+	require.NoError(t, w.Write(context.Background(), service.NewMessage([]byte("baz"))))
+	require.NoError(t, w.Close(context.Background()))
+
+	contents := fileOutputContents(t, dir)
+	require.Len(t, contents, 2)
+	assert.Equal(t, "data.txt: baz\n", contents[0])
+	assert.Regexp(t, `^data\.txt\.\d{8}T\d{6}\.\d{9}: foo\nbar\n$`, contents[1])
+}
+
+func TestFileOutputRotateIntervalAcrossReopens(t *testing.T) {
+	dir := t.TempDir()
+
+	w := fileWriterFromConf(t, `
+path: '%v/${! content() }.txt'
+rotation:
+  interval: 1h
+`, dir)
+
+	now := time.Now()
+	w.now = func() time.Time { return now }
+
+	write := func(content string) {
+		t.Helper()
+		require.NoError(t, w.Write(context.Background(), service.NewMessage([]byte(content))))
+	}
+
+	// With one open file at a time each write to a different path evicts the
+	// previously open file.
+	write("foo")
+	write("bar")
+
+	now = now.Add(time.Minute * 30)
+	write("foo")
+	write("bar")
+
+	now = now.Add(time.Minute * 31)
+	write("foo")
+	require.NoError(t, w.Close(context.Background()))
+
+	// The closed bar.txt file has also reached the interval and is therefore
+	// rotated when foo.txt is reopened.
+	contents := fileOutputContents(t, dir)
+	require.Len(t, contents, 3)
+	assert.Regexp(t, `^bar\.txt\.\d{8}T\d{6}\.\d{9}: bar\nbar\n$`, contents[0])
+	assert.Equal(t, "foo.txt: foo\n", contents[1])
+	assert.Regexp(t, `^foo\.txt\.\d{8}T\d{6}\.\d{9}: foo\nfoo\n$`, contents[2])
+}
+
+func TestFileOutputRotateIntervalIdleClosed(t *testing.T) {
+	dir := t.TempDir()
+
+	w := fileWriterFromConf(t, `
+path: '%v/${! content() }.txt'
+max_open_files: 10
+idle_timeout: 10ms
+rotation:
+  interval: 1h
+`, dir)
+
+	// The clock follows real time so that idle files are closed, with an
+	// offset in order to simulate the passing of the rotation interval.
+	var nowMut sync.Mutex
+	var offset time.Duration
+	w.now = func() time.Time {
+		nowMut.Lock()
+		defer nowMut.Unlock()
+		return time.Now().Add(offset)
+	}
+	advance := func(d time.Duration) {
+		nowMut.Lock()
+		offset += d
+		nowMut.Unlock()
+	}
+
+	waitIdleClosed := func() {
+		t.Helper()
+		assert.Eventually(t, func() bool {
+			w.handlesMut.Lock()
+			defer w.handlesMut.Unlock()
+			return len(w.handles) == 0
+		}, time.Second, time.Millisecond*5)
+	}
+
+	require.NoError(t, w.Write(context.Background(), service.NewMessage([]byte("foo"))))
+	waitIdleClosed()
+
+	advance(time.Minute * 30)
+	require.NoError(t, w.Write(context.Background(), service.NewMessage([]byte("foo"))))
+	waitIdleClosed()
+
+	advance(time.Minute * 31)
+	require.NoError(t, w.Write(context.Background(), service.NewMessage([]byte("foo"))))
+	require.NoError(t, w.Close(context.Background()))
+
+	contents := fileOutputContents(t, dir)
+	require.Len(t, contents, 2)
+	assert.Equal(t, "foo.txt: foo\n", contents[0])
+	assert.Regexp(t, `^foo\.txt\.\d{8}T\d{6}\.\d{9}: foo\nfoo\n$`, contents[1])
+}
+
+func TestFileOutputRotateIntervalClosedFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	w := fileWriterFromConf(t, `
+path: '%v/${! content() }.txt'
+rotation:
+  interval: 1h
+`, dir)
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	w.now = func() time.Time { return now }
+
+	require.NoError(t, w.Write(context.Background(), service.NewMessage([]byte("foo"))))
+
+	now = now.Add(time.Minute * 30)
+	require.NoError(t, w.Write(context.Background(), service.NewMessage([]byte("bar"))))
+
+	// Writing to a new path after the interval rotates closed files that are
+	// due rather than tracking them forever.
+	now = now.Add(time.Minute * 31)
+	require.NoError(t, w.Write(context.Background(), service.NewMessage([]byte("baz"))))
+	require.NoError(t, w.Close(context.Background()))
+
+	contents := fileOutputContents(t, dir)
+	require.Len(t, contents, 3)
+	assert.Equal(t, "bar.txt: bar\n", contents[0])
+	assert.Equal(t, "baz.txt: baz\n", contents[1])
+	assert.Equal(t, "foo.txt.20240102T040505.000000006: foo\n", contents[2])
+
+	w.handlesMut.Lock()
+	defer w.handlesMut.Unlock()
+	assert.Len(t, w.created, 2)
+}
+
+func TestFileOutputRotateExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "data.txt"), []byte("existing\n"), 0o644))
+
+	w := fileWriterFromConf(t, `
+path: '%v/data.txt'
+rotation:
+  max_size: 10
+`, dir)
+
+	require.NoError(t, w.Write(context.Background(), service.NewMessage([]byte("foo"))))
+	require.NoError(t, w.Close(context.Background()))
+
+	contents := fileOutputContents(t, dir)
+	require.Len(t, contents, 2)
+	assert.Equal(t, "data.txt: foo\n", contents[0])
+	assert.Regexp(t, `^data\.txt\.\d{8}T\d{6}\.\d{9}: existing\n$`, contents[1])
+}
+
+func TestFileOutputRotateAllBytes(t *testing.T) {
+	pConf, err := fileOutputSpec().ParseYAML(`
+path: /tmp/data.txt
+codec: all-bytes
+rotation:
+  max_size: 10
+`, nil)
+	require.NoError(t, err)
+
+	conf, err := fileOutputConfigFromParsed(pConf)
+	require.NoError(t, err)
+
+	_, err = newFileWriter(conf, service.MockResources())
+	require.Error(t, err)
+}
@@ -35,6 +35,9 @@ const (
 	hsoFieldPath               = "path"
 	hsoFieldStreamPath         = "stream_path"
 	hsoFieldWSPath             = "ws_path"
+	hsoFieldWSBroadcast        = "ws_broadcast"
+	hsoFieldWSClientBuffer     = "ws_client_buffer"
+	hsoFieldWSReplay           = "ws_replay"
 	hsoFieldAllowedVerbs       = "allowed_verbs"
 	hsoFieldTimeout            = "timeout"
 	hsoFieldCertFile           = "cert_file"
@@ -49,6 +52,9 @@ type hsoConfig struct {
 	Path         string
 	StreamPath   string
 	WSPath       string
+	WSBroadcast  bool
+	WSClientBuf  int
+	WSReplay     int
 	AllowedVerbs map[string]struct{}
 	Timeout      time.Duration
 	CertFile     string
@@ -69,6 +75,15 @@ func hsoConfigFromParsed(pConf *service.ParsedConfig) (conf hsoConfig, err error
 	if conf.WSPath, err = pConf.FieldString(hsoFieldWSPath); err != nil {
 		return
 	}
+	if conf.WSBroadcast, err = pConf.FieldBool(hsoFieldWSBroadcast); err != nil {
+		return
+	}
+	if conf.WSClientBuf, err = pConf.FieldInt(hsoFieldWSClientBuffer); err != nil {
+		return
+	}
+	if conf.WSReplay, err = pConf.FieldInt(hsoFieldWSReplay); err != nil {
+		return
+	}
 	{
 		var verbsList []string
 		if verbsList, err = pConf.FieldStringList(hsoFieldAllowedVerbs); err != nil {
@@ -114,6 +129,14 @@ When messages are batched the `+"`path`"+` endpoint encodes the batch according
 
 Please note, messages are considered delivered as soon as the data is written to the client. There is no concept of at least once delivery on this output.
 
+### Broadcasting Over Websockets
+
+By default each message is delivered to only one of the connected clients. Setting the field `+"`ws_broadcast`"+` to `+"`true`"+` instead sends every message to all clients connected to the `+"`ws_path`"+` endpoint, which is useful for feeding live dashboards. In this mode messages are considered delivered as soon as they are queued for each client, and are dropped when no clients are connected. The `+"`path` and `stream_path`"+` endpoints still compete for messages when broadcasting, and should therefore usually be disabled by setting them to empty strings.
+
+Each client has a queue of messages bounded by the field `+"`ws_client_buffer`"+`, and a client that falls so far behind that its queue is full is disconnected rather than stalling the pipeline. The field `+"`ws_replay`"+` can be used to send newly connected clients the most recent messages that were broadcast.
+
+The number of connected broadcast clients is tracked by the gauge `+"`http_server_ws_connections`"+`.
+
 `+api.EndpointCaveats()+`
 `).
 		Fields(
@@ -129,6 +152,20 @@ Please note, messages are considered delivered as soon as the data is written to
 			service.NewStringField(hsoFieldWSPath).
 				Description("The path from which websocket connections can be established.").
 				Default("/get/ws"),
+			service.NewBoolField(hsoFieldWSBroadcast).
+				Description("Whether to send every message to all clients connected to the `ws_path` endpoint, rather than each message being consumed by only one of them.").
+				Version("4.28.0").
+				Default(false),
+			service.NewIntField(hsoFieldWSClientBuffer).
+				Description("The maximum number of messages to queue for each client when `ws_broadcast` is enabled, clients that exceed this are disconnected.").
+				Version("4.28.0").
+				Advanced().
+				Default(64),
+			service.NewIntField(hsoFieldWSReplay).
+				Description("The number of the most recently broadcast messages to send to newly connected clients when `ws_broadcast` is enabled.").
+				Version("4.28.0").
+				Advanced().
+				Default(0),
 			service.NewStringListField(hsoFieldAllowedVerbs).
 				Description("An array of verbs that are allowed for the `path` and `stream_path` HTTP endpoint.").
 				Default([]any{"GET"}),
@@ -193,6 +230,8 @@ type httpServerOutput struct {
 	mWSBatchSent metrics.StatCounter
 	mWSError     metrics.StatCounter
 
+	broadcaster *wsBroadcaster
+
 	mStreamSent      metrics.StatCounter
 	mStreamBatchSent metrics.StatCounter
 	mStreamError     metrics.StatCounter
@@ -238,6 +277,10 @@ func newHTTPServerOutput(conf hsoConfig, mgr bundle.NewManagement) (output.Strea
 		mStreamError:     mError,
 	}
 
+	if conf.WSBroadcast {
+		h.broadcaster = newWSBroadcaster(conf.WSClientBuf, conf.WSReplay, stats.GetGauge("http_server_ws_connections"))
+	}
+
 	if gMux != nil {
 		if h.conf.Path != "" {
 			api.GetMuxRoute(gMux, h.conf.Path).HandlerFunc(h.getHandler)
@@ -404,6 +447,11 @@ func (h *httpServerOutput) wsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	defer ws.Close()
 
+	if h.broadcaster != nil {
+		h.wsBroadcastClient(r.Context(), ws)
+		return
+	}
+
 	ctx, done := h.shutSig.SoftStopCtx(r.Context())
 	defer done()
 
@@ -438,12 +486,78 @@ func (h *httpServerOutput) wsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *httpServerOutput) wsBroadcastClient(ctx context.Context, ws *websocket.Conn) {
+	c := h.broadcaster.register()
+	defer h.broadcaster.unregister(c)
+
+	// Messages from the client are discarded, but reading is required in order
+	// for close and ping frames to be processed, and also detects clients that
+	// disconnect between broadcasts.
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		for {
+			if _, _, err := ws.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-readDone:
+			return
+		case msg := <-c.msgs:
+			if err := ws.WriteMessage(websocket.BinaryMessage, msg); err != nil {
+				h.mWSError.Incr(1)
+				return
+			}
+		case <-c.evicted:
+			h.log.Warn("Disconnecting websocket client that exceeded its message buffer\n")
+			return
+		case <-ctx.Done():
+			return
+		case <-h.shutSig.SoftStopChan():
+			return
+		}
+	}
+}
+
+func (h *httpServerOutput) broadcastLoop() {
+	ctx, done := h.shutSig.SoftStopCtx(context.Background())
+	defer done()
+
+	for {
+		var ts message.Transaction
+		var open bool
+
+		select {
+		case ts, open = <-h.transactions:
+			if !open {
+				go h.TriggerCloseNow()
+				return
+			}
+		case <-h.shutSig.SoftStopChan():
+			return
+		}
+
+		h.broadcaster.broadcast(message.GetAllBytes(ts.Payload))
+		h.mWSBatchSent.Incr(1)
+		h.mWSSent.Incr(int64(batch.MessageCollapsedCount(ts.Payload)))
+		_ = ts.Ack(ctx, nil)
+	}
+}
+
 func (h *httpServerOutput) Consume(ts <-chan message.Transaction) error {
 	if h.transactions != nil {
 		return component.ErrAlreadyStarted
 	}
 	h.transactions = ts
 
+	if h.broadcaster != nil {
+		go h.broadcastLoop()
+	}
+
 	if h.server != nil {
 		go func() {
 			if h.conf.KeyFile != "" || h.conf.CertFile != "" {
@@ -496,3 +610,87 @@ func (h *httpServerOutput) WaitForClose(ctx context.Context) error {
 	}
 	return nil
 }
+
+//------------------------------------------------------------------------------
+
+type wsBroadcastClient struct {
+	msgs    chan []byte
+	evicted chan struct{}
+}
+
+// wsBroadcaster distributes messages to all registered websocket clients,
+// evicting clients whose queue of pending messages is full.
+type wsBroadcaster struct {
+	bufferSize  int
+	replayLimit int
+
+	mut     sync.Mutex
+	clients map[*wsBroadcastClient]struct{}
+	replay  [][]byte
+
+	mConnections metrics.StatGauge
+}
+
+func newWSBroadcaster(bufferSize, replayLimit int, mConnections metrics.StatGauge) *wsBroadcaster {
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+	return &wsBroadcaster{
+		bufferSize:   bufferSize,
+		replayLimit:  replayLimit,
+		clients:      map[*wsBroadcastClient]struct{}{},
+		mConnections: mConnections,
+	}
+}
+
+func (b *wsBroadcaster) register() *wsBroadcastClient {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	c := &wsBroadcastClient{
+		msgs:    make(chan []byte, b.bufferSize+len(b.replay)),
+		evicted: make(chan struct{}),
+	}
+	for _, msg := range b.replay {
+		c.msgs <- msg
+	}
+	b.clients[c] = struct{}{}
+	b.mConnections.Incr(1)
+	return c
+}
+
+func (b *wsBroadcaster) unregister(c *wsBroadcastClient) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	if _, exists := b.clients[c]; exists {
+		delete(b.clients, c)
+		b.mConnections.Decr(1)
+	}
+}
+
+func (b *wsBroadcaster) broadcast(msgs [][]byte) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	if b.replayLimit > 0 {
+		b.replay = append(b.replay, msgs...)
+		if excess := len(b.replay) - b.replayLimit; excess > 0 {
+			b.replay = append([][]byte(nil), b.replay[excess:]...)
+		}
+	}
+
+clientsLoop:
+	for c := range b.clients {
+		for _, msg := range msgs {
+			select {
+			case c.msgs <- msg:
+			default:
+				delete(b.clients, c)
+				b.mConnections.Decr(1)
+				close(c.evicted)
+				continue clientsLoop
+			}
+		}
+	}
+}
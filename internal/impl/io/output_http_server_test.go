@@ -7,8 +7,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/output"
 	"github.com/benthosdev/benthos/v4/internal/component/testutil"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
@@ -159,3 +162,144 @@ http_server:
 	h.TriggerCloseNow()
 	require.NoError(t, h.WaitForClose(ctx))
 }
+
+func TestHTTPServerOutputWSBroadcast(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	port := getFreePort(t)
+	conf := parseYAMLOutputConf(t, `
+http_server:
+  address: localhost:%v
+  path: ""
+  stream_path: ""
+  ws_path: /ws
+  ws_broadcast: true
+  ws_replay: 1
+`, port)
+
+	mgr := mock.NewManager()
+	stats := metrics.NewLocal()
+	mgr.M = stats
+
+	h, err := mgr.NewOutput(conf)
+	require.NoError(t, err)
+
+	msgChan := make(chan message.Transaction)
+	require.NoError(t, h.Consume(msgChan))
+
+	<-time.After(time.Millisecond * 100)
+
+	wsURL := fmt.Sprintf("ws://localhost:%v/ws", port)
+
+	var clients []*websocket.Conn
+	for i := 0; i < 2; i++ {
+		c, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = c.Close()
+		})
+		clients = append(clients, c)
+	}
+
+	assert.Eventually(t, func() bool {
+		return stats.GetCounters()["http_server_ws_connections"] == 2
+	}, time.Second*5, time.Millisecond*10)
+
+	for _, content := range []string{"foo", "bar"} {
+		resChan := make(chan error)
+		select {
+		case msgChan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte(content)}), resChan):
+		case <-ctx.Done():
+			t.Fatal("timed out")
+		}
+		require.NoError(t, <-resChan)
+
+		for _, c := range clients {
+			_, data, err := c.ReadMessage()
+			require.NoError(t, err)
+			assert.Equal(t, content, string(data))
+		}
+	}
+
+	// New clients receive the most recent message
+	late, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	defer late.Close()
+
+	_, data, err := late.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, "bar", string(data))
+
+	h.TriggerCloseNow()
+	require.NoError(t, h.WaitForClose(ctx))
+}
+
+func TestHTTPServerOutputWSBroadcastClientClose(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	port := getFreePort(t)
+	conf := parseYAMLOutputConf(t, `
+http_server:
+  address: localhost:%v
+  path: ""
+  stream_path: ""
+  ws_path: /ws
+  ws_broadcast: true
+`, port)
+
+	mgr := mock.NewManager()
+	stats := metrics.NewLocal()
+	mgr.M = stats
+
+	h, err := mgr.NewOutput(conf)
+	require.NoError(t, err)
+
+	msgChan := make(chan message.Transaction)
+	require.NoError(t, h.Consume(msgChan))
+
+	<-time.After(time.Millisecond * 100)
+
+	c, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("ws://localhost:%v/ws", port), nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	assert.Eventually(t, func() bool {
+		return stats.GetCounters()["http_server_ws_connections"] == 1
+	}, time.Second*5, time.Millisecond*10)
+
+	pongChan := make(chan string, 1)
+	c.SetPongHandler(func(appData string) error {
+		pongChan <- appData
+		return nil
+	})
+	require.NoError(t, c.WriteControl(websocket.PingMessage, []byte("hello"), time.Now().Add(time.Second)))
+
+	// The default close handler attempts to reply with a close frame, which
+	// fails as we've already sent one.
+	c.SetCloseHandler(func(code int, text string) error {
+		return nil
+	})
+	require.NoError(t, c.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")))
+
+	// Reading processes the pong and then the close frame echoed by the server.
+	require.NoError(t, c.SetReadDeadline(time.Now().Add(time.Second*5)))
+	_, _, err = c.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), err)
+
+	select {
+	case data := <-pongChan:
+		assert.Equal(t, "hello", data)
+	case <-ctx.Done():
+		t.Fatal("timed out")
+	}
+
+	// The client is removed without waiting for a broadcast to fail.
+	assert.Eventually(t, func() bool {
+		return stats.GetCounters()["http_server_ws_connections"] == 0
+	}, time.Second*5, time.Millisecond*10)
+
+	h.TriggerCloseNow()
+	require.NoError(t, h.WaitForClose(ctx))
+}
@@ -1,27 +1,63 @@
 package io
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 
 	"github.com/benthosdev/benthos/v4/internal/codec"
+	"github.com/benthosdev/benthos/v4/internal/docs"
 	"github.com/benthosdev/benthos/v4/public/service"
 )
 
+const (
+	stdoutCodecJSONArray      = "json_array"
+	stdoutCodecLinesMultipart = "lines/multipart"
+)
+
+func stdoutCodecDocs() docs.FieldSpec {
+	return codec.NewWriterDocs("codec").HasAnnotatedOptions(
+		stdoutCodecJSONArray, "Write each batch of messages as a single JSON array followed by a line break, where each message of the batch is an element of the array. Messages that are not valid JSON result in an error.",
+		stdoutCodecLinesMultipart, "Write each message of a batch followed by a line break, and terminate each batch with an empty line, which can be consumed by the `stdin` input with the codec `lines/multipart`. Messages that are empty or contain line breaks result in an error as they cannot be read back as a single message.",
+	).HasDefault("lines")
+}
+
 func init() {
-	err := service.RegisterOutput(
+	err := service.RegisterBatchOutput(
 		"stdout", service.NewConfigSpec().
 			Stable().
 			Categories("Local").
 			Summary(`Prints messages to stdout as a continuous stream of data.`).
-			Fields(service.NewInternalField(codec.NewWriterDocs("codec").AtVersion("3.46.0").HasDefault("lines"))),
-		func(conf *service.ParsedConfig, mgr *service.Resources) (service.Output, int, error) {
-			w, err := newStdoutWriterFromParsed(conf)
+			Fields(service.NewInternalField(stdoutCodecDocs().AtVersion("3.46.0"))),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.BatchOutput, service.BatchPolicy, int, error) {
+			w, err := newStdoutWriterFromParsed(conf, os.Stdout)
 			if err != nil {
-				return nil, 0, err
+				return nil, service.BatchPolicy{}, 0, err
 			}
-			return w, 1, nil
+			return w, service.BatchPolicy{}, 1, nil
+		})
+	if err != nil {
+		panic(err)
+	}
+
+	err = service.RegisterBatchOutput(
+		"stderr", service.NewConfigSpec().
+			Beta().
+			Categories("Local").
+			Version("4.28.0").
+			Summary(`Prints messages to stderr as a continuous stream of data.`).
+			Description(`
+This output is useful for debugging pipelines where stdout is already piped to another process, as messages written to stderr are not interleaved with that data.`).
+			Fields(service.NewInternalField(stdoutCodecDocs())),
+		func(conf *service.ParsedConfig, mgr *service.Resources) (service.BatchOutput, service.BatchPolicy, int, error) {
+			w, err := newStdoutWriterFromParsed(conf, os.Stderr)
+			if err != nil {
+				return nil, service.BatchPolicy{}, 0, err
+			}
+			return w, service.BatchPolicy{}, 1, nil
 		})
 	if err != nil {
 		panic(err)
@@ -29,16 +65,31 @@ func init() {
 }
 
 type stdoutWriter struct {
-	suffixFn codec.SuffixFn
-	handle   io.WriteCloser
+	suffixFn  codec.SuffixFn
+	jsonArray bool
+	multipart bool
+	handle    io.Writer
 }
 
-func newStdoutWriterFromParsed(conf *service.ParsedConfig) (*stdoutWriter, error) {
+func newStdoutWriterFromParsed(conf *service.ParsedConfig, handle io.Writer) (*stdoutWriter, error) {
 	codecStr, err := conf.FieldString("codec")
 	if err != nil {
 		return nil, err
 	}
 
+	if codecStr == stdoutCodecJSONArray {
+		return &stdoutWriter{
+			jsonArray: true,
+			handle:    handle,
+		}, nil
+	}
+	if codecStr == stdoutCodecLinesMultipart {
+		return &stdoutWriter{
+			multipart: true,
+			handle:    handle,
+		}, nil
+	}
+
 	codec, _, err := codec.GetWriter(codecStr)
 	if err != nil {
 		return nil, err
@@ -46,7 +97,7 @@ func newStdoutWriterFromParsed(conf *service.ParsedConfig) (*stdoutWriter, error
 
 	return &stdoutWriter{
 		suffixFn: codec,
-		handle:   os.Stdout,
+		handle:   handle,
 	}, nil
 }
 
@@ -73,8 +124,62 @@ func (w *stdoutWriter) writeTo(wtr io.Writer, p *service.Message) error {
 	return nil
 }
 
-func (w *stdoutWriter) Write(ctx context.Context, msg *service.Message) error {
-	return w.writeTo(w.handle, msg)
+func (w *stdoutWriter) writeJSONArray(wtr io.Writer, batch service.MessageBatch) error {
+	elements := make([]json.RawMessage, 0, len(batch))
+	for i, p := range batch {
+		mBytes, err := p.AsBytes()
+		if err != nil {
+			return err
+		}
+		if !json.Valid(mBytes) {
+			return fmt.Errorf("message %v is not valid JSON", i)
+		}
+		elements = append(elements, mBytes)
+	}
+
+	aBytes, err := json.Marshal(elements)
+	if err != nil {
+		return err
+	}
+	_, err = wtr.Write(append(aBytes, '\n'))
+	return err
+}
+
+func (w *stdoutWriter) writeMultipart(wtr io.Writer, batch service.MessageBatch) error {
+	var buf bytes.Buffer
+	for i, p := range batch {
+		mBytes, err := p.AsBytes()
+		if err != nil {
+			return err
+		}
+		mBytes = bytes.TrimSuffix(mBytes, []byte("\n"))
+		if len(mBytes) == 0 {
+			return fmt.Errorf("message %v is empty", i)
+		}
+		if bytes.IndexByte(mBytes, '\n') >= 0 {
+			return fmt.Errorf("message %v contains a line break", i)
+		}
+		buf.Write(mBytes)
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	_, err := wtr.Write(buf.Bytes())
+	return err
+}
+
+func (w *stdoutWriter) WriteBatch(ctx context.Context, batch service.MessageBatch) error {
+	if w.jsonArray {
+		return w.writeJSONArray(w.handle, batch)
+	}
+	if w.multipart {
+		return w.writeMultipart(w.handle, batch)
+	}
+	for _, p := range batch {
+		if err := w.writeTo(w.handle, p); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (w *stdoutWriter) Close(ctx context.Context) error {
@@ -0,0 +1,151 @@
+package io
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/internal/codec"
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestStdoutWriterCodecs(t *testing.T) {
+	spec := service.NewConfigSpec().Fields(service.NewInternalField(stdoutCodecDocs()))
+
+	for _, test := range []struct {
+		codec    string
+		batches  [][]string
+		expected string
+	}{
+		{
+			codec:    "lines",
+			batches:  [][]string{{"foo", "bar"}, {"baz\n"}},
+			expected: "foo\nbar\nbaz\n",
+		},
+		{
+			codec:    "delim:|",
+			batches:  [][]string{{"foo", "bar"}, {"baz"}},
+			expected: "foo|bar|baz|",
+		},
+		{
+			codec:    "json_array",
+			batches:  [][]string{{`{"id":1}`, `"two"`, `3`}, {`{"id":4}`}},
+			expected: "[{\"id\":1},\"two\",3]\n[{\"id\":4}]\n",
+		},
+		{
+			codec:    "lines/multipart",
+			batches:  [][]string{{"foo", "bar"}, {"baz\n"}},
+			expected: "foo\nbar\n\nbaz\n\n",
+		},
+	} {
+		test := test
+		t.Run(test.codec, func(t *testing.T) {
+			pConf, err := spec.ParseYAML(fmt.Sprintf(`codec: %q`, test.codec), nil)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			w, err := newStdoutWriterFromParsed(pConf, &buf)
+			require.NoError(t, err)
+
+			for _, b := range test.batches {
+				var batch service.MessageBatch
+				for _, m := range b {
+					batch = append(batch, service.NewMessage([]byte(m)))
+				}
+				require.NoError(t, w.WriteBatch(context.Background(), batch))
+			}
+			assert.Equal(t, test.expected, buf.String())
+		})
+	}
+}
+
+func TestStdoutWriterJSONArrayInvalid(t *testing.T) {
+	spec := service.NewConfigSpec().Fields(service.NewInternalField(stdoutCodecDocs()))
+	pConf, err := spec.ParseYAML(`codec: json_array`, nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := newStdoutWriterFromParsed(pConf, &buf)
+	require.NoError(t, err)
+
+	err = w.WriteBatch(context.Background(), service.MessageBatch{
+		service.NewMessage([]byte(`{"id":1}`)),
+		service.NewMessage([]byte(`not json`)),
+	})
+	require.EqualError(t, err, "message 1 is not valid JSON")
+	assert.Empty(t, buf.String())
+}
+
+func TestStdoutWriterMultipartRoundTrip(t *testing.T) {
+	spec := service.NewConfigSpec().Fields(service.NewInternalField(stdoutCodecDocs()))
+	pConf, err := spec.ParseYAML(`codec: lines/multipart`, nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := newStdoutWriterFromParsed(pConf, &buf)
+	require.NoError(t, err)
+
+	batches := [][]string{{"foo", "bar", "baz"}, {"buz"}, {"qux", "quz"}}
+	for _, b := range batches {
+		var batch service.MessageBatch
+		for _, m := range b {
+			batch = append(batch, service.NewMessage([]byte(m)))
+		}
+		require.NoError(t, w.WriteBatch(context.Background(), batch))
+	}
+
+	ctor, err := codec.GetReader("lines/multipart", codec.NewReaderConfig())
+	require.NoError(t, err)
+
+	r, err := ctor("", io.NopCloser(&buf), func(ctx context.Context, err error) error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	var actual [][]string
+	for {
+		parts, ackFn, err := r.Next(context.Background())
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		require.NoError(t, ackFn(context.Background(), nil))
+
+		var batch []string
+		for _, p := range parts {
+			batch = append(batch, string(p.AsBytes()))
+		}
+		actual = append(actual, batch)
+	}
+	require.NoError(t, r.Close(context.Background()))
+
+	assert.Equal(t, batches, actual)
+}
+
+func TestStdoutWriterMultipartInvalid(t *testing.T) {
+	spec := service.NewConfigSpec().Fields(service.NewInternalField(stdoutCodecDocs()))
+	pConf, err := spec.ParseYAML(`codec: lines/multipart`, nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := newStdoutWriterFromParsed(pConf, &buf)
+	require.NoError(t, err)
+
+	err = w.WriteBatch(context.Background(), service.MessageBatch{
+		service.NewMessage([]byte(`foo`)),
+		service.NewMessage(nil),
+	})
+	require.EqualError(t, err, "message 1 is empty")
+
+	err = w.WriteBatch(context.Background(), service.MessageBatch{
+		service.NewMessage([]byte("foo\nbar")),
+	})
+	require.EqualError(t, err, "message 0 contains a line break")
+	assert.Empty(t, buf.String())
+}
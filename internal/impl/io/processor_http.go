@@ -57,7 +57,8 @@ pipeline:
 		).
 		Field(httpclient.ConfigField("POST", false,
 			service.NewBoolField("batch_as_multipart").Description("Send message batches as a single request using [RFC1341](https://www.w3.org/Protocols/rfc1341/7_2_Multipart.html).").Advanced().Default(false),
-			service.NewBoolField("parallel").Description("When processing batched messages, whether to send messages of the batch in parallel, otherwise they are sent serially.").Default(false)),
+			service.NewBoolField("parallel").Description("When processing batched messages, whether to send messages of the batch in parallel, otherwise they are sent serially.").Default(false),
+			service.NewIntField("max_parallel").Description("When `parallel` is enabled, the maximum number of messages of a batch to send concurrently. When set to zero all messages of a batch are sent at once.").Advanced().Default(0).Version("4.28.0")),
 		)
 }
 
@@ -76,6 +77,7 @@ type httpProc struct {
 	client      *httpclient.Client
 	asMultipart bool
 	parallel    bool
+	maxParallel int
 	rawURL      string
 	log         *service.Logger
 }
@@ -96,6 +98,14 @@ func newHTTPProcFromParsed(conf *service.ParsedConfig, mgr *service.Resources) (
 		return nil, err
 	}
 
+	maxParallel, err := conf.FieldInt("max_parallel")
+	if err != nil {
+		return nil, err
+	}
+	if maxParallel < 0 {
+		return nil, fmt.Errorf("max_parallel must not be negative, got %v", maxParallel)
+	}
+
 	rawURL, _ := conf.FieldString("url")
 
 	g := &httpProc{
@@ -103,6 +113,7 @@ func newHTTPProcFromParsed(conf *service.ParsedConfig, mgr *service.Resources) (
 		log:         mgr.Logger(),
 		asMultipart: asMultipart,
 		parallel:    parallel,
+		maxParallel: maxParallel,
 	}
 	if g.client, err = httpclient.NewClientFromOldConfig(oldConf, mgr); err != nil {
 		return nil, err
@@ -115,7 +126,7 @@ func (h *httpProc) ProcessBatch(ctx context.Context, msg service.MessageBatch) (
 
 	if h.asMultipart || len(msg) == 1 {
 		// Easy, just do a single request.
-		resultMsg, err := h.client.Send(context.Background(), msg)
+		resultMsg, err := h.client.Send(ctx, msg)
 		if err != nil {
 			var code int
 			var hErr httpclient.ErrUnexpectedHTTPRes
@@ -153,7 +164,7 @@ func (h *httpProc) ProcessBatch(ctx context.Context, msg service.MessageBatch) (
 	} else if !h.parallel {
 		for _, p := range msg {
 			tmpMsg := service.MessageBatch{p}
-			result, err := h.client.Send(context.Background(), tmpMsg)
+			result, err := h.client.Send(ctx, tmpMsg)
 			if err != nil {
 				h.log.Errorf("HTTP request to '%v' failed: %v", h.rawURL, err)
 
@@ -188,11 +199,15 @@ func (h *httpProc) ProcessBatch(ctx context.Context, msg service.MessageBatch) (
 		}
 		reqChan, resChan := make(chan int), make(chan error)
 
-		for i := 0; i < len(msg); i++ {
+		workers := len(msg)
+		if h.maxParallel > 0 && h.maxParallel < workers {
+			workers = h.maxParallel
+		}
+		for i := 0; i < workers; i++ {
 			go func() {
 				for index := range reqChan {
 					tmpMsg := service.MessageBatch{msg[index]}
-					result, err := h.client.Send(context.Background(), tmpMsg)
+					result, err := h.client.Send(ctx, tmpMsg)
 					if err == nil && len(result) != 1 {
 						err = fmt.Errorf("unexpected response size: %v", len(result))
 					}
@@ -9,6 +9,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -432,3 +433,47 @@ http:
 		}
 	}
 }
+
+func TestHTTPClientParallelLimit(t *testing.T) {
+	var inFlight, maxInFlight int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			prev := atomic.LoadInt32(&maxInFlight)
+			if n <= prev || atomic.CompareAndSwapInt32(&maxInFlight, prev, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond * 20)
+		_, _ = w.Write([]byte("foobar"))
+	}))
+	defer ts.Close()
+
+	conf := parseYAMLProcConf(t, `
+http:
+  url: %v/testpost
+  parallel: true
+  max_parallel: 2
+`, ts.URL)
+
+	h, err := mock.NewManager().NewProcessor(conf)
+	require.NoError(t, err)
+
+	msgs, res := h.ProcessBatch(context.Background(), message.QuickBatch([][]byte{
+		[]byte("foo"),
+		[]byte("bar"),
+		[]byte("baz"),
+		[]byte("qux"),
+		[]byte("quz"),
+		[]byte("quy"),
+	}))
+	require.NoError(t, res)
+	require.Len(t, msgs, 1)
+	require.Len(t, msgs[0], 6)
+	for _, p := range msgs[0] {
+		assert.Equal(t, "foobar", string(p.AsBytes()))
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+	assert.Greater(t, atomic.LoadInt32(&maxInFlight), int32(0))
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"math/bits"
+	"os"
 	"os/exec"
 	"strconv"
 	"sync"
@@ -31,6 +32,7 @@ const (
 	spFieldMaxBuffer = "max_buffer"
 	spFieldCodecSend = "codec_send"
 	spFieldCodecRecv = "codec_recv"
+	spFieldTimeout   = "timeout"
 )
 
 type subprocConfig struct {
@@ -39,6 +41,7 @@ type subprocConfig struct {
 	MaxBuffer int
 	CodecSend string
 	CodecRecv string
+	Timeout   time.Duration
 }
 
 func subProcSpec() *service.ConfigSpec {
@@ -59,6 +62,8 @@ The execution environment of the subprocess is the same as the Benthos instance,
 
 The field `+"`max_buffer`"+` defines the maximum response size able to be read from the subprocess. This value should be set significantly above the real expected maximum response size.
 
+When a `+"[`timeout`](#timeout)"+` is specified and the subprocess fails to respond to a message within that period the message is marked as failed and the subprocess is restarted, since any response it might later write would otherwise be attributed to the wrong message.
+
 ## Subprocess requirements
 
 It is required that subprocesses flush their stdout and stderr pipes for each line. Benthos will attempt to keep the process alive for as long as the pipeline is running. If the process exits early it will be restarted.
@@ -87,6 +92,12 @@ If a message contains line breaks each line of the message is piped to the subpr
 				Version("3.37.0").
 				Advanced().
 				Default("lines"),
+			service.NewDurationField(spFieldTimeout).
+				Description("An optional maximum period of time to wait for the subprocess to respond to each message. When a response is not received within this period the message is marked as failed and the subprocess is restarted.").
+				Example("5s").
+				Version("4.28.0").
+				Advanced().
+				Optional(),
 		)
 }
 
@@ -111,6 +122,11 @@ func init() {
 			if sConf.CodecRecv, err = conf.FieldString(spFieldCodecRecv); err != nil {
 				return nil, err
 			}
+			if conf.Contains(spFieldTimeout) {
+				if sConf.Timeout, err = conf.FieldDuration(spFieldTimeout); err != nil {
+					return nil, err
+				}
+			}
 
 			mgr := interop.UnwrapManagement(res)
 			p, err := newSubprocess(sConf, mgr)
@@ -128,13 +144,15 @@ type subprocessProc struct {
 	log log.Modular
 
 	subproc  *subprocWrapper
-	procFunc func(part *message.Part) error
+	procFunc func(ctx context.Context, part *message.Part) error
+	timeout  time.Duration
 	mut      sync.Mutex
 }
 
 func newSubprocess(conf subprocConfig, mgr bundle.NewManagement) (*subprocessProc, error) {
 	e := &subprocessProc{
-		log: mgr.Logger(),
+		log:     mgr.Logger(),
+		timeout: conf.Timeout,
 	}
 	var err error
 	if e.subproc, err = newSubprocWrapper(conf.Name, conf.Args, conf.MaxBuffer, conf.CodecRecv, mgr.Logger()); err != nil {
@@ -146,17 +164,17 @@ func newSubprocess(conf subprocConfig, mgr bundle.NewManagement) (*subprocessPro
 	return e, nil
 }
 
-func (e *subprocessProc) getSendSubprocessorFunc(codec string) (func(part *message.Part) error, error) {
+func (e *subprocessProc) getSendSubprocessorFunc(codec string) (func(ctx context.Context, part *message.Part) error, error) {
 	switch codec {
 	case "length_prefixed_uint32_be":
-		return func(part *message.Part) error {
+		return func(ctx context.Context, part *message.Part) error {
 			const prefixBytes int = 4
 
 			lenBuf := make([]byte, prefixBytes)
 			m := part.AsBytes()
 			binary.BigEndian.PutUint32(lenBuf, uint32(len(m)))
 
-			res, err := e.subproc.Send(lenBuf, m, nil)
+			res, err := e.subproc.Send(ctx, lenBuf, m, nil)
 			if err != nil {
 				e.log.Error("Failed to send message to subprocess: %v\n", err)
 				return err
@@ -167,11 +185,11 @@ func (e *subprocessProc) getSendSubprocessorFunc(codec string) (func(part *messa
 			return nil
 		}, nil
 	case "netstring":
-		return func(part *message.Part) error {
+		return func(ctx context.Context, part *message.Part) error {
 			lenBuf := make([]byte, 0)
 			m := part.AsBytes()
 			lenBuf = append(strconv.AppendUint(lenBuf, uint64(len(m)), 10), ':')
-			res, err := e.subproc.Send(lenBuf, m, commaBytes)
+			res, err := e.subproc.Send(ctx, lenBuf, m, commaBytes)
 			if err != nil {
 				e.log.Error("Failed to send message to subprocess: %v\n", err)
 				return err
@@ -182,7 +200,7 @@ func (e *subprocessProc) getSendSubprocessorFunc(codec string) (func(part *messa
 			return nil
 		}, nil
 	case "lines":
-		return func(part *message.Part) error {
+		return func(ctx context.Context, part *message.Part) error {
 			results := [][]byte{}
 			splitMsg := bytes.Split(part.AsBytes(), newLineBytes)
 			for j, p := range splitMsg {
@@ -190,7 +208,7 @@ func (e *subprocessProc) getSendSubprocessorFunc(codec string) (func(part *messa
 					results = append(results, []byte(""))
 					continue
 				}
-				res, err := e.subproc.Send(nil, p, newLineBytes)
+				res, err := e.subproc.Send(ctx, nil, p, newLineBytes)
 				if err != nil {
 					e.log.Error("Failed to send message to subprocess: %v\n", err)
 					return err
@@ -221,6 +239,11 @@ type subprocWrapper struct {
 	cmdStdin    io.WriteCloser
 	cmdCancelFn func()
 
+	// When non-nil the subprocess has been abandoned and sends are held until
+	// it has been restarted, at which point the channel is closed.
+	cmdRestarted chan struct{}
+	abandonChan  chan struct{}
+
 	shutSig *shutdown.Signaller
 }
 
@@ -231,6 +254,8 @@ func newSubprocWrapper(name string, args []string, maxBuf int, codecRecv string,
 		maxBuf:  maxBuf,
 		logger:  log,
 		shutSig: shutdown.NewSignaller(),
+
+		abandonChan: make(chan struct{}, 1),
 	}
 	switch codecRecv {
 	case "lines":
@@ -255,24 +280,11 @@ func newSubprocWrapper(name string, args []string, maxBuf int, codecRecv string,
 			case <-s.cmdExitChan:
 				log.Warn("Subprocess exited")
 				_ = s.stop()
-
-				// Flush channels
-				var msgBytes []byte
-				for stdoutMsg := range s.stdoutChan {
-					msgBytes = append(msgBytes, stdoutMsg...)
-				}
-				if len(msgBytes) > 0 {
-					log.Info(string(msgBytes))
-				}
-				msgBytes = nil
-				for stderrMsg := range s.stderrChan {
-					msgBytes = append(msgBytes, stderrMsg...)
-				}
-				if len(msgBytes) > 0 {
-					log.Error(string(msgBytes))
-				}
-
-				_ = s.start()
+				s.flushAndRestart()
+			case <-s.abandonChan:
+				log.Warn("Subprocess abandoned, restarting")
+				_ = s.stop()
+				s.flushAndRestart()
 			case <-s.shutSig.SoftStopChan():
 				return
 			}
@@ -281,6 +293,27 @@ func newSubprocWrapper(name string, args []string, maxBuf int, codecRecv string,
 	return s, nil
 }
 
+// flushAndRestart logs any remaining output of a stopped subprocess and then
+// starts a new one.
+func (s *subprocWrapper) flushAndRestart() {
+	var msgBytes []byte
+	for stdoutMsg := range s.stdoutChan {
+		msgBytes = append(msgBytes, stdoutMsg...)
+	}
+	if len(msgBytes) > 0 {
+		s.logger.Info(string(msgBytes))
+	}
+	msgBytes = nil
+	for stderrMsg := range s.stderrChan {
+		msgBytes = append(msgBytes, stderrMsg...)
+	}
+	if len(msgBytes) > 0 {
+		s.logger.Error(string(msgBytes))
+	}
+
+	_ = s.start()
+}
+
 var maxInt = (1<<bits.UintSize)/2 - 1
 
 func lengthPrefixedUInt32BESplitFunc(data []byte, atEOF bool) (advance int, token []byte, err error) {
@@ -333,6 +366,16 @@ func netstringSplitFunc(data []byte, atEOF bool) (advance int, token []byte, err
 func (s *subprocWrapper) start() (err error) {
 	s.cmdMut.Lock()
 	defer s.cmdMut.Unlock()
+	defer func() {
+		if s.cmdRestarted != nil {
+			close(s.cmdRestarted)
+			s.cmdRestarted = nil
+		}
+		select {
+		case <-s.abandonChan:
+		default:
+		}
+	}()
 
 	cmdCtx, cmdCancelFn := context.WithCancel(context.Background())
 	defer func() {
@@ -388,7 +431,7 @@ func (s *subprocWrapper) start() (err error) {
 
 			stdoutChan <- dataCopy
 		}
-		if err := scanner.Err(); err != nil {
+		if err := scanner.Err(); err != nil && !errors.Is(err, os.ErrClosed) {
 			s.logger.Error("Failed to read subprocess output: %v\n", err)
 		}
 	}()
@@ -414,7 +457,7 @@ func (s *subprocWrapper) start() (err error) {
 
 			stderrChan <- dataCopy
 		}
-		if err := scanner.Err(); err != nil {
+		if err := scanner.Err(); err != nil && !errors.Is(err, os.ErrClosed) {
 			s.logger.Error("Failed to read subprocess error output: %v\n", err)
 		}
 	}()
@@ -440,33 +483,95 @@ func (s *subprocWrapper) stop() error {
 	return err
 }
 
-func (s *subprocWrapper) Send(prolog, payload, epilog []byte) ([]byte, error) {
+// abandon kills the subprocess that owns the provided stdin, if it is still
+// running, and holds all sends until a new subprocess has been started. This
+// prevents a late response to an abandoned payload from being read as the
+// response to the next.
+func (s *subprocWrapper) abandon(stdin io.WriteCloser) {
 	s.cmdMut.Lock()
-	stdin := s.cmdStdin
-	outChan := s.stdoutChan
-	errChan := s.stderrChan
+	if s.cmdStdin == stdin && s.cmdRestarted == nil {
+		s.cmdRestarted = make(chan struct{})
+		s.cmdCancelFn()
+
+		// Children of the subprocess may keep its output pipes open, and so
+		// rather than waiting for them to close we restart immediately.
+		select {
+		case s.abandonChan <- struct{}{}:
+		default:
+		}
+	}
 	s.cmdMut.Unlock()
+}
 
-	if stdin == nil {
-		return nil, component.ErrTypeClosed
+func writeAll(w io.Writer, parts ...[]byte) error {
+	for _, p := range parts {
+		if p == nil {
+			continue
+		}
+		if _, err := w.Write(p); err != nil {
+			return err
+		}
 	}
-	if prolog != nil {
-		if _, err := stdin.Write(prolog); err != nil {
-			return nil, err
+	return nil
+}
+
+func (s *subprocWrapper) Send(ctx context.Context, prolog, payload, epilog []byte) ([]byte, error) {
+	var stdin io.WriteCloser
+	var outChan, errChan chan []byte
+	for {
+		s.cmdMut.Lock()
+		stdin = s.cmdStdin
+		outChan = s.stdoutChan
+		errChan = s.stderrChan
+		restarted := s.cmdRestarted
+		s.cmdMut.Unlock()
+
+		if restarted == nil {
+			break
+		}
+		select {
+		case <-restarted:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-s.shutSig.SoftStopChan():
+			return nil, component.ErrTypeClosed
 		}
 	}
-	if _, err := stdin.Write(payload); err != nil {
-		return nil, err
+
+	if stdin == nil {
+		return nil, component.ErrTypeClosed
 	}
-	if epilog != nil {
-		if _, err := stdin.Write(epilog); err != nil {
+	if ctx.Done() == nil {
+		if err := writeAll(stdin, prolog, payload, epilog); err != nil {
 			return nil, err
 		}
+	} else {
+		// Writes block when the subprocess isn't reading its stdin, in which
+		// case killing it unblocks the write.
+		writeErrChan := make(chan error, 1)
+		go func() {
+			writeErrChan <- writeAll(stdin, prolog, payload, epilog)
+		}()
+		select {
+		case err := <-writeErrChan:
+			if err != nil {
+				return nil, err
+			}
+		case <-ctx.Done():
+			s.abandon(stdin)
+			return nil, ctx.Err()
+		}
 	}
 
 	var outBytes, errBytes []byte
 	var open bool
 	select {
+	case <-ctx.Done():
+		// The subprocess may still write a response for this payload, which
+		// would then be read as the response to the next, so we kill it and
+		// let it be restarted.
+		s.abandon(stdin)
+		return nil, ctx.Err()
 	case outBytes, open = <-outChan:
 	case errBytes, open = <-errChan:
 		tout := time.After(time.Second)
@@ -505,7 +610,13 @@ func (e *subprocessProc) Process(ctx context.Context, msg *message.Part) ([]*mes
 	e.mut.Lock()
 	defer e.mut.Unlock()
 
-	if err := e.procFunc(msg); err != nil {
+	if e.timeout > 0 {
+		var done func()
+		ctx, done = context.WithTimeout(ctx, e.timeout)
+		defer done()
+	}
+
+	if err := e.procFunc(ctx, msg); err != nil {
 		return nil, err
 	}
 	return []*message.Part{msg}, nil
@@ -173,6 +173,42 @@ subprocess:
 	require.NoError(t, proc.Close(ctx))
 }
 
+func TestSubprocessTimeout(t *testing.T) {
+	conf, err := testutil.ProcessorFromYAML(`
+subprocess:
+  name: sh
+  args: [ "-c", "while read l; do if [ \"$l\" = slow ]; then sleep 0.3; fi; echo \"$l\"; done" ]
+  timeout: 50ms
+`)
+	require.NoError(t, err)
+
+	proc, err := mock.NewManager().NewProcessor(conf)
+	if err != nil {
+		t.Skipf("Not sure if this is due to missing executable: %v", err)
+	}
+
+	msgs, _ := proc.ProcessBatch(context.Background(), message.QuickBatch([][]byte{
+		[]byte(`slow`),
+	}))
+	require.Len(t, msgs, 1)
+	require.Error(t, msgs[0].Get(0).ErrorGet())
+
+	// The late response to the timed out message must not be attributed to
+	// the following messages, which are held until the subprocess restarts.
+	for _, content := range []string{"fast", "faster", "fastest"} {
+		msgs, _ := proc.ProcessBatch(context.Background(), message.QuickBatch([][]byte{
+			[]byte(content),
+		}))
+		require.Len(t, msgs, 1)
+		require.NoError(t, msgs[0].Get(0).ErrorGet())
+		assert.Equal(t, content, string(msgs[0].Get(0).AsBytes()))
+	}
+
+	ctx, done := context.WithTimeout(context.Background(), time.Second*5)
+	defer done()
+	require.NoError(t, proc.Close(ctx))
+}
+
 func testProgram(t *testing.T, program string) string {
 	t.Helper()
 
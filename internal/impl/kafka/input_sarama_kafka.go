@@ -49,6 +49,10 @@ In order to enforce ordered processing of partition messages set the `+"[`checkp
 
 Batching messages before processing can be enabled using the `+"[`batching`](#batching)"+` field, and this batching is performed per-partition such that messages of a batch will always originate from the same partition. This batching mechanism is capable of creating batches of greater size than the `+"[`checkpoint_limit`](#checkpoint_limit)"+`, in which case the next batch will only be created upon delivery of the current one.
 
+### Rebalancing
+
+When partitions are revoked from this consumer during a rebalance consumption of those partitions is paused until all messages already consumed from them have been acknowledged, or until the `+"[`group.rebalance_timeout`](#grouprebalance_timeout)"+` is reached, so that their offsets are committed before another member of the group takes ownership. Acknowledgements that arrive after a partition has been revoked do not commit offsets, and therefore those messages may be redelivered to the new owner of the partition.
+
 ### Metadata
 
 This input adds the following metadata fields to each message:
@@ -197,6 +201,7 @@ type kafkaReader struct {
 	cMut            sync.Mutex
 	consumerCloseFn context.CancelFunc
 	consumerDoneCtx context.Context
+	groupCtx        context.Context
 	msgChan         chan asyncMessage
 	session         offsetMarker
 
@@ -291,7 +296,23 @@ func newKafkaReaderFromParsed(conf *service.ParsedConfig, mgr *service.Resources
 
 //------------------------------------------------------------------------------
 
-func (k *kafkaReader) asyncCheckpointer(topic string, partition int32) func(context.Context, chan<- asyncMessage, service.MessageBatch, int64) bool {
+// markOffset marks an offset for a topic partition on the active session. When
+// sess is non-nil the offset is only marked if that session is still active,
+// which prevents acknowledgements that resolve after a rebalance from marking
+// offsets of partitions that may now be owned by another consumer.
+func (k *kafkaReader) markOffset(sess offsetMarker, topic string, partition int32, offset int64) {
+	k.cMut.Lock()
+	defer k.cMut.Unlock()
+
+	if k.session == nil || (sess != nil && k.session != sess) {
+		k.mgr.Logger().Debugf("Unable to mark offset for topic '%v' partition '%v'.\n", topic, partition)
+		return
+	}
+	k.mgr.Logger().Tracef("Marking offset for topic '%v' partition '%v'.\n", topic, partition)
+	k.session.MarkOffset(topic, partition, offset, "")
+}
+
+func (k *kafkaReader) asyncCheckpointer(topic string, partition int32, sess offsetMarker, inFlight *sync.WaitGroup) func(context.Context, chan<- asyncMessage, service.MessageBatch, int64) bool {
 	cp := checkpoint.NewCapped[int64](int64(k.checkpointLimit))
 	return func(ctx context.Context, c chan<- asyncMessage, msg service.MessageBatch, offset int64) bool {
 		if msg == nil {
@@ -304,53 +325,44 @@ func (k *kafkaReader) asyncCheckpointer(topic string, partition int32) func(cont
 			}
 			return false
 		}
+		inFlight.Add(1)
 		select {
 		case c <- asyncMessage{
 			msg: msg,
 			ackFn: func(ctx context.Context, res error) error {
+				defer inFlight.Done()
 				maxOffset := resolveFn()
 				if maxOffset == nil {
 					return nil
 				}
-				k.cMut.Lock()
-				if k.session != nil {
-					k.mgr.Logger().Tracef("Marking offset for topic '%v' partition '%v'.\n", topic, partition)
-					k.session.MarkOffset(topic, partition, *maxOffset, "")
-				} else {
-					k.mgr.Logger().Debugf("Unable to mark offset for topic '%v' partition '%v'.\n", topic, partition)
-				}
-				k.cMut.Unlock()
+				k.markOffset(sess, topic, partition, *maxOffset)
 				return nil
 			},
 		}:
 		case <-ctx.Done():
+			inFlight.Done()
 			return false
 		}
 		return true
 	}
 }
 
-func (k *kafkaReader) syncCheckpointer(topic string, partition int32) func(context.Context, chan<- asyncMessage, service.MessageBatch, int64) bool {
+func (k *kafkaReader) syncCheckpointer(topic string, partition int32, sess offsetMarker, inFlight *sync.WaitGroup) func(context.Context, chan<- asyncMessage, service.MessageBatch, int64) bool {
 	ackedChan := make(chan error)
 	return func(ctx context.Context, c chan<- asyncMessage, msg service.MessageBatch, offset int64) bool {
 		if msg == nil {
 			return true
 		}
+		inFlight.Add(1)
 		select {
 		case c <- asyncMessage{
 			msg: msg,
 			ackFn: func(ctx context.Context, res error) error {
 				resErr := res
 				if resErr == nil {
-					k.cMut.Lock()
-					if k.session != nil {
-						k.mgr.Logger().Debugf("Marking offset for topic '%v' partition '%v'.\n", topic, partition)
-						k.session.MarkOffset(topic, partition, offset, "")
-					} else {
-						k.mgr.Logger().Debugf("Unable to mark offset for topic '%v' partition '%v'.\n", topic, partition)
-					}
-					k.cMut.Unlock()
+					k.markOffset(sess, topic, partition, offset)
 				}
+				inFlight.Done()
 				select {
 				case ackedChan <- resErr:
 				case <-ctx.Done():
@@ -368,6 +380,7 @@ func (k *kafkaReader) syncCheckpointer(topic string, partition int32) func(conte
 				return false
 			}
 		case <-ctx.Done():
+			inFlight.Done()
 			return false
 		}
 		return true
@@ -3,6 +3,7 @@ package kafka
 import (
 	"context"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/IBM/sarama"
@@ -47,12 +48,18 @@ func (k *kafkaReader) ConsumeClaim(sess sarama.ConsumerGroupSession, claim saram
 	}
 	defer batchPolicy.Close(context.Background())
 
+	// Track message batches that are yet to be acknowledged so that we can
+	// hold onto the claim until they're resolved, this ensures that their
+	// offsets are marked before the session commits for the last time.
+	var inFlight sync.WaitGroup
+	defer k.waitForInFlight(topic, partition, &inFlight)
+
 	var nextTimedBatchChan <-chan time.Time
 	var flushBatch func(context.Context, chan<- asyncMessage, service.MessageBatch, int64) bool
 	if k.checkpointLimit > 1 {
-		flushBatch = k.asyncCheckpointer(claim.Topic(), claim.Partition())
+		flushBatch = k.asyncCheckpointer(topic, partition, sess, &inFlight)
 	} else {
-		flushBatch = k.syncCheckpointer(claim.Topic(), claim.Partition())
+		flushBatch = k.syncCheckpointer(topic, partition, sess, &inFlight)
 	}
 
 	for {
@@ -97,6 +104,38 @@ func (k *kafkaReader) ConsumeClaim(sess sarama.ConsumerGroupSession, claim saram
 	}
 }
 
+// waitForInFlight blocks until all message batches consumed from a claim have
+// been acknowledged, the consumer group is closed, or the rebalance timeout is
+// reached. Consumption of the claim is paused for the duration of the wait.
+func (k *kafkaReader) waitForInFlight(topic string, partition int32, inFlight *sync.WaitGroup) {
+	resolvedChan := make(chan struct{})
+	go func() {
+		inFlight.Wait()
+		close(resolvedChan)
+	}()
+
+	select {
+	case <-resolvedChan:
+		return
+	default:
+	}
+
+	k.cMut.Lock()
+	groupCtx := k.groupCtx
+	k.cMut.Unlock()
+	if groupCtx == nil {
+		return
+	}
+
+	k.mgr.Logger().Debugf("Waiting for in flight messages from topic '%v' partition '%v' to be acknowledged\n", topic, partition)
+	select {
+	case <-resolvedChan:
+	case <-groupCtx.Done():
+	case <-time.After(k.saramConf.Consumer.Group.Rebalance.Timeout):
+		k.mgr.Logger().Warnf("Timed out waiting for in flight messages from topic '%v' partition '%v' to be acknowledged, these messages may be redelivered\n", topic, partition)
+	}
+}
+
 //------------------------------------------------------------------------------
 
 func (k *kafkaReader) connectBalancedTopics(ctx context.Context, config *sarama.Config) error {
@@ -134,6 +173,7 @@ func (k *kafkaReader) connectBalancedTopics(ctx context.Context, config *sarama.
 
 			k.cMut.Lock()
 			k.consumerCloseFn = doneFn
+			k.groupCtx = ctx
 			k.cMut.Unlock()
 
 			k.mgr.Logger().Debug("Starting consumer group")
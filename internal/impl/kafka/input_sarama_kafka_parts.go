@@ -43,12 +43,13 @@ func (k *kafkaReader) runPartitionConsumer(
 	}
 	defer batchPolicy.Close(context.Background())
 
+	var inFlight sync.WaitGroup
 	var nextTimedBatchChan <-chan time.Time
 	var flushBatch func(context.Context, chan<- asyncMessage, service.MessageBatch, int64) bool
 	if k.checkpointLimit > 1 {
-		flushBatch = k.asyncCheckpointer(topic, partition)
+		flushBatch = k.asyncCheckpointer(topic, partition, nil, &inFlight)
 	} else {
-		flushBatch = k.syncCheckpointer(topic, partition)
+		flushBatch = k.syncCheckpointer(topic, partition, nil, &inFlight)
 	}
 
 	var latestOffset int64
@@ -1,8 +1,11 @@
 package kafka
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Jeffail/gabs/v2"
 	"github.com/stretchr/testify/assert"
@@ -49,3 +52,65 @@ topics: %v
 		})
 	}
 }
+
+type testOffsetMarker struct {
+	marked []int64
+}
+
+func (t *testOffsetMarker) MarkOffset(topic string, partition int32, offset int64, metadata string) {
+	t.marked = append(t.marked, offset)
+}
+
+func TestKafkaCheckpointerStaleSession(t *testing.T) {
+	pConf, err := iskConfigSpec().ParseYAML(`
+addresses: [ example.com:1234 ]
+topics: [ foo ]
+consumer_group: bar
+checkpoint_limit: 10
+`, nil)
+	require.NoError(t, err)
+
+	k, err := newKafkaReaderFromParsed(pConf, service.MockResources())
+	require.NoError(t, err)
+
+	oldSess, newSess := &testOffsetMarker{}, &testOffsetMarker{}
+	k.session = oldSess
+	k.groupCtx = context.Background()
+
+	var inFlight sync.WaitGroup
+	msgChan := make(chan asyncMessage, 2)
+	flushBatch := k.asyncCheckpointer("foo", 0, oldSess, &inFlight)
+
+	require.True(t, flushBatch(context.Background(), msgChan, service.MessageBatch{service.NewMessage([]byte("a"))}, 1))
+	require.True(t, flushBatch(context.Background(), msgChan, service.MessageBatch{service.NewMessage([]byte("b"))}, 2))
+
+	first, second := <-msgChan, <-msgChan
+	require.NoError(t, first.ackFn(context.Background(), nil))
+	assert.Equal(t, []int64{1}, oldSess.marked)
+
+	// Simulate a rebalance, the remaining ack must not be marked against the
+	// new session.
+	k.session = newSess
+
+	waitDone := make(chan struct{})
+	go func() {
+		k.waitForInFlight("foo", 0, &inFlight)
+		close(waitDone)
+	}()
+
+	select {
+	case <-waitDone:
+		t.Fatal("expected wait to block on in flight messages")
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	require.NoError(t, second.ackFn(context.Background(), nil))
+	select {
+	case <-waitDone:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for in flight messages")
+	}
+
+	assert.Equal(t, []int64{1}, oldSess.marked)
+	assert.Empty(t, newSess.marked)
+}
@@ -39,6 +39,7 @@ const (
 	oskFieldMaxMsgBytes                  = "max_msg_bytes"
 	oskFieldTimeout                      = "timeout"
 	oskFieldIdempotentWrite              = "idempotent_write"
+	oskFieldTransactionID                = "transaction_id"
 	oskFieldRetryAsBatch                 = "retry_as_batch"
 	oskFieldBatching                     = "batching"
 	oskFieldMaxRetries                   = "max_retries"
@@ -66,6 +67,10 @@ You must also ensure that failed batches are never rerouted back to the same out
 
 However, this also means that manual intervention will eventually be required in cases where the batch cannot be sent due to configuration problems such as an incorrect `+"`max_msg_bytes`"+` estimate. A less strict but automated alternative would be to route failed batches to a dead letter queue using a `+"[`fallback` broker](/docs/components/outputs/fallback)"+`, but this would allow subsequent batches to be delivered in the meantime whilst those failed batches are dealt with.
 
+### Transactions
+
+When the field `+"`transaction_id`"+` is set each batch is written within a Kafka transaction, which is committed before the batch is acknowledged. If any message of the batch fails to send then the transaction is aborted and the whole batch is retried within a new transaction, which means consumers reading with the isolation level `+"`read_committed`"+` will never observe partially written batches. Transactions require the idempotent producer, which is enabled automatically, and the field `+"`max_in_flight`"+` must be set to `+"`1`"+`.
+
 ### Troubleshooting
 
 If you're seeing issues writing to or reading from Kafka with this component then it's worth trying out the newer `+"[`kafka_franz` output](/docs/components/outputs/kafka_franz)"+`.
@@ -127,9 +132,15 @@ Unfortunately this error message will appear for a wide range of connection prob
 			service.NewInjectTracingSpanMappingField(),
 			service.NewOutputMaxInFlightField(),
 			service.NewBoolField(oskFieldIdempotentWrite).
-				Description("Enable the idempotent write producer option. This requires the `IDEMPOTENT_WRITE` permission on `CLUSTER` and can be disabled if this permission is not available.").
+				Description("Enable the idempotent write producer option. This requires the `IDEMPOTENT_WRITE` permission on `CLUSTER` and can be disabled if this permission is not available. When enabled messages are acknowledged by all replicas regardless of the field `ack_replicas`, and at most one request is sent to each broker at a time.").
 				Default(false).
 				Advanced(),
+			service.NewStringField(oskFieldTransactionID).
+				Description("An optional transactional identifier, which when set causes each batch to be written within a Kafka transaction. The identifier must be unique to each producer instance and requires a `target_version` of at least `0.11.0.0`.").
+				Example("benthos-producer-1").
+				Optional().
+				Advanced().
+				Version("4.28.0"),
 			service.NewBoolField(oskFieldAckReplicas).
 				Description("Ensure that messages have been copied across all replicas before acknowledging receipt.").
 				Advanced().Default(false),
@@ -167,6 +178,10 @@ func init() {
 		if mIF, err = conf.FieldMaxInFlight(); err != nil {
 			return
 		}
+		if conf.Contains(oskFieldTransactionID) && mIF > 1 {
+			err = fmt.Errorf("max_in_flight must be 1 when a transaction_id is set, got %v", mIF)
+			return
+		}
 
 		o, err = conf.WrapBatchOutputExtractTracingSpanMapping("kafka", o)
 		return
@@ -186,6 +201,7 @@ type kafkaWriter struct {
 	staticHeaders map[string]string
 	metaFilter    *service.MetadataExcludeFilter
 	retryAsBatch  bool
+	transactional bool
 
 	customTopicCreation bool
 	customTopicParts    int
@@ -440,15 +456,35 @@ func (k *kafkaWriter) saramaConfigFromParsed(conf *service.ParsedConfig) (*saram
 		return nil, err
 	}
 
-	if ackReplicas {
+	if conf.Contains(oskFieldTransactionID) {
+		if config.Producer.Transaction.ID, err = conf.FieldString(oskFieldTransactionID); err != nil {
+			return nil, err
+		}
+		config.Producer.Idempotent = true
+		k.transactional = true
+	}
+
+	if ackReplicas || config.Producer.Idempotent {
 		config.Producer.RequiredAcks = sarama.WaitForAll
 	} else {
 		config.Producer.RequiredAcks = sarama.WaitForLocal
 	}
+	if config.Producer.Idempotent {
+		config.Net.MaxOpenRequests = 1
+	}
 
 	if err := ApplySaramaSASLFromParsed(conf, k.mgr, config); err != nil {
 		return nil, err
 	}
+
+	// Idempotent and transactional writes have requirements such as a minimum
+	// protocol version, which we'd rather report now than on each connection
+	// attempt.
+	if config.Producer.Idempotent {
+		if err := config.Validate(); err != nil {
+			return nil, err
+		}
+	}
 	return config, nil
 }
 
@@ -537,9 +573,9 @@ func (k *kafkaWriter) WriteBatch(ctx context.Context, msg service.MessageBatch)
 		msgs = append(msgs, nextMsg)
 	}
 
-	err := producer.SendMessages(msgs)
+	err := k.sendMessages(producer, msgs)
 	for err != nil {
-		if pErrs, ok := err.(sarama.ProducerErrors); !k.retryAsBatch && ok {
+		if pErrs, ok := err.(sarama.ProducerErrors); !k.retryAsBatch && !k.transactional && ok {
 			if len(pErrs) == 0 {
 				break
 			}
@@ -582,12 +618,56 @@ func (k *kafkaWriter) WriteBatch(ctx context.Context, msg service.MessageBatch)
 		if producer == nil {
 			return service.ErrNotConnected
 		}
-		err = producer.SendMessages(msgs)
+		err = k.sendMessages(producer, msgs)
 	}
 
 	return nil
 }
 
+// sendMessages writes messages with the producer, and when configured with a
+// transaction_id does so within a transaction that is aborted on failure.
+func (k *kafkaWriter) sendMessages(producer sarama.SyncProducer, msgs []*sarama.ProducerMessage) error {
+	if !k.transactional {
+		return producer.SendMessages(msgs)
+	}
+
+	if err := producer.BeginTxn(); err != nil {
+		k.resetIfTxnFatal(producer)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	err := producer.SendMessages(msgs)
+	if err == nil {
+		if err = producer.CommitTxn(); err == nil {
+			return nil
+		}
+		err = fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if abortErr := producer.AbortTxn(); abortErr != nil {
+		k.mgr.Logger().Errorf("Failed to abort transaction: %v\n", abortErr)
+	}
+	k.resetIfTxnFatal(producer)
+	return err
+}
+
+// resetIfTxnFatal closes the producer if its transaction manager is in a fatal
+// state, which it cannot recover from, so that a new one is created by the
+// next connection attempt.
+func (k *kafkaWriter) resetIfTxnFatal(producer sarama.SyncProducer) {
+	if producer.TxnStatus()&sarama.ProducerTxnFlagFatalError == 0 {
+		return
+	}
+
+	k.connMut.Lock()
+	defer k.connMut.Unlock()
+
+	if k.producer == producer {
+		_ = k.producer.Close()
+		k.producer = nil
+	}
+}
+
 // Close shuts down the Kafka writer and stops processing messages.
 func (k *kafkaWriter) Close(context.Context) error {
 	k.connMut.Lock()
@@ -0,0 +1,141 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestKafkaWriterPartitioners(t *testing.T) {
+	for _, partitioner := range []string{"fnv1a_hash", "murmur2_hash"} {
+		partitioner := partitioner
+		t.Run(partitioner, func(t *testing.T) {
+			pConf, err := OSKConfigSpec().ParseYAML(fmt.Sprintf(`
+addresses: [ localhost:9092 ]
+topic: foo
+key: ${! json("id") }
+partitioner: %v
+`, partitioner), nil)
+			require.NoError(t, err)
+
+			// Constructed manually as NewKafkaWriterFromParsed connects to the
+			// cluster.
+			k := &kafkaWriter{mgr: service.MockResources()}
+			k.saramConf, err = k.saramaConfigFromParsed(pConf)
+			require.NoError(t, err)
+			k.key, err = pConf.FieldInterpolatedString(oskFieldKey)
+			require.NoError(t, err)
+			k.topic, err = pConf.FieldInterpolatedString(oskFieldTopic)
+			require.NoError(t, err)
+			k.metaFilter, err = pConf.FieldMetadataExcludeFilter(oskFieldMetadata)
+			require.NoError(t, err)
+			k.backoffCtor = func() backoff.BackOff {
+				return &backoff.StopBackOff{}
+			}
+
+			var sent []*sarama.ProducerMessage
+			producer := mocks.NewSyncProducer(t, k.saramConf)
+			for i := 0; i < 6; i++ {
+				producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+					sent = append(sent, msg)
+					return nil
+				})
+			}
+			k.producer = producer
+
+			var batch service.MessageBatch
+			for _, id := range []string{"a", "b", "c", "a", "b", "c"} {
+				batch = append(batch, service.NewMessage([]byte(fmt.Sprintf(`{"id":%q}`, id))))
+			}
+			require.NoError(t, k.WriteBatch(context.Background(), batch))
+			require.NoError(t, producer.Close())
+
+			require.Len(t, sent, 6)
+			for i := 0; i < 3; i++ {
+				keyA, err := sent[i].Key.Encode()
+				require.NoError(t, err)
+				keyB, err := sent[i+3].Key.Encode()
+				require.NoError(t, err)
+
+				assert.Equal(t, []string{"a", "b", "c"}[i], string(keyA))
+				assert.Equal(t, keyA, keyB)
+				assert.Equal(t, sent[i].Partition, sent[i+3].Partition, "key %s", keyA)
+			}
+		})
+	}
+}
+
+func TestKafkaWriterTransactions(t *testing.T) {
+	pConf, err := OSKConfigSpec().ParseYAML(`
+addresses: [ localhost:9092 ]
+topic: foo
+transaction_id: foo-producer
+`, nil)
+	require.NoError(t, err)
+
+	k := &kafkaWriter{mgr: service.MockResources()}
+	k.saramConf, err = k.saramaConfigFromParsed(pConf)
+	require.NoError(t, err)
+	assert.True(t, k.transactional)
+	assert.True(t, k.saramConf.Producer.Idempotent)
+	assert.Equal(t, sarama.WaitForAll, k.saramConf.Producer.RequiredAcks)
+
+	k.key, err = pConf.FieldInterpolatedString(oskFieldKey)
+	require.NoError(t, err)
+	k.topic, err = pConf.FieldInterpolatedString(oskFieldTopic)
+	require.NoError(t, err)
+	k.metaFilter, err = pConf.FieldMetadataExcludeFilter(oskFieldMetadata)
+	require.NoError(t, err)
+	k.backoffCtor = func() backoff.BackOff {
+		return backoff.WithMaxRetries(&backoff.ZeroBackOff{}, 1)
+	}
+
+	producer := mocks.NewSyncProducer(t, k.saramConf)
+
+	var sent []string
+	checker := func(msg *sarama.ProducerMessage) error {
+		v, err := msg.Value.Encode()
+		require.NoError(t, err)
+		assert.Equal(t, sarama.ProducerTxnFlagInTransaction, producer.TxnStatus())
+		sent = append(sent, string(v))
+		return nil
+	}
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checker)
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndFail(checker, sarama.ErrOutOfBrokers)
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checker)
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checker)
+	k.producer = producer
+
+	require.NoError(t, k.WriteBatch(context.Background(), service.MessageBatch{
+		service.NewMessage([]byte("foo")),
+		service.NewMessage([]byte("bar")),
+	}))
+	assert.Equal(t, sarama.ProducerTxnFlagReady, producer.TxnStatus())
+	require.NoError(t, producer.Close())
+
+	// The whole batch is resent within a new transaction after the failure.
+	assert.Equal(t, []string{"foo", "bar", "foo", "bar"}, sent)
+}
+
+func TestKafkaWriterTransactionsOldVersion(t *testing.T) {
+	pConf, err := OSKConfigSpec().ParseYAML(`
+addresses: [ localhost:9092 ]
+topic: foo
+target_version: 0.10.2.0
+transaction_id: foo-producer
+`, nil)
+	require.NoError(t, err)
+
+	k := &kafkaWriter{mgr: service.MockResources()}
+	_, err = k.saramaConfigFromParsed(pConf)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "V0_11_0_0")
+}
@@ -34,7 +34,11 @@ This input adds the following metadata fields to each message:
 - mqtt_message_id
 `+"```"+`
 
-You can access these metadata fields using [function interpolation](/docs/configuration/interpolation#bloblang-queries).`).
+You can access these metadata fields using [function interpolation](/docs/configuration/interpolation#bloblang-queries).
+
+### Delivery Guarantees
+
+Messages consumed with a QoS of 1 or 2 are only acknowledged to the broker once they have been successfully delivered by the outputs of the pipeline. Messages that are not acknowledged are redelivered by the broker upon reconnecting, provided that `+"`clean_session`"+` is set to `+"`false`"+`.`).
 		Fields(ClientFields()...).
 		Fields(
 			service.NewStringListField(miFieldTopics).
@@ -127,8 +131,11 @@ func (m *mqttReader) Connect(ctx context.Context) error {
 		return chanOpen
 	}
 
+	// Messages are acknowledged to the broker only once they have been
+	// delivered downstream, see the ack function returned by Read.
 	conf := m.clientBuilder.apply(mqtt.NewClientOptions()).
 		SetCleanSession(m.cleanSession).
+		SetAutoAckDisabled(true).
 		SetConnectionLostHandler(func(client mqtt.Client, reason error) {
 			client.Disconnect(0)
 			closeMsgChan()
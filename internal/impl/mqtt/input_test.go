@@ -0,0 +1,106 @@
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func (b *fakeBroker) reader(t *testing.T) *mqttReader {
+	t.Helper()
+
+	pConf, err := inputConfigSpec().ParseYAML(fmt.Sprintf(`
+urls: [ tcp://%v ]
+client_id: foo
+topics: [ bar ]
+qos: 1
+connect_timeout: 5s
+`, b.ln.Addr().String()), nil)
+	require.NoError(t, err)
+
+	r, err := newMQTTReaderFromParsed(pConf, service.MockResources())
+	require.NoError(t, err)
+	return r
+}
+
+func (b *fakeBroker) nextPuback(t *testing.T) uint16 {
+	t.Helper()
+
+	select {
+	case id := <-b.pubacks:
+		return id
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for puback")
+	}
+	return 0
+}
+
+func publishTo(t *testing.T, conn net.Conn, id uint16, payload string) {
+	t.Helper()
+
+	p := packets.NewControlPacket(packets.Publish).(*packets.PublishPacket)
+	p.Qos = 1
+	p.TopicName = "bar"
+	p.MessageID = id
+	p.Payload = []byte(payload)
+	require.NoError(t, p.Write(conn))
+}
+
+func TestMQTTInputAckAfterDelivery(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	broker := newFakeBroker(t)
+	r := broker.reader(t)
+	t.Cleanup(func() {
+		_ = r.Close(ctx)
+	})
+
+	require.NoError(t, r.Connect(ctx))
+	conn := broker.nextConn(t)
+
+	select {
+	case topics := <-broker.subscribes:
+		assert.Equal(t, []string{"bar"}, topics)
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for subscribe")
+	}
+
+	publishTo(t, conn, 7, "first")
+
+	msg, ackFn, err := r.Read(ctx)
+	require.NoError(t, err)
+	mBytes, err := msg.AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(mBytes))
+
+	// A message that failed downstream must not be acknowledged.
+	require.NoError(t, ackFn(ctx, errors.New("nope")))
+
+	publishTo(t, conn, 8, "second")
+
+	msg, ackFn, err = r.Read(ctx)
+	require.NoError(t, err)
+	mBytes, err = msg.AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(mBytes))
+
+	id, exists := msg.MetaGetMut("mqtt_message_id")
+	require.True(t, exists)
+	assert.Equal(t, 8, id)
+
+	// Only the delivered message is acknowledged, had the client acknowledged
+	// messages automatically the first message would have been acknowledged
+	// before the second was consumed.
+	require.NoError(t, ackFn(ctx, nil))
+	assert.Equal(t, uint16(8), broker.nextPuback(t))
+}
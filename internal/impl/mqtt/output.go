@@ -2,6 +2,7 @@ package mqtt
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"sync"
@@ -120,13 +121,16 @@ func (m *mqttWriter) Connect(ctx context.Context) error {
 	}
 
 	conf := m.clientBuilder.apply(mqtt.NewClientOptions()).
-		SetConnectionLostHandler(func(client mqtt.Client, reason error) {
-			client.Disconnect(0)
-			m.log.Errorf("Connection lost due to: %v", reason)
-		}).
 		SetWriteTimeout(m.writeTimeout)
 
-	client := mqtt.NewClient(conf)
+	var client mqtt.Client
+	conf = conf.SetConnectionLostHandler(func(c mqtt.Client, reason error) {
+		c.Disconnect(0)
+		m.resetClient(client)
+		m.log.Errorf("Connection lost due to: %v", reason)
+	})
+
+	client = mqtt.NewClient(conf)
 
 	tok := client.Connect()
 	tok.Wait()
@@ -168,17 +172,29 @@ func (m *mqttWriter) Write(ctx context.Context, msg *service.Message) error {
 	}
 
 	mtok := client.Publish(topicStr, m.qos, retained, mBytes)
-	mtok.Wait()
+	select {
+	case <-mtok.Done():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 	sendErr := mtok.Error()
-	if sendErr == mqtt.ErrNotConnected {
-		m.connMut.RLock()
-		m.client = nil
-		m.connMut.RUnlock()
+	if errors.Is(sendErr, mqtt.ErrNotConnected) {
+		m.resetClient(client)
 		sendErr = service.ErrNotConnected
 	}
 	return sendErr
 }
 
+// resetClient clears the client, if it hasn't already been replaced, in order
+// for the next write attempt to trigger a reconnect.
+func (m *mqttWriter) resetClient(client mqtt.Client) {
+	m.connMut.Lock()
+	if m.client == client {
+		m.client = nil
+	}
+	m.connMut.Unlock()
+}
+
 func (m *mqttWriter) Close(context.Context) error {
 	m.connMut.Lock()
 	defer m.connMut.Unlock()
@@ -0,0 +1,202 @@
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+// fakeBroker is a minimal MQTT broker that accepts connections, records
+// published payloads and never acknowledges QoS 1 or 2 publishes. Subscriptions
+// are granted and the IDs of messages acknowledged by clients are recorded.
+type fakeBroker struct {
+	ln         net.Listener
+	conns      chan net.Conn
+	publishes  chan string
+	subscribes chan []string
+	pubacks    chan uint16
+}
+
+func newFakeBroker(t *testing.T) *fakeBroker {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	b := &fakeBroker{
+		ln:         ln,
+		conns:      make(chan net.Conn, 10),
+		publishes:  make(chan string, 10),
+		subscribes: make(chan []string, 10),
+		pubacks:    make(chan uint16, 10),
+	}
+	t.Cleanup(func() {
+		_ = ln.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go b.serve(conn)
+		}
+	}()
+	return b
+}
+
+func (b *fakeBroker) serve(conn net.Conn) {
+	defer conn.Close()
+
+	p, err := packets.ReadPacket(conn)
+	if err != nil {
+		return
+	}
+	if _, ok := p.(*packets.ConnectPacket); !ok {
+		return
+	}
+	if err := packets.NewControlPacket(packets.Connack).Write(conn); err != nil {
+		return
+	}
+	b.conns <- conn
+
+	for {
+		p, err := packets.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+		switch t := p.(type) {
+		case *packets.PublishPacket:
+			b.publishes <- string(t.Payload)
+		case *packets.SubscribePacket:
+			ack := packets.NewControlPacket(packets.Suback).(*packets.SubackPacket)
+			ack.MessageID = t.MessageID
+			ack.ReturnCodes = t.Qoss
+			if err := ack.Write(conn); err != nil {
+				return
+			}
+			b.subscribes <- t.Topics
+		case *packets.PubackPacket:
+			b.pubacks <- t.MessageID
+		case *packets.PingreqPacket:
+			if err := packets.NewControlPacket(packets.Pingresp).Write(conn); err != nil {
+				return
+			}
+		case *packets.DisconnectPacket:
+			return
+		}
+	}
+}
+
+func (b *fakeBroker) writer(t *testing.T, qos int) *mqttWriter {
+	t.Helper()
+
+	pConf, err := outputConfigSpec().ParseYAML(fmt.Sprintf(`
+urls: [ tcp://%v ]
+client_id: foo
+topic: bar
+qos: %v
+connect_timeout: 5s
+`, b.ln.Addr().String(), qos), nil)
+	require.NoError(t, err)
+
+	w, err := newMQTTWriterFromParsed(pConf, service.MockResources())
+	require.NoError(t, err)
+	return w
+}
+
+func (b *fakeBroker) nextConn(t *testing.T) net.Conn {
+	t.Helper()
+
+	select {
+	case conn := <-b.conns:
+		return conn
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for connection")
+	}
+	return nil
+}
+
+func (b *fakeBroker) nextPublish(t *testing.T) string {
+	t.Helper()
+
+	select {
+	case payload := <-b.publishes:
+		return payload
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for publish")
+	}
+	return ""
+}
+
+func TestMQTTOutputReconnectAfterConnectionLost(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	broker := newFakeBroker(t)
+	w := broker.writer(t, 0)
+	t.Cleanup(func() {
+		_ = w.Close(ctx)
+	})
+
+	require.NoError(t, w.Connect(ctx))
+	conn := broker.nextConn(t)
+
+	require.NoError(t, w.Write(ctx, service.NewMessage([]byte("hello"))))
+	assert.Equal(t, "hello", broker.nextPublish(t))
+
+	// Sever the connection from the broker side, the connection lost handler
+	// should clear the client.
+	require.NoError(t, conn.Close())
+	require.Eventually(t, func() bool {
+		w.connMut.RLock()
+		defer w.connMut.RUnlock()
+		return w.client == nil
+	}, time.Second*5, time.Millisecond*10)
+
+	require.ErrorIs(t, w.Write(ctx, service.NewMessage([]byte("lost"))), service.ErrNotConnected)
+
+	require.NoError(t, w.Connect(ctx))
+	_ = broker.nextConn(t)
+
+	require.NoError(t, w.Write(ctx, service.NewMessage([]byte("world"))))
+	assert.Equal(t, "world", broker.nextPublish(t))
+}
+
+func TestMQTTOutputPublishContextCancelled(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	broker := newFakeBroker(t)
+	w := broker.writer(t, 1)
+	t.Cleanup(func() {
+		_ = w.Close(ctx)
+	})
+
+	require.NoError(t, w.Connect(ctx))
+	_ = broker.nextConn(t)
+
+	// The broker never acknowledges the publish and so the write only returns
+	// once the context is cancelled.
+	writeCtx, writeDone := context.WithTimeout(ctx, time.Millisecond*100)
+	defer writeDone()
+
+	require.ErrorIs(t, w.Write(writeCtx, service.NewMessage([]byte("hello"))), context.DeadlineExceeded)
+	assert.Equal(t, "hello", broker.nextPublish(t))
+
+	// A cancelled publish does not indicate a lost connection, so the client
+	// is kept.
+	w.connMut.RLock()
+	client := w.client
+	w.connMut.RUnlock()
+	assert.NotNil(t, client)
+}
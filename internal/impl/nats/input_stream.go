@@ -86,7 +86,7 @@ func siSpec() *service.ConfigSpec {
 The NATS Streaming Server is being deprecated. Critical bug fixes and security fixes will be applied until June of 2023. NATS-enabled applications requiring persistence should use [JetStream](https://docs.nats.io/nats-concepts/jetstream).
 :::
 
-Tracking and persisting offsets through a durable name is also optional and works with or without a queue. If a durable name is not provided then subjects are consumed from the most recently published message.
+Tracking and persisting offsets through a durable name is also optional and works with or without a queue. If a durable name is not provided then subjects are consumed from the most recently published message. When a durable name is provided the position of the subscription is retained by the server, and therefore reconnecting after a connection loss or server restart resumes from the last acknowledged message.
 
 When a consumer closes its connection it unsubscribes, when all consumers of a durable queue do this the offsets are deleted. In order to avoid this you can stop the consumers from unsubscribing by setting the field `+"`unsubscribe_on_close` to `false`"+`.
 
@@ -97,6 +97,7 @@ This input adds the following metadata fields to each message:
 `+"``` text"+`
 - nats_stream_subject
 - nats_stream_sequence
+- nats_stream_timestamp_unix_nano
 `+"```"+`
 
 You can access these metadata fields using [function interpolation](/docs/configuration/interpolation#bloblang-queries).
@@ -201,8 +202,11 @@ func (n *natsStreamReader) disconnect() {
 		if n.conf.UnsubOnClose {
 			_ = n.natsSub.Unsubscribe()
 		}
+		// The streaming connection must be closed before the underlying NATS
+		// connection so that the server is notified, otherwise the client ID
+		// remains registered and reconnecting with it is rejected.
+		_ = n.stanConn.Close()
 		n.natsConn.Close()
-		n.stanConn.Close()
 
 		n.natsSub = nil
 		n.natsConn = nil
@@ -250,6 +254,7 @@ func (n *natsStreamReader) Connect(ctx context.Context) error {
 		}),
 	)
 	if err != nil {
+		natsConn.Close()
 		return err
 	}
 
@@ -287,6 +292,7 @@ func (n *natsStreamReader) Connect(ctx context.Context) error {
 		)
 	}
 	if err != nil {
+		_ = stanConn.Close()
 		natsConn.Close()
 		return err
 	}
@@ -325,6 +331,7 @@ func (n *natsStreamReader) Read(ctx context.Context) (*service.Message, service.
 	part := service.NewMessage(msg.Data)
 	part.MetaSetMut("nats_stream_subject", msg.Subject)
 	part.MetaSetMut("nats_stream_sequence", strconv.FormatUint(msg.Sequence, 10))
+	part.MetaSetMut("nats_stream_timestamp_unix_nano", strconv.FormatInt(msg.Timestamp, 10))
 
 	return part, func(rctx context.Context, res error) error {
 		if res == nil {
@@ -0,0 +1,79 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nats-io/stan.go"
+	"github.com/nats-io/stan.go/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func testNATSStreamReader(t *testing.T) *natsStreamReader {
+	t.Helper()
+
+	conf, err := siSpec().ParseYAML(`
+urls: [ nats://127.0.0.1:1 ]
+cluster_id: foo
+subject: bar
+`, nil)
+	require.NoError(t, err)
+
+	pConf, err := siConfigFromParsed(conf, service.MockResources())
+	require.NoError(t, err)
+
+	n, err := newNATSStreamReader(pConf, service.MockResources())
+	require.NoError(t, err)
+	return n
+}
+
+func TestNATSStreamReaderMetadata(t *testing.T) {
+	n := testNATSStreamReader(t)
+
+	msgChan := make(chan *stan.Msg, 1)
+	msgChan <- &stan.Msg{MsgProto: pb.MsgProto{
+		Sequence:  5,
+		Subject:   "bar",
+		Data:      []byte("hello world"),
+		Timestamp: 1674567890123456789,
+	}}
+	n.msgChan = msgChan
+
+	msg, ackFn, err := n.Read(context.Background())
+	require.NoError(t, err)
+
+	mBytes, err := msg.AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(mBytes))
+
+	for k, exp := range map[string]string{
+		"nats_stream_subject":             "bar",
+		"nats_stream_sequence":            "5",
+		"nats_stream_timestamp_unix_nano": "1674567890123456789",
+	} {
+		v, exists := msg.MetaGet(k)
+		assert.True(t, exists, k)
+		assert.Equal(t, exp, v, k)
+	}
+
+	// Rejected messages are not acknowledged so that they are redelivered.
+	require.NoError(t, ackFn(context.Background(), errors.New("nope")))
+}
+
+func TestNATSStreamReaderNotConnected(t *testing.T) {
+	n := testNATSStreamReader(t)
+
+	_, _, err := n.Read(context.Background())
+	require.ErrorIs(t, err, service.ErrNotConnected)
+
+	require.Error(t, n.Connect(context.Background()))
+
+	_, _, err = n.Read(context.Background())
+	require.ErrorIs(t, err, service.ErrNotConnected)
+
+	require.NoError(t, n.Close(context.Background()))
+}
@@ -18,7 +18,14 @@ func natsJetStreamOutputConfig() *service.ConfigSpec {
 		Categories("Services").
 		Version("3.46.0").
 		Summary("Write messages to a NATS JetStream subject.").
-		Description(connectionNameDescription() + authDescription()).
+		Description(`
+Each message is only acknowledged once the server has confirmed that it has been persisted to a stream.
+
+### Deduplication
+
+Messages can be deduplicated by the server by setting the header ` + "`Nats-Msg-Id`" + ` to a unique identifier with the field ` + "`headers`" + `. Duplicates received within the duplicate window of the stream are then dropped, which makes it safe for Benthos to retry sending messages.
+
+` + connectionNameDescription() + authDescription()).
 		Fields(connectionHeadFields()...).
 		Field(service.NewInterpolatedStringField("subject").
 			Description("A subject to write to.").
@@ -31,6 +38,9 @@ func natsJetStreamOutputConfig() *service.ConfigSpec {
 			Example(map[string]any{
 				"Content-Type": "application/json",
 				"Timestamp":    `${!meta("Timestamp")}`,
+			}).
+			Example(map[string]any{
+				"Nats-Msg-Id": `${! meta("id") }`,
 			}).Version("4.1.0")).
 		Field(service.NewMetadataFilterField("metadata").
 			Description("Determine which (if any) metadata values should be added to messages as headers.").
@@ -187,7 +197,7 @@ func (j *jetStreamOutput) Write(ctx context.Context, msg *service.Message) error
 		return nil
 	})
 
-	_, err = jCtx.PublishMsg(jsmsg)
+	_, err = jCtx.PublishMsg(jsmsg, nats.Context(ctx))
 	return err
 }
 
@@ -53,7 +53,7 @@ If the Push Gateway requires HTTP Basic Authentication it can be configured with
 				Advanced().
 				Default(false),
 			service.NewFloatListField(pmFieldHistogramBuckets).
-				Description("Timing metrics histogram buckets (in seconds). If left empty defaults to DefBuckets (https://pkg.go.dev/github.com/prometheus/client_golang/prometheus#pkg-variables). Applicable when `use_histogram_timing` is set to `true`.").
+				Description("Timing metrics histogram buckets (in seconds), which must be listed in increasing order. If left empty defaults to DefBuckets (https://pkg.go.dev/github.com/prometheus/client_golang/prometheus#pkg-variables). Applicable when `use_histogram_timing` is set to `true`.").
 				Advanced().
 				Version("3.63.0").
 				Default([]any{}),
@@ -290,6 +290,11 @@ func FromParsed(conf *service.ParsedConfig, log *service.Logger) (p *Metrics, er
 	if len(p.histogramBuckets) == 0 {
 		p.histogramBuckets = prometheus.DefBuckets
 	}
+	for i := 1; i < len(p.histogramBuckets); i++ {
+		if p.histogramBuckets[i] <= p.histogramBuckets[i-1] {
+			return nil, fmt.Errorf("histogram buckets must be in strictly increasing order: %v >= %v", p.histogramBuckets[i-1], p.histogramBuckets[i])
+		}
+	}
 
 	if quantilesParsedList, _ := conf.FieldObjectList(pmFieldSummaryQuantilesObj); len(quantilesParsedList) > 0 {
 		if p.summaryQuantiles, err = quantilesAsFloatMapFromParsed(quantilesParsedList); err != nil {
@@ -178,6 +178,29 @@ use_histogram_timing: true
 	assert.Contains(t, body, "\ntimertwo_sum{label3=\"value4\",label4=\"value5\"} 1.4e-08")
 }
 
+func TestPrometheusHistBuckets(t *testing.T) {
+	nm := promFromYAML(t, `
+use_histogram_timing: true
+histogram_buckets: [ 0.1, 1, 10 ]
+`)
+
+	nm.NewTimerCtor("timerone")().Timing(int64(time.Millisecond * 500))
+
+	body := getPage(t, nm.HandlerFunc())
+	assert.Contains(t, body, "\ntimerone_bucket{le=\"0.1\"} 0")
+	assert.Contains(t, body, "\ntimerone_bucket{le=\"1\"} 1")
+	assert.Contains(t, body, "\ntimerone_bucket{le=\"10\"} 1")
+
+	pConf, err := ConfigSpec().ParseYAML(`
+use_histogram_timing: true
+histogram_buckets: [ 1, 0.1, 10 ]
+`, nil)
+	require.NoError(t, err)
+
+	_, err = FromParsed(pConf, nil)
+	require.EqualError(t, err, "histogram buckets must be in strictly increasing order: 1 >= 0.1")
+}
+
 func TestPrometheusWithFileOutputPath(t *testing.T) {
 	fPath := t.TempDir() + "/benthos_metrics.prom"
 
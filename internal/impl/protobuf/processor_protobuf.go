@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
-	"os"
 	"path/filepath"
 
 	"github.com/benthosdev/benthos/v4/public/service"
@@ -36,6 +35,8 @@ The main functionality of this processor is to map to and from JSON documents, y
 
 Using reflection for processing protobuf messages in this way is less performant than generating and using native code. Therefore when performance is critical it is recommended that you use Benthos plugins instead for processing protobuf messages natively, you can find an example of Benthos plugins at [https://github.com/benthosdev/benthos-plugin-example](https://github.com/benthosdev/benthos-plugin-example)
 
+The import paths are parsed and the target message is resolved when the processor is created, and therefore a config that references a message that cannot be found fails at startup rather than when processing messages.
+
 ## Operators
 
 ### `+"`to_json`"+`
@@ -44,7 +45,7 @@ Converts protobuf messages into a generic JSON structure. This makes it easier t
 
 ### `+"`from_json`"+`
 
-Attempts to create a target protobuf message from a generic JSON structure.
+Attempts to create a target protobuf message from a generic JSON structure. By default documents containing fields that are unknown to the schema fail to convert, which can be changed with the field `+"[`discard_unknown`](#discard_unknown)"+`.
 `).Fields(
 		service.NewStringEnumField(fieldOperator, "to_json", "from_json").
 			Description("The [operator](#operators) to execute"),
@@ -264,7 +265,7 @@ func loadDescriptors(f fs.FS, importPaths []string) (*protoregistry.Files, *prot
 				if ferr != nil {
 					return fmt.Errorf("failed to get relative path: %v", ferr)
 				}
-				content, ferr := os.ReadFile(path)
+				content, ferr := service.ReadFile(f, path)
 				if ferr != nil {
 					return fmt.Errorf("failed to read import %v: %v", path, ferr)
 				}
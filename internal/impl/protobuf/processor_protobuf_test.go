@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -257,3 +258,43 @@ import_paths: [ %v ]
 		})
 	}
 }
+
+func TestProtobufMessageNotFound(t *testing.T) {
+	for _, operator := range []string{"to_json", "from_json"} {
+		conf, err := protobufProcessorSpec().ParseYAML(fmt.Sprintf(`
+operator: %v
+message: testing.Nope
+import_paths: [ ../../../config/test/protobuf/schema ]
+`, operator), nil)
+		require.NoError(t, err)
+
+		_, err = newProtobuf(conf, service.MockResources())
+		require.Error(t, err, operator)
+		assert.Contains(t, err.Error(), "unable to find message 'testing.Nope'", operator)
+	}
+}
+
+func TestProtobufToJSONFromFS(t *testing.T) {
+	f := fstest.MapFS{
+		"schema/thing.proto": &fstest.MapFile{
+			Data: []byte(`
+syntax = "proto3";
+package testing;
+
+message Thing {
+  string name = 1;
+}
+`),
+		},
+	}
+
+	op, err := newProtobufToJSONOperator(f, "testing.Thing", []string{"schema"}, false)
+	require.NoError(t, err)
+
+	msg := service.NewMessage([]byte{0x0a, 0x03, 0x66, 0x6f, 0x6f})
+	require.NoError(t, op(msg))
+
+	mBytes, err := msg.AsBytes()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"foo"}`, string(mBytes))
+}
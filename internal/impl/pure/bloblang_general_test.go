@@ -1,6 +1,8 @@
 package pure
 
 import (
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -73,6 +75,32 @@ root.f = "f".apply("map_wide_counter")
 	assert.Equal(t, int64(10), vObj["c"])
 }
 
+func TestBloblangCounterParallel(t *testing.T) {
+	exec, err := bloblang.Parse(`root = counter(max: 10) == 10`)
+	require.NoError(t, err)
+
+	var matches int64
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				v, err := exec.Query(nil)
+				if !assert.NoError(t, err) {
+					return
+				}
+				if v.(bool) {
+					atomic.AddInt64(&matches, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(100), matches)
+}
+
 func TestBloblangCounterBadParams(t *testing.T) {
 	exec, err := bloblang.Parse(`
 root.a = counter(min: this.min, max: this.max)
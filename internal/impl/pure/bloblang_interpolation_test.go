@@ -0,0 +1,94 @@
+package pure
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/internal/bloblang"
+	"github.com/benthosdev/benthos/v4/internal/message"
+)
+
+func TestInterpolationFunctions(t *testing.T) {
+	t.Setenv("BENTHOS_TEST_INTERP", "from env")
+
+	part := message.NewPart([]byte(`{"foo":{"bar":"baz"}}`))
+	part.MetaSetMut("key", "meta value")
+	batch := message.Batch{part, message.NewPart(nil)}
+
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+
+	tests := map[string]string{
+		`${! env("BENTHOS_TEST_INTERP") }`:       "from env",
+		`${! meta("key") }`:                      "meta value",
+		`${! json("foo.bar") }`:                  "baz",
+		`${! batch_size() }`:                     "2",
+		`${! hostname() }`:                       hostname,
+		`${! 0.ts_format("2006-01-02", "UTC") }`: "1970-01-01",
+		`${! "a" }-${{! "b" }}`:                  `a-${! "b" }`,
+	}
+
+	for expr, exp := range tests {
+		f, err := bloblang.GlobalEnvironment().NewField(expr)
+		require.NoError(t, err, expr)
+
+		act, err := f.String(0, batch)
+		require.NoError(t, err, expr)
+		assert.Equal(t, exp, act, expr)
+	}
+
+	f, err := bloblang.GlobalEnvironment().NewField(`${! uuid_v4() }`)
+	require.NoError(t, err)
+	a, err := f.String(0, batch)
+	require.NoError(t, err)
+	b, err := f.String(0, batch)
+	require.NoError(t, err)
+	assert.Len(t, a, 36)
+	assert.NotEqual(t, a, b)
+
+	f, err = bloblang.GlobalEnvironment().NewField(`${! counter() }`)
+	require.NoError(t, err)
+	for _, exp := range []string{"1", "2", "3"} {
+		act, err := f.String(0, batch)
+		require.NoError(t, err)
+		assert.Equal(t, exp, act)
+	}
+
+	_, err = bloblang.GlobalEnvironment().NewField(`${! not_a_function() }`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unrecognised function 'not_a_function'")
+}
+
+func BenchmarkInterpolationFunctions(b *testing.B) {
+	part := message.NewPart([]byte(`{"foo":{"bar":"baz"}}`))
+	part.MetaSetMut("key", "meta value")
+	batch := message.Batch{part}
+
+	for _, expr := range []string{
+		`static string`,
+		`${! meta("key") }`,
+		`${! json("foo.bar") }`,
+		`${! uuid_v4() }`,
+		`${! timestamp_unix_nano() }`,
+		`${! counter() }`,
+		`${! now().ts_format("2006-01-02") }`,
+		`foo-${! meta("key") }-${! json("foo.bar") }-${! batch_size() }`,
+	} {
+		expr := expr
+		b.Run(expr, func(b *testing.B) {
+			f, err := bloblang.GlobalEnvironment().NewField(expr)
+			require.NoError(b, err)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := f.String(0, batch); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
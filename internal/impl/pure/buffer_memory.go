@@ -38,6 +38,14 @@ This buffer is appropriate when consuming messages from inputs that do not grace
 
 This buffer has a configurable limit, where consumption will be stopped with back pressure upstream if the total size of messages in the buffer reaches this amount. Since this calculation is only an estimate, and the real size of messages in RAM is always higher, it is recommended to set the limit significantly below the amount of RAM available.
 
+## Metrics
+
+This buffer emits the gauges ` + "`buffer_backlog_bytes`" + ` and ` + "`buffer_backlog_count`" + `, which track the estimated size in bytes and the number of messages currently held in the buffer, including messages that have been read but are yet to be acknowledged.
+
+## Message Expiry
+
+When a ` + "`ttl`" + ` is configured messages that have been stored within the buffer for longer than the TTL are dropped instead of being delivered, and the counter ` + "`buffer_ttl_dropped`" + ` is incremented for each dropped message. Expiry is checked as messages are read from the buffer, including messages that are being redelivered after a rejection downstream.
+
 ## Delivery Guarantees
 
 This buffer intentionally weakens the delivery guarantees of the pipeline and therefore should never be used in places where data loss is unacceptable.
@@ -48,6 +56,11 @@ It is possible to batch up messages sent from this buffer using a [batch policy]
 		Field(service.NewIntField("limit").
 			Description(`The maximum buffer size (in bytes) to allow before applying backpressure upstream.`).
 			Default(524288000)).
+		Field(service.NewDurationField("ttl").
+			Description("An optional maximum period of time that a message may be stored within the buffer, after which it is dropped rather than delivered.").
+			Example("60s").
+			Optional().
+			Advanced()).
 		Field(service.NewInternalField(bs))
 }
 
@@ -68,6 +81,13 @@ func newMemoryBufferFromConfig(conf *service.ParsedConfig, res *service.Resource
 		return nil, err
 	}
 
+	var ttl time.Duration
+	if conf.Contains("ttl") {
+		if ttl, err = conf.FieldDuration("ttl"); err != nil {
+			return nil, err
+		}
+	}
+
 	batchingEnabled, err := conf.FieldBool("batch_policy", "enabled")
 	if err != nil {
 		return nil, err
@@ -88,7 +108,7 @@ func newMemoryBufferFromConfig(conf *service.ParsedConfig, res *service.Resource
 		}
 	}
 
-	return newMemoryBuffer(limit, batcher), nil
+	return newMemoryBuffer(limit, ttl, batcher, res), nil
 }
 
 //------------------------------------------------------------------------------
@@ -96,28 +116,48 @@ func newMemoryBufferFromConfig(conf *service.ParsedConfig, res *service.Resource
 type measuredBatch struct {
 	b    service.MessageBatch
 	size int
+	at   time.Time
 }
 
 type memoryBuffer struct {
 	batches []measuredBatch
 	bytes   int
+	count   int
 
 	cap        int
+	ttl        time.Duration
 	cond       *sync.Cond
 	endOfInput bool
 	closed     bool
 
 	batcher *service.Batcher
+	log     *service.Logger
+
+	mBacklogBytes *service.MetricGauge
+	mBacklogCount *service.MetricGauge
+	mTTLDropped   *service.MetricCounter
 }
 
-func newMemoryBuffer(capacity int, batcher *service.Batcher) *memoryBuffer {
+func newMemoryBuffer(capacity int, ttl time.Duration, batcher *service.Batcher, res *service.Resources) *memoryBuffer {
 	return &memoryBuffer{
 		cap:     capacity,
+		ttl:     ttl,
 		cond:    sync.NewCond(&sync.Mutex{}),
 		batcher: batcher,
+		log:     res.Logger(),
+
+		mBacklogBytes: res.Metrics().NewGauge("buffer_backlog_bytes"),
+		mBacklogCount: res.Metrics().NewGauge("buffer_backlog_count"),
+		mTTLDropped:   res.Metrics().NewCounter("buffer_ttl_dropped"),
 	}
 }
 
+// updateBacklog must be called with the mutex held.
+func (m *memoryBuffer) updateBacklog() {
+	m.mBacklogBytes.Set(int64(m.bytes))
+	m.mBacklogCount.Set(int64(m.count))
+}
+
 //------------------------------------------------------------------------------
 
 func (m *memoryBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
@@ -178,6 +218,20 @@ func (m *memoryBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, ser
 		}
 
 		for len(m.batches) > 0 && !batchReady {
+			if m.ttl > 0 && time.Since(m.batches[0].at) > m.ttl {
+				expired := m.batches[0]
+				m.log.Debugf("Dropping expired message batch of %v bytes", expired.size)
+				m.mTTLDropped.Incr(int64(len(expired.b)))
+				m.bytes -= expired.size
+				m.count -= len(expired.b)
+				m.updateBacklog()
+
+				m.batches[0] = measuredBatch{}
+				m.batches = m.batches[1:]
+				m.cond.Broadcast()
+				continue
+			}
+
 			outSize += m.batches[0].size
 			for _, msg := range m.batches[0].b {
 				batchReady = m.batcher.Add(msg.Copy())
@@ -213,6 +267,10 @@ func (m *memoryBuffer) ReadBatch(ctx context.Context) (service.MessageBatch, ser
 		defer m.cond.L.Unlock()
 		if err == nil {
 			m.bytes -= outSize
+			for _, b := range batchSources {
+				m.count -= len(b.b)
+			}
+			m.updateBacklog()
 		} else {
 			m.batches = append(batchSources, m.batches...)
 		}
@@ -259,8 +317,11 @@ func (m *memoryBuffer) WriteBatch(ctx context.Context, msgBatch service.MessageB
 	m.batches = append(m.batches, measuredBatch{
 		b:    msgBatch,
 		size: extraBytes,
+		at:   time.Now(),
 	})
 	m.bytes += extraBytes
+	m.count += len(msgBatch)
+	m.updateBacklog()
 
 	m.cond.Broadcast()
 	return nil
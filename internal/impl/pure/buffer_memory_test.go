@@ -14,6 +14,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
+	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/public/service"
 )
 
@@ -260,6 +262,104 @@ limit: 1000
 	assert.Equal(t, service.ErrEndOfBuffer, err)
 }
 
+func TestMemoryBacklogMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	stats := metrics.NewLocal()
+	parsedConf, err := memoryBufferConfig().ParseYAML(`
+limit: 1000
+`, nil)
+	require.NoError(t, err)
+
+	block, err := newMemoryBufferFromConfig(parsedConf, service.MockResources(func(m *mock.Manager) {
+		m.M = stats
+	}))
+	require.NoError(t, err)
+	defer block.Close(ctx)
+
+	assertBacklog := func(bytes, count int64) {
+		t.Helper()
+		counters := stats.GetCounters()
+		assert.Equal(t, bytes, counters["buffer_backlog_bytes"])
+		assert.Equal(t, count, counters["buffer_backlog_count"])
+	}
+
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("hello")),
+		service.NewMessage([]byte("world")),
+	}, func(ctx context.Context, err error) error { return nil }))
+
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("foo")),
+	}, func(ctx context.Context, err error) error { return nil }))
+
+	assertBacklog(13, 3)
+
+	m, ackFunc, err := block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 2)
+
+	// Read but unacknowledged messages are still counted
+	assertBacklog(13, 3)
+
+	require.NoError(t, ackFunc(ctx, errors.New("nope")))
+	assertBacklog(13, 3)
+
+	m, ackFunc, err = block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 2)
+	require.NoError(t, ackFunc(ctx, nil))
+
+	assertBacklog(3, 1)
+}
+
+func TestMemoryTTL(t *testing.T) {
+	ctx := context.Background()
+
+	stats := metrics.NewLocal()
+	parsedConf, err := memoryBufferConfig().ParseYAML(`
+limit: 1000
+ttl: 50ms
+`, nil)
+	require.NoError(t, err)
+
+	block, err := newMemoryBufferFromConfig(parsedConf, service.MockResources(func(m *mock.Manager) {
+		m.M = stats
+	}))
+	require.NoError(t, err)
+	defer block.Close(ctx)
+
+	for _, s := range []string{"foo", "bar"} {
+		require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+			service.NewMessage([]byte(s)),
+		}, func(ctx context.Context, err error) error { return nil }))
+	}
+
+	// Rejected messages are also subject to expiry
+	m, ackFunc, err := block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	msgEqual(t, "foo", m[0])
+	require.NoError(t, ackFunc(ctx, errors.New("nope")))
+
+	<-time.After(time.Millisecond * 100)
+
+	require.NoError(t, block.WriteBatch(ctx, service.MessageBatch{
+		service.NewMessage([]byte("baz")),
+	}, func(ctx context.Context, err error) error { return nil }))
+
+	m, ackFunc, err = block.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, m, 1)
+	msgEqual(t, "baz", m[0])
+	require.NoError(t, ackFunc(ctx, nil))
+
+	counters := stats.GetCounters()
+	assert.Equal(t, int64(2), counters["buffer_ttl_dropped"])
+	assert.Equal(t, int64(0), counters["buffer_backlog_bytes"])
+	assert.Equal(t, int64(0), counters["buffer_backlog_count"])
+}
+
 func TestMemoryCloseWithPending(t *testing.T) {
 	ctx := context.Background()
 	block := memBufFromConf(t, `
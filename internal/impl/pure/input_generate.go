@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/robfig/cron/v3"
@@ -23,6 +24,7 @@ const (
 	giFieldInterval  = "interval"
 	giFieldCount     = "count"
 	giFieldBatchSize = "batch_size"
+	giFieldThreads   = "threads"
 )
 
 func genInputSpec() *service.ConfigSpec {
@@ -50,6 +52,11 @@ func genInputSpec() *service.ConfigSpec {
 			service.NewIntField(giFieldBatchSize).
 				Description("The number of generated messages that should be accumulated into each batch flushed at the specified interval.").
 				Default(1),
+			service.NewIntField(giFieldThreads).
+				Description("The number of generators to run in parallel, each executing the mapping at the specified interval independently. When a `"+giFieldCount+"` is set it is shared across all generators. Increasing this value can help saturate downstream outputs when the mapping itself is expensive.").
+				Default(1).
+				Advanced().
+				Version("4.28.0"),
 			service.NewAutoRetryNacksToggleField(),
 		).
 		Example("Cron Scheduled Processing", "A common use case for the generate input is to trigger processors on a schedule so that the processors themselves can behave similarly to an input. The following configuration reads rows from a PostgreSQL table every 5 minutes.", `
@@ -88,21 +95,35 @@ func init() {
 	err := service.RegisterBatchInput("generate", genInputSpec(), func(conf *service.ParsedConfig, mgr *service.Resources) (service.BatchInput, error) {
 		nm := interop.UnwrapManagement(mgr)
 
-		var b input.Async
-		var err error
-		if b, err = newGenerateReaderFromParsed(conf, nm); err != nil {
+		readers, err := newGenerateReadersFromParsed(conf, nm)
+		if err != nil {
 			return nil, err
 		}
 
-		if autoRetry, _ := conf.FieldBool(service.AutoRetryNacksToggleFieldName); autoRetry {
-			b = input.NewAsyncPreserver(b)
+		autoRetry, _ := conf.FieldBool(service.AutoRetryNacksToggleFieldName)
+
+		var inputs []input.Streamed
+		for _, r := range readers {
+			var b input.Async = r
+			if autoRetry {
+				b = input.NewAsyncPreserver(b)
+			}
+
+			i, err := input.NewAsyncReader("generate", input.NewAsyncPreserver(b), nm)
+			if err != nil {
+				return nil, err
+			}
+			inputs = append(inputs, i)
+		}
+		if len(inputs) == 1 {
+			return interop.NewUnwrapInternalInput(inputs[0]), nil
 		}
 
-		i, err := input.NewAsyncReader("generate", input.NewAsyncPreserver(b), nm)
+		fanIn, err := newFanInInputBroker(inputs)
 		if err != nil {
 			return nil, err
 		}
-		return interop.NewUnwrapInternalInput(i), nil
+		return interop.NewUnwrapInternalInput(fanIn), nil
 	})
 	if err != nil {
 		panic(err)
@@ -111,10 +132,35 @@ func init() {
 
 //------------------------------------------------------------------------------
 
+// generateBudget tracks the number of messages remaining to be generated,
+// which may be shared between multiple readers.
+type generateBudget struct {
+	mut       sync.Mutex
+	remaining int
+}
+
+// take reserves up to n messages from the budget and returns the number that
+// were reserved.
+func (g *generateBudget) take(n int) int {
+	g.mut.Lock()
+	defer g.mut.Unlock()
+	if g.remaining < n {
+		n = g.remaining
+	}
+	g.remaining -= n
+	return n
+}
+
+// refund returns reserved messages that were not generated to the budget.
+func (g *generateBudget) refund(n int) {
+	g.mut.Lock()
+	g.remaining += n
+	g.mut.Unlock()
+}
+
 type generateReader struct {
-	remaining    int
+	budget       *generateBudget
 	batchSize    int
-	limited      bool
 	firstIsFree  bool
 	exec         *mapping.Executor
 	timer        *time.Ticker
@@ -179,11 +225,15 @@ func newGenerateReaderFromParsed(conf *service.ParsedConfig, mgr bundle.NewManag
 		return nil, err
 	}
 
+	var budget *generateBudget
+	if count > 0 {
+		budget = &generateBudget{remaining: count}
+	}
+
 	return &generateReader{
 		exec:         exec,
-		remaining:    count,
+		budget:       budget,
 		batchSize:    batchSize,
-		limited:      count > 0,
 		timer:        timer,
 		schedule:     schedule,
 		schedulePrev: schedulePrev,
@@ -191,6 +241,30 @@ func newGenerateReaderFromParsed(conf *service.ParsedConfig, mgr bundle.NewManag
 	}, nil
 }
 
+func newGenerateReadersFromParsed(conf *service.ParsedConfig, mgr bundle.NewManagement) ([]*generateReader, error) {
+	threads, err := conf.FieldInt(giFieldThreads)
+	if err != nil {
+		return nil, err
+	}
+	if threads < 1 {
+		return nil, fmt.Errorf("field %v must be at least 1, got %v", giFieldThreads, threads)
+	}
+
+	var readers []*generateReader
+	for i := 0; i < threads; i++ {
+		r, err := newGenerateReaderFromParsed(conf, mgr)
+		if err != nil {
+			return nil, err
+		}
+		if len(readers) > 0 {
+			// All readers draw from the same count.
+			r.budget = readers[0].budget
+		}
+		readers = append(readers, r)
+	}
+	return readers, nil
+}
+
 func parseCronExpression(cronExpression string) (*cron.Schedule, error) {
 	// If time zone is not included, set default to UTC
 	if !strings.HasPrefix(cronExpression, "TZ=") {
@@ -214,19 +288,17 @@ func (b *generateReader) Connect(ctx context.Context) error {
 // ReadBatch a new bloblang generated message.
 func (b *generateReader) ReadBatch(ctx context.Context) (message.Batch, input.AsyncAckFn, error) {
 	batchSize := b.batchSize
-	if b.limited {
-		if b.remaining <= 0 {
+	if b.budget != nil {
+		if batchSize = b.budget.take(batchSize); batchSize == 0 {
 			return nil, nil, component.ErrTypeClosed
 		}
-		if b.remaining < batchSize {
-			batchSize = b.remaining
-		}
 	}
 
 	if !b.firstIsFree && b.timer != nil {
 		select {
 		case t, open := <-b.timer.C:
 			if !open {
+				b.refund(batchSize)
 				return nil, nil, component.ErrTypeClosed
 			}
 			if b.schedule != nil {
@@ -245,6 +317,7 @@ func (b *generateReader) ReadBatch(ctx context.Context) (message.Batch, input.As
 				b.timer.Reset(duration)
 			}
 		case <-ctx.Done():
+			b.refund(batchSize)
 			return nil, nil, component.ErrTimeout
 		}
 	}
@@ -254,21 +327,26 @@ func (b *generateReader) ReadBatch(ctx context.Context) (message.Batch, input.As
 	for i := 0; i < batchSize; i++ {
 		p, err := b.exec.MapPart(0, batch)
 		if err != nil {
+			b.refund(batchSize - len(batch))
 			return nil, nil, err
 		}
 		if p != nil {
-			if b.limited {
-				b.remaining--
-			}
 			batch = append(batch, p)
 		}
 	}
+	b.refund(batchSize - len(batch))
 	if len(batch) == 0 {
 		return nil, nil, component.ErrTimeout
 	}
 	return batch, func(context.Context, error) error { return nil }, nil
 }
 
+func (b *generateReader) refund(n int) {
+	if b.budget != nil && n > 0 {
+		b.budget.refund(n)
+	}
+}
+
 // CloseAsync shuts down the bloblang reader.
 func (b *generateReader) Close(ctx context.Context) (err error) {
 	if b.timer != nil {
@@ -221,3 +221,40 @@ interval: ""
 
 	require.NoError(t, b.Close(context.Background()))
 }
+
+func TestBloblangThreadsSharedCount(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer done()
+
+	pConf, err := genInputSpec().ParseYAML(`
+mapping: 'root = "foobar"'
+interval: ""
+count: 10
+batch_size: 3
+threads: 3
+`, nil)
+	require.NoError(t, err)
+
+	readers, err := newGenerateReadersFromParsed(pConf, mock.NewManager())
+	require.NoError(t, err)
+	require.Len(t, readers, 3)
+
+	var total int
+	for _, r := range readers {
+		require.NoError(t, r.Connect(ctx))
+		m, _, err := r.ReadBatch(ctx)
+		require.NoError(t, err)
+		total += m.Len()
+	}
+	assert.Equal(t, 9, total)
+
+	m, _, err := readers[1].ReadBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, m.Len())
+
+	for _, r := range readers {
+		_, _, err = r.ReadBatch(ctx)
+		assert.EqualError(t, err, "type was closed")
+		require.NoError(t, r.Close(context.Background()))
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/Jeffail/shutdown"
 
 	"github.com/benthosdev/benthos/v4/internal/bundle"
+	"github.com/benthosdev/benthos/v4/internal/component"
 	"github.com/benthosdev/benthos/v4/internal/component/interop"
 	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/log"
@@ -21,7 +22,9 @@ func inprocInputSpec() *service.ConfigSpec {
 		Description(`
 Directly connect to an output within a Benthos process by referencing it by a chosen ID. This allows you to hook up isolated streams whilst running Benthos in ` + "[streams mode](/docs/guides/streams_mode/about)" + `, it is NOT recommended that you connect the inputs of a stream with an output of the same stream, as feedback loops can lead to deadlocks in your message flow.
 
-It is possible to connect multiple inputs to the same inproc ID, resulting in messages dispatching in a round-robin fashion to connected inputs. However, only one output can assume an inproc ID, and will replace existing outputs if a collision occurs.`).
+It is possible to connect multiple inputs to the same inproc ID, resulting in messages dispatching in a round-robin fashion to connected inputs. However, only one output can assume an inproc ID, and will replace existing outputs if a collision occurs.
+
+Acknowledgements are propagated back to the connected output. If this input is shut down whilst holding a message that has not yet been dispatched then the message is rejected, allowing the output side to retry it rather than remaining blocked.`).
 		Field(service.NewStringField("").Default(""))
 }
 
@@ -67,6 +70,9 @@ func (i *inprocInput) loop() {
 		i.shutSig.TriggerHasStopped()
 	}()
 
+	closeNowCtx, done := i.shutSig.HardStopCtx(context.Background())
+	defer done()
+
 	var inprocChan <-chan message.Transaction
 
 messageLoop:
@@ -96,6 +102,7 @@ messageLoop:
 			select {
 			case i.transactions <- t:
 			case <-i.shutSig.SoftStopChan():
+				_ = t.Ack(closeNowCtx, component.ErrTypeClosed)
 				return
 			}
 		case <-i.shutSig.SoftStopChan():
@@ -5,9 +5,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/component/testutil"
 	"github.com/benthosdev/benthos/v4/internal/manager"
+	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
 )
 
@@ -51,3 +55,40 @@ inproc: foo
 	ip.TriggerStopConsuming()
 	require.NoError(t, ip.WaitForClose(ctx))
 }
+
+func TestInprocRejectsPendingOnClose(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	t.Parallel()
+
+	pipe := make(chan message.Transaction)
+
+	mgr := mock.NewManager()
+	mgr.SetPipe("foo", pipe)
+
+	iConf, err := testutil.InputFromYAML(`
+inproc: foo
+`)
+	require.NoError(t, err)
+
+	ip, err := mgr.NewInput(iConf)
+	require.NoError(t, err)
+
+	resChan := make(chan error)
+	select {
+	case pipe <- message.NewTransaction(message.QuickBatch([][]byte{[]byte("hello world")}), resChan):
+	case <-ctx.Done():
+		t.Fatal("timed out")
+	}
+
+	ip.TriggerStopConsuming()
+
+	select {
+	case err := <-resChan:
+		assert.Equal(t, component.ErrTypeClosed, err)
+	case <-ctx.Done():
+		t.Fatal("timed out")
+	}
+	require.NoError(t, ip.WaitForClose(ctx))
+}
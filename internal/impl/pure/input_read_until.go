@@ -20,10 +20,11 @@ import (
 )
 
 const (
-	ruiFieldInput       = "input"
-	ruiFieldRestart     = "restart_input"
-	ruiFieldCheck       = "check"
-	ruiFieldIdleTimeout = "idle_timeout"
+	ruiFieldInput          = "input"
+	ruiFieldRestart        = "restart_input"
+	ruiFieldRestartOnCheck = "restart_on_check"
+	ruiFieldCheck          = "check"
+	ruiFieldIdleTimeout    = "idle_timeout"
 )
 
 func readUntilInputSpec() *service.ConfigSpec {
@@ -38,6 +39,8 @@ If the idle timeout is configured, the input will be closed if no new messages a
 
 Sometimes inputs close themselves. For example, when the `+"`file`"+` input type reaches the end of a file it will shut down. By default this type will also shut down. If you wish for the input type to be restarted every time it shuts down until the query check is met then set `+"`restart_input` to `true`."+`
 
+It is also possible to consume from the child input cyclically by setting `+"`restart_on_check` to `true`"+`, in which case once the message that passes the query check has been delivered the child input is closed and a fresh instance of it is created rather than this input closing.
+
 ### Metadata
 
 A metadata key `+"`benthos_read_until` containing the value `final`"+` is added to the first part of the message that triggers the input to stop.`).
@@ -87,6 +90,11 @@ input:
 		service.NewBoolField(ruiFieldRestart).
 			Description("Whether the input should be reopened if it closes itself before the condition has resolved to true.").
 			Default(false),
+		service.NewBoolField(ruiFieldRestartOnCheck).
+			Description("Whether the child input should be closed and reopened once the condition has resolved to true and the triggering message has been delivered, rather than closing this input.").
+			Advanced().
+			Version("4.28.0").
+			Default(false),
 	)
 }
 
@@ -105,7 +113,8 @@ func init() {
 }
 
 type readUntilInput struct {
-	restart bool
+	restart        bool
+	restartOnCheck bool
 
 	wrappedInputLocked *atomic.Pointer[input.Streamed]
 	check              *mapping.Executor
@@ -141,6 +150,11 @@ func newReadUntilInputFromParsed(conf *service.ParsedConfig, res *service.Resour
 		return nil, err
 	}
 
+	restartOnCheck, err := conf.FieldBool(ruiFieldRestartOnCheck)
+	if err != nil {
+		return nil, err
+	}
+
 	var check *mapping.Executor
 	if checkStr, _ := conf.FieldString(ruiFieldCheck); checkStr != "" {
 		if check, err = mgr.BloblEnvironment().NewMapping(checkStr); err != nil {
@@ -162,7 +176,8 @@ func newReadUntilInputFromParsed(conf *service.ParsedConfig, res *service.Resour
 	wInputLocked := &atomic.Pointer[input.Streamed]{}
 	wInputLocked.Store(&wrapped)
 	rdr := &readUntilInput{
-		restart: restart,
+		restart:        restart,
+		restartOnCheck: restartOnCheck,
 
 		wrappedCtor:        wrappedCtor,
 		wrappedInputLocked: wInputLocked,
@@ -199,7 +214,7 @@ func (r *readUntilInput) loop() {
 	restartBackoff.MaxInterval = time.Millisecond * 100
 	restartBackoff.MaxElapsedTime = 0
 
-	var open bool
+	var open, checkPassed bool
 
 	closeCtx, done := r.shutSig.SoftStopCtx(context.Background())
 	defer done()
@@ -209,7 +224,8 @@ runLoop:
 		var wrapped input.Streamed
 		wrappedP := r.wrappedInputLocked.Load()
 		if wrappedP == nil {
-			if r.restart {
+			if r.restart || checkPassed {
+				checkPassed = false
 				select {
 				case <-time.After(restartBackoff.NextBackOff()):
 				case <-r.shutSig.SoftStopChan():
@@ -287,9 +303,21 @@ runLoop:
 			if err := tran.Ack(closeCtx, res); err != nil && r.shutSig.IsSoftStopSignalled() {
 				return
 			}
-			if streamEnds {
+			if !streamEnds {
+				continue runLoop
+			}
+			if !r.restartOnCheck {
+				return
+			}
+
+			r.log.Debug("Check query passed, restarting input")
+			wrapped.TriggerStopConsuming()
+			if err := wrapped.WaitForClose(closeCtx); err != nil {
+				wrapped.TriggerCloseNow()
 				return
 			}
+			r.wrappedInputLocked.Store(nil)
+			checkPassed = true
 		case <-r.shutSig.SoftStopChan():
 			return
 		}
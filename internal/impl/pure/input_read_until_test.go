@@ -65,6 +65,9 @@ read_until:
 	t.Run("ReadUntilRetry", func(te *testing.T) {
 		testReadUntilRetry(inConfStr, te)
 	})
+	t.Run("ReadUntilRestartOnCheck", func(te *testing.T) {
+		testReadUntilRestartOnCheck(inConfStr, te)
+	})
 }
 
 func testReadUntilBasic(inConf string, t *testing.T) {
@@ -166,6 +169,40 @@ func testReadUntilRestart(inConf string, t *testing.T) {
 	require.NoError(t, in.WaitForClose(ctx))
 }
 
+func testReadUntilRestartOnCheck(inConf string, t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*5)
+	defer done()
+
+	rConf, err := testutil.InputFromYAML(inConf + `
+  check: 'content() == "bar"'
+  restart_on_check: true
+`)
+	require.NoError(t, err)
+
+	in, err := bmock.NewManager().NewInput(rConf)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		for _, expMsg := range []string{"foo", "bar"} {
+			var tran message.Transaction
+			var open bool
+			select {
+			case tran, open = <-in.TransactionChan():
+				require.True(t, open)
+			case <-time.After(time.Second):
+				t.Fatal("timed out")
+			}
+
+			require.Len(t, tran.Payload, 1)
+			assert.Equal(t, expMsg, string(tran.Payload[0].AsBytes()))
+			require.NoError(t, tran.Ack(ctx, nil))
+		}
+	}
+
+	in.TriggerStopConsuming()
+	require.NoError(t, in.WaitForClose(ctx))
+}
+
 func testReadUntilRetry(inConf string, t *testing.T) {
 	ctx, done := context.WithTimeout(context.Background(), time.Second*5)
 	defer done()
@@ -32,7 +32,14 @@ func sequenceInputSpec() *service.ConfigSpec {
 		Stable().
 		Categories("Utility").
 		Summary("Reads messages from a sequence of child inputs, starting with the first and once that input gracefully terminates starts consuming from the next, and so on.").
-		Description("This input is useful for consuming from inputs that have an explicit end but must not be consumed in parallel.").
+		Description(`
+This input is useful for consuming from inputs that have an explicit end but must not be consumed in parallel.
+
+Acknowledgements are routed back to the child input that produced each message, even after the sequence has moved on to a subsequent input.
+
+## Metrics
+
+This input emits the gauge `+"`sequence_input_index`"+`, which is set to the index of the child input currently being consumed, starting at zero.`).
 		Fields(
 			service.NewObjectField(siFieldShardedJoin,
 				// TODO: V5 Remove "full-outter" and "outter"
@@ -334,8 +341,8 @@ type sequenceInput struct {
 
 	joiner *messageJoiner
 
-	log *service.Logger
-
+	log          *service.Logger
+	mInputIndex  *service.MetricGauge
 	transactions chan message.Transaction
 
 	shutSig *shutdown.Signaller
@@ -368,6 +375,7 @@ func newSequenceInputFromParsed(conf *service.ParsedConfig, res *service.Resourc
 	rdr := &sequenceInput{
 		remaining:    targets,
 		log:          res.Logger(),
+		mInputIndex:  res.Metrics().NewGauge("sequence_input_index"),
 		transactions: make(chan message.Transaction),
 		shutSig:      shutdown.NewSignaller(),
 	}
@@ -463,6 +471,7 @@ func (r *sequenceInput) createNextTarget() (input.Streamed, bool, error) {
 	}
 	if target != nil {
 		r.log.Debugf("Initialized sequence input %v.", len(r.spent)-1)
+		r.mInputIndex.Set(int64(r.spent[len(r.spent)-1].index))
 		r.target = target
 	}
 	final := len(r.remaining) == 0
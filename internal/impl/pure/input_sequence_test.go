@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/benthosdev/benthos/v4/internal/component/input"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/testutil"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 )
@@ -490,3 +491,57 @@ sequence:
 	rdr.TriggerCloseNow()
 	assert.NoError(t, rdr.WaitForClose(ctx))
 }
+
+func TestSequenceInputIndexMetric(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Minute)
+	defer done()
+
+	t.Parallel()
+
+	iConf, err := testutil.InputFromYAML(`
+sequence:
+  inputs:
+    - generate:
+        count: 2
+        interval: ""
+        mapping: 'root = "foo"'
+    - generate:
+        count: 2
+        interval: ""
+        mapping: 'root = "bar"'
+`)
+	require.NoError(t, err)
+
+	stats := metrics.NewLocal()
+	mgr := mock.NewManager()
+	mgr.M = stats
+
+	rdr, err := mgr.NewInput(iConf)
+	require.NoError(t, err)
+
+	for _, exp := range []struct {
+		content   string
+		index     int64
+		skipIndex bool
+	}{
+		{content: "foo", index: 0},
+		{content: "foo", skipIndex: true}, // The next input may already be active
+		{content: "bar", index: 1},
+		{content: "bar", index: 1},
+	} {
+		select {
+		case tran, open := <-rdr.TransactionChan():
+			require.True(t, open)
+			assert.Equal(t, exp.content, string(tran.Payload.Get(0).AsBytes()))
+			if !exp.skipIndex {
+				assert.Equal(t, exp.index, stats.GetCounters()["sequence_input_index"])
+			}
+			require.NoError(t, tran.Ack(ctx, nil))
+		case <-ctx.Done():
+			t.Fatal(ctx.Err())
+		}
+	}
+
+	rdr.TriggerStopConsuming()
+	require.NoError(t, rdr.WaitForClose(ctx))
+}
@@ -0,0 +1,104 @@
+package pure
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/benthosdev/benthos/v4/internal/bundle"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
+	"github.com/benthosdev/benthos/v4/internal/docs"
+)
+
+const (
+	mmFieldTargets = "targets"
+)
+
+func init() {
+	err := bundle.AllMetrics.Add(newMultiMetrics, docs.ComponentSpec{
+		Name:    "multi",
+		Type:    docs.TypeMetrics,
+		Status:  docs.StatusBeta,
+		Version: "4.28.0",
+		Summary: "Sends metrics to a list of child metrics targets simultaneously.",
+		Description: `
+Every counter, gauge and timer update is forwarded to each child target. Each child is a full metrics config, and therefore may specify its own ` + "`mapping`" + `, which is applied after any mapping set on the ` + "`multi`" + ` target itself.
+
+The service HTTP endpoints ` + "`/metrics` and `/stats`" + ` are served by the first child target that exposes them, and therefore a target that is scraped (such as ` + "`prometheus`" + `) should be listed before other targets that expose an endpoint.`,
+		Examples: []docs.AnnotatedExample{
+			{
+				Title:   "Prometheus and logs",
+				Summary: "Exposes metrics for scraping by Prometheus whilst also periodically logging the value of every metric.",
+				Config: `
+metrics:
+  multi:
+    targets:
+      - prometheus: {}
+      - logger:
+          push_interval: 1m
+`,
+			},
+		},
+		Config: docs.FieldComponent().WithChildren(
+			docs.FieldMetrics(mmFieldTargets, "A list of child metrics targets to send metrics to.").Array(),
+		),
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func newMultiMetrics(conf metrics.Config, nm bundle.NewManagement) (metrics.Type, error) {
+	targetConfs, err := multiMetricsTargetConfs(nm.Environment(), conf.Plugin)
+	if err != nil {
+		return nil, err
+	}
+	if len(targetConfs) == 0 {
+		return nil, errors.New("at least one target must be specified")
+	}
+
+	var targets []metrics.Type
+	for i, tConf := range targetConfs {
+		t, err := nm.Environment().MetricsInit(tConf, nm)
+		if err != nil {
+			for _, t := range targets {
+				_ = t.Close()
+			}
+			return nil, fmt.Errorf("target %v: %w", i, err)
+		}
+		targets = append(targets, t)
+	}
+
+	m := targets[0]
+	for _, t := range targets[1:] {
+		m = metrics.Combine(m, t)
+	}
+	return m, nil
+}
+
+func multiMetricsTargetConfs(prov docs.Provider, pluginConf any) (confs []metrics.Config, err error) {
+	var targets []any
+	switch t := pluginConf.(type) {
+	case *yaml.Node:
+		for i := 0; i < len(t.Content)-1; i += 2 {
+			if t.Content[i].Value != mmFieldTargets {
+				continue
+			}
+			for _, n := range t.Content[i+1].Content {
+				targets = append(targets, n)
+			}
+		}
+	case map[string]any:
+		targets, _ = t[mmFieldTargets].([]any)
+	}
+
+	for i, v := range targets {
+		var conf metrics.Config
+		if conf, err = metrics.FromAny(prov, v); err != nil {
+			return nil, fmt.Errorf("target %v: %w", i, err)
+		}
+		confs = append(confs, conf)
+	}
+	return
+}
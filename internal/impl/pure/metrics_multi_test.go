@@ -0,0 +1,103 @@
+package pure_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/internal/bundle"
+	"github.com/benthosdev/benthos/v4/internal/component/testutil"
+	"github.com/benthosdev/benthos/v4/internal/manager/mock"
+
+	_ "github.com/benthosdev/benthos/v4/public/components/io"
+	_ "github.com/benthosdev/benthos/v4/public/components/prometheus"
+)
+
+func TestMultiMetricsTargets(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		config   string
+		contains string
+	}{
+		{
+			name: "json first",
+			config: `
+multi:
+  targets:
+    - json_api: {}
+    - prometheus: {}
+`,
+			contains: `"foo":3`,
+		},
+		{
+			name: "prometheus first",
+			config: `
+multi:
+  targets:
+    - prometheus: {}
+    - json_api: {}
+`,
+			contains: "foo 3",
+		},
+		{
+			name: "child mapping",
+			config: `
+multi:
+  targets:
+    - json_api: {}
+      mapping: 'root = "bar"'
+`,
+			contains: `"bar":3`,
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			conf, err := testutil.MetricsFromYAML(test.config)
+			require.NoError(t, err)
+
+			m, err := bundle.AllMetrics.Init(conf, mock.NewManager())
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				require.NoError(t, m.Close())
+			})
+
+			m.GetCounter("foo").Incr(3)
+
+			h := m.HandlerFunc()
+			require.NotNil(t, h)
+
+			rec := httptest.NewRecorder()
+			h(rec, httptest.NewRequest("GET", "/metrics", nil))
+			body, err := io.ReadAll(rec.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), test.contains)
+		})
+	}
+}
+
+func TestMultiMetricsTargetErrors(t *testing.T) {
+	conf, err := testutil.MetricsFromYAML(`
+multi:
+  targets:
+    - json_api: {}
+    - prometheus:
+        histogram_buckets: [ 2, 1 ]
+`)
+	require.NoError(t, err)
+
+	_, err = bundle.AllMetrics.Init(conf, mock.NewManager())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "target 1")
+
+	conf, err = testutil.MetricsFromYAML(`
+multi:
+  targets: []
+`)
+	require.NoError(t, err)
+
+	_, err = bundle.AllMetrics.Init(conf, mock.NewManager())
+	require.Error(t, err)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/benthosdev/benthos/v4/internal/component"
 	"github.com/benthosdev/benthos/v4/internal/component/interop"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/output"
 	"github.com/benthosdev/benthos/v4/internal/log"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -28,7 +29,11 @@ func dropOnOutputSpec() *service.ConfigSpec {
 		Stable().
 		Categories("Utility").
 		Summary(`Attempts to write messages to a child output and if the write fails for one of a list of configurable reasons the message is dropped (acked) instead of being reattempted (or nacked).`).
-		Description(`Regular Benthos outputs will apply back pressure when downstream services aren't accessible, and Benthos retries (or nacks) all messages that fail to be delivered. However, in some circumstances, or for certain output types, we instead might want to relax these mechanisms, which is when this output becomes useful.`).
+		Description(`Regular Benthos outputs will apply back pressure when downstream services aren't accessible, and Benthos retries (or nacks) all messages that fail to be delivered. However, in some circumstances, or for certain output types, we instead might want to relax these mechanisms, which is when this output becomes useful.
+
+## Metrics
+
+Each message dropped by this output increments the counter `+"`output_dropped`"+`. A sample of the contents of dropped messages is also logged at the `+"`DEBUG`"+` level at most once per second, truncated to the first 256 bytes and accompanied by the number of messages dropped since the previous sample.`).
 		Example(
 			"Dropping failed HTTP requests",
 			"In this example we have a fan_out broker, where we guarantee delivery to our Kafka output, but drop messages if they fail our secondary HTTP client output.",
@@ -88,7 +93,8 @@ func init() {
 		func(conf *service.ParsedConfig, mgr *service.Resources) (out service.BatchOutput, batchPolicy service.BatchPolicy, maxInFlight int, err error) {
 			maxInFlight = 1
 			var s output.Streamed
-			if s, err = newDropOnWriter(conf, interop.UnwrapManagement(mgr).Logger()); err != nil {
+			nm := interop.UnwrapManagement(mgr)
+			if s, err = newDropOnWriter(conf, nm.Logger(), nm.Metrics()); err != nil {
 				return
 			}
 			out = interop.NewUnwrapInternalOutput(s)
@@ -102,7 +108,11 @@ func init() {
 //------------------------------------------------------------------------------
 
 type dropOnWriter struct {
-	log log.Modular
+	log      log.Modular
+	mDropped metrics.StatCounter
+
+	lastDropSample     time.Time
+	droppedSinceSample int
 
 	onError        bool
 	onErrorMatches []*regexp.Regexp
@@ -115,7 +125,7 @@ type dropOnWriter struct {
 	shutSig *shutdown.Signaller
 }
 
-func newDropOnWriter(conf *service.ParsedConfig, log log.Modular) (*dropOnWriter, error) {
+func newDropOnWriter(conf *service.ParsedConfig, log log.Modular, stats metrics.Type) (*dropOnWriter, error) {
 	onError, err := conf.FieldBool(dooFieldError)
 	if err != nil {
 		return nil, err
@@ -150,6 +160,7 @@ func newDropOnWriter(conf *service.ParsedConfig, log log.Modular) (*dropOnWriter
 
 	return &dropOnWriter{
 		log:             log,
+		mDropped:        stats.GetCounter("output_dropped"),
 		wrapped:         interop.UnwrapOwnedOutput(pOut),
 		transactionsOut: make(chan message.Transaction),
 
@@ -189,6 +200,7 @@ func (d *dropOnWriter) loop() {
 		}
 
 		var res error
+		var dropped bool
 		if d.onBackpressure > 0 {
 			if !func() bool {
 				// Use a ticker here and call Stop explicitly.
@@ -228,6 +240,7 @@ func (d *dropOnWriter) loop() {
 					d.log.Warn("Message dropped due to back pressure.")
 					if d.onError {
 						res = nil
+						dropped = true
 					} else {
 						res = fmt.Errorf("experienced back pressure beyond: %v", d.onBackpressure)
 					}
@@ -254,6 +267,7 @@ func (d *dropOnWriter) loop() {
 		if res != nil && d.onError {
 			d.log.Warn("Message dropped due to: %v", res)
 			res = nil
+			dropped = true
 		}
 
 		if res != nil && len(d.onErrorMatches) > 0 {
@@ -262,17 +276,49 @@ func (d *dropOnWriter) loop() {
 				if m.MatchString(errStr) {
 					d.log.Warn("Message dropped due to error matching pattern %v: %v", i, res)
 					res = nil
+					dropped = true
 					break
 				}
 			}
 		}
 
+		if dropped {
+			d.recordDropped(ts.Payload)
+		}
+
 		if err := ts.Ack(cnCtx, res); err != nil && cnCtx.Err() != nil {
 			return
 		}
 	}
 }
 
+const (
+	dropOnLogSampleLimit    = 256
+	dropOnLogSampleInterval = time.Second
+)
+
+// recordDropped counts dropped messages and logs the contents of a sampled
+// message at most once per interval, which prevents a flood of logs when
+// messages are dropped at a high rate. This is only called from the loop
+// goroutine.
+func (d *dropOnWriter) recordDropped(b message.Batch) {
+	d.mDropped.Incr(int64(b.Len()))
+	d.droppedSinceSample += b.Len()
+
+	if b.Len() == 0 || time.Since(d.lastDropSample) < dropOnLogSampleInterval {
+		return
+	}
+
+	sample := b.Get(0).AsBytes()
+	if len(sample) > dropOnLogSampleLimit {
+		sample = sample[:dropOnLogSampleLimit]
+	}
+	d.log.Debug("Dropped %v messages since the last sample, sampled message contents: %s", d.droppedSinceSample, sample)
+
+	d.lastDropSample = time.Now()
+	d.droppedSinceSample = 0
+}
+
 func (d *dropOnWriter) Consume(ts <-chan message.Transaction) error {
 	if d.transactionsIn != nil {
 		return component.ErrAlreadyStarted
@@ -1,6 +1,7 @@
 package pure_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -15,8 +16,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/output"
 	"github.com/benthosdev/benthos/v4/internal/component/testutil"
+	"github.com/benthosdev/benthos/v4/internal/filepath/ifs"
+	"github.com/benthosdev/benthos/v4/internal/log"
 	bmock "github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
 
@@ -94,7 +98,20 @@ drop_on:
       drop_on: [ %v ]
 `, ts.URL, http.StatusForbidden)
 
-	d, err := bmock.NewManager().NewOutput(dropConf)
+	logConf := log.NewConfig()
+	logConf.LogLevel = "DEBUG"
+	logConf.Format = "logfmt"
+
+	var logBuf bytes.Buffer
+	logger, err := log.New(&logBuf, ifs.OS(), logConf)
+	require.NoError(t, err)
+
+	mgr := bmock.NewManager()
+	stats := metrics.NewLocal()
+	mgr.M = stats
+	mgr.L = logger
+
+	d, err := mgr.NewOutput(dropConf)
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		ctx, done := context.WithTimeout(context.Background(), time.Second*30)
@@ -108,20 +125,27 @@ drop_on:
 
 	require.NoError(t, d.Consume(tChan))
 
-	select {
-	case tChan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte("foobar")}), rChan):
-	case <-time.After(time.Second):
-		t.Fatal("timed out")
-	}
+	for i := 0; i < 3; i++ {
+		select {
+		case tChan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte(fmt.Sprintf("foobar%v", i))}), rChan):
+		case <-time.After(time.Second):
+			t.Fatal("timed out")
+		}
 
-	var res error
-	select {
-	case res = <-rChan:
-	case <-time.After(time.Second):
-		t.Fatal("timed out")
+		var res error
+		select {
+		case res = <-rChan:
+		case <-time.After(time.Second):
+			t.Fatal("timed out")
+		}
+		assert.NoError(t, res)
 	}
 
-	assert.NoError(t, res)
+	assert.Equal(t, int64(3), stats.GetCounters()["output_dropped"])
+
+	// Only the first dropped message is sampled within the interval.
+	assert.Equal(t, 1, strings.Count(logBuf.String(), "sampled message contents"), logBuf.String())
+	assert.Contains(t, logBuf.String(), "Dropped 1 messages since the last sample, sampled message contents: foobar0")
 }
 
 func TestDropOnBackpressureWithErrors(t *testing.T) {
@@ -9,6 +9,7 @@ import (
 	"github.com/benthosdev/benthos/v4/internal/batch"
 	"github.com/benthosdev/benthos/v4/internal/component"
 	"github.com/benthosdev/benthos/v4/internal/component/interop"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/output"
 	"github.com/benthosdev/benthos/v4/internal/message"
 	"github.com/benthosdev/benthos/v4/public/service"
@@ -45,6 +46,12 @@ output:
 
 When a given output fails the message routed to the following output will have a metadata value named `+"`fallback_error`"+` containing a string error message outlining the cause of the failure. The content of this string will depend on the particular output and can be used to enrich the message or provide information used to broker the data to an appropriate output using something like a `+"`switch`"+` output.
 
+These messages will also have a metadata value named `+"`fallback_tier`"+` containing the index of the output within the sequence that they are being routed to, where the first output has the index zero. This records which tier a message was written by, and messages written by the first output do not have this metadata.
+
+### Metrics
+
+Each time messages are routed to the following output of the sequence the counter `+"`output_fallback`"+` is incremented.
+
 ### Batching
 
 When an output within a fallback sequence uses batching, like so:
@@ -70,7 +77,7 @@ However, depending on the output and the error returned it is sometimes not poss
 			Field(service.NewOutputListField("").Default([]any{})),
 		func(conf *service.ParsedConfig, mgr *service.Resources) (out service.BatchOutput, batchPolicy service.BatchPolicy, maxInFlight int, err error) {
 			var w *fallbackBroker
-			if w, err = newFallbackFromParsed(conf); err != nil {
+			if w, err = newFallbackFromParsed(conf, interop.UnwrapManagement(mgr).Metrics()); err != nil {
 				return
 			}
 
@@ -84,7 +91,7 @@ However, depending on the output and the error returned it is sometimes not poss
 
 //------------------------------------------------------------------------------
 
-func newFallbackFromParsed(conf *service.ParsedConfig) (*fallbackBroker, error) {
+func newFallbackFromParsed(conf *service.ParsedConfig, stats metrics.Type) (*fallbackBroker, error) {
 	pOutputs, err := conf.FieldOutputList()
 	if err != nil {
 		return nil, err
@@ -102,6 +109,7 @@ func newFallbackFromParsed(conf *service.ParsedConfig) (*fallbackBroker, error)
 	if t, err = newFallbackBroker(outputs); err != nil {
 		return nil, err
 	}
+	t.mFallback = stats.GetCounter("output_fallback")
 	return t, nil
 }
 
@@ -111,6 +119,8 @@ type fallbackBroker struct {
 	outputTSChans []chan message.Transaction
 	outputs       []output.Streamed
 
+	mFallback metrics.StatCounter
+
 	shutSig *shutdown.Signaller
 }
 
@@ -118,6 +128,7 @@ func newFallbackBroker(outputs []output.Streamed) (*fallbackBroker, error) {
 	t := &fallbackBroker{
 		transactions: nil,
 		outputs:      outputs,
+		mFallback:    metrics.Noop().GetCounter("output_fallback"),
 		shutSig:      shutdown.NewSignaller(),
 	}
 	if len(outputs) == 0 {
@@ -183,12 +194,16 @@ func (t *fallbackBroker) loop() {
 		}
 
 		outSorter, outBatch := message.NewSortGroup(tran.Payload)
-		nextBatchFromErr := func(err error) message.Batch {
+		setFallbackMeta := func(p *message.Part, tier int, err error) {
+			p.MetaSetMut("fallback_error", err.Error())
+			p.MetaSetMut("fallback_tier", tier)
+		}
+		nextBatchFromErr := func(tier int, err error) message.Batch {
 			var bErr *batch.Error
 			if len(outBatch) <= 1 || !errors.As(err, &bErr) {
 				tmpBatch := outBatch.ShallowCopy()
 				for _, m := range tmpBatch {
-					m.MetaSetMut("fallback_error", err.Error())
+					setFallbackMeta(m, tier, err)
 				}
 				return tmpBatch
 			}
@@ -202,7 +217,7 @@ func (t *fallbackBroker) loop() {
 					}
 					seenIndexes[i] = struct{}{}
 					tmp := p.ShallowCopy()
-					tmp.MetaSetMut("fallback_error", err.Error())
+					setFallbackMeta(tmp, tier, err)
 					onlyErrs = append(onlyErrs, tmp)
 				}
 				return true
@@ -214,7 +229,7 @@ func (t *fallbackBroker) loop() {
 			if len(onlyErrs) == 0 {
 				tmpBatch := outBatch.ShallowCopy()
 				for _, m := range tmpBatch {
-					m.MetaSetMut("fallback_error", err.Error())
+					setFallbackMeta(m, tier, err)
 				}
 				return tmpBatch
 			}
@@ -231,8 +246,9 @@ func (t *fallbackBroker) loop() {
 				return tran.Ack(ctx, err)
 			}
 
+			t.mFallback.Incr(1)
 			select {
-			case t.outputTSChans[i] <- message.NewTransactionFunc(nextBatchFromErr(err), ackFn):
+			case t.outputTSChans[i] <- message.NewTransactionFunc(nextBatchFromErr(i, err), ackFn):
 			case <-ctx.Done():
 				return ctx.Err()
 			}
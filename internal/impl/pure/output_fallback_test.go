@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/benthosdev/benthos/v4/internal/bundle"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/output"
 	"github.com/benthosdev/benthos/v4/internal/component/testutil"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
@@ -216,6 +217,9 @@ func TestFallbackHappyishPath(t *testing.T) {
 	oTM, err := newFallbackBroker(outputs)
 	require.NoError(t, err)
 
+	stats := metrics.NewLocal()
+	oTM.mFallback = stats.GetCounter("output_fallback")
+
 	require.NoError(t, oTM.Consume(readChan))
 
 	for i := 0; i < 10; i++ {
@@ -234,6 +238,8 @@ func TestFallbackHappyishPath(t *testing.T) {
 				if !bytes.Equal(ts.Payload.Get(0).AsBytes(), content[0]) {
 					t.Errorf("Wrong content returned %s != %s", ts.Payload.Get(0).AsBytes(), content[0])
 				}
+				_, exists := ts.Payload.Get(0).MetaGetMut("fallback_tier")
+				assert.False(t, exists)
 			case <-mockOutputs[1].TChan:
 				t.Error("Received message in wrong order")
 				return
@@ -254,6 +260,7 @@ func TestFallbackHappyishPath(t *testing.T) {
 					t.Errorf("Wrong content returned %s != %s", ts.Payload.Get(0).AsBytes(), content[0])
 				}
 				assert.Equal(t, "test err", ts.Payload.Get(0).MetaGetStr("fallback_error"))
+				assert.Equal(t, "1", ts.Payload.Get(0).MetaGetStr("fallback_tier"))
 			case <-mockOutputs[0].TChan:
 				t.Error("Received message in wrong order")
 				return
@@ -278,6 +285,8 @@ func TestFallbackHappyishPath(t *testing.T) {
 		}
 	}
 
+	assert.Equal(t, int64(10), stats.GetCounters()["output_fallback"])
+
 	close(readChan)
 	require.NoError(t, oTM.WaitForClose(tCtx))
 }
@@ -325,6 +334,9 @@ func TestFallbackAllFail(t *testing.T) {
 					if !bytes.Equal(ts.Payload.Get(0).AsBytes(), content[0]) {
 						t.Errorf("Wrong content returned %s != %s", ts.Payload.Get(0).AsBytes(), content[0])
 					}
+					if j > 0 {
+						assert.Equal(t, strconv.Itoa(j), ts.Payload.Get(0).MetaGetStr("fallback_tier"))
+					}
 				case <-mockOutputs[(j+1)%3].TChan:
 					t.Errorf("Received message in wrong order: %v != %v", j%3, (j+1)%3)
 					return
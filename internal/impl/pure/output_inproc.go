@@ -2,6 +2,7 @@ package pure
 
 import (
 	"context"
+	"time"
 
 	"github.com/Jeffail/shutdown"
 
@@ -22,7 +23,9 @@ func init() {
 			Description(`
 Sends data directly to Benthos inputs by connecting to a unique ID. This allows you to hook up isolated streams whilst running Benthos in `+"[streams mode](/docs/guides/streams_mode/about)"+`, it is NOT recommended that you connect the inputs of a stream with an output of the same stream, as feedback loops can lead to deadlocks in your message flow.
 
-It is possible to connect multiple inputs to the same inproc ID, resulting in messages dispatching in a round-robin fashion to connected inputs. However, only one output can assume an inproc ID, and will replace existing outputs if a collision occurs.`).
+It is possible to connect multiple inputs to the same inproc ID, resulting in messages dispatching in a round-robin fashion to connected inputs. However, only one output can assume an inproc ID, and will replace existing outputs if a collision occurs.
+
+Messages are only acknowledged once they have been acknowledged by the stream that consumed them. When no input is connected to the ID this output applies back pressure until one arrives.`).
 			Field(service.NewStringField("").Default("")),
 		func(conf *service.ParsedConfig, res *service.Resources) (out service.BatchOutput, batchPolicy service.BatchPolicy, maxInFlight int, err error) {
 			nm := interop.UnwrapManagement(res)
@@ -91,6 +94,12 @@ func (i *inprocOutput) loop() {
 		select {
 		case i.transactionsOut <- ts:
 		case <-i.shutSig.HardStopChan():
+			// Reject the message so that it isn't left pending upstream, the
+			// hard stop has already been signalled so we give the ack a brief
+			// window of its own.
+			ackCtx, ackDone := context.WithTimeout(context.Background(), time.Second)
+			_ = ts.Ack(ackCtx, component.ErrTypeClosed)
+			ackDone()
 			return
 		}
 	}
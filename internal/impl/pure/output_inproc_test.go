@@ -65,3 +65,39 @@ func TestInproc(t *testing.T) {
 	_, err = mgr.GetPipe("foo")
 	assert.Equal(t, err, component.ErrPipeNotFound)
 }
+
+func TestInprocRejectsPendingOnCloseNow(t *testing.T) {
+	tCtx, done := context.WithTimeout(context.Background(), time.Second*5)
+	defer done()
+
+	mgr, err := manager.New(manager.NewResourceConfig())
+	require.NoError(t, err)
+
+	conf := output.NewConfig()
+	conf.Type = "inproc"
+	conf.Plugin = "foo"
+
+	ip, err := mgr.NewOutput(conf)
+	require.NoError(t, err)
+
+	tinchan := make(chan message.Transaction)
+	require.NoError(t, ip.Consume(tinchan))
+
+	// Nothing is consuming from the pipe and so this remains pending.
+	resChan := make(chan error)
+	select {
+	case tinchan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte("hello world")}), resChan):
+	case <-tCtx.Done():
+		t.Fatal("timed out")
+	}
+
+	ip.TriggerCloseNow()
+
+	select {
+	case err := <-resChan:
+		assert.Equal(t, component.ErrTypeClosed, err)
+	case <-tCtx.Done():
+		t.Fatal("timed out")
+	}
+	require.NoError(t, ip.WaitForClose(tCtx))
+}
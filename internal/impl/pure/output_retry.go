@@ -14,6 +14,7 @@ import (
 	"github.com/benthosdev/benthos/v4/internal/bundle"
 	"github.com/benthosdev/benthos/v4/internal/component"
 	"github.com/benthosdev/benthos/v4/internal/component/interop"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/output"
 	"github.com/benthosdev/benthos/v4/internal/log"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -110,7 +111,11 @@ All messages in Benthos are always retried on an output error, but this would us
 
 This output type is useful whenever we wish to avoid reprocessing a message on the event of a failed send. We might, for example, have a dedupe processor that we want to avoid reapplying to the same message more than once in the pipeline.
 
-Rather than retrying the same output you may wish to retry the send using a different output target (a dead letter queue). In which case you should instead use the ` + "[`fallback`](/docs/components/outputs/fallback)" + ` output type.`).
+Rather than retrying the same output you may wish to retry the send using a different output target (a dead letter queue). In which case you should instead use the ` + "[`fallback`](/docs/components/outputs/fallback)" + ` output type.
+
+## Metrics
+
+Each reattempted write to the child output increments the counter ` + "`output_retry`" + `, which has the label ` + "`child_type`" + ` set to the type of the child output.`).
 		Fields(CommonRetryBackOffFields(0, "500ms", "3s", "0s")...).
 		Fields(
 			service.NewOutputField(roFieldOutput).
@@ -142,24 +147,37 @@ func init() {
 // where send errors downstream are automatically caught and retried rather than
 // propagated upstream as nacks.
 func RetryOutputIndefinitely(mgr bundle.NewManagement, wrapped output.Streamed) (output.Streamed, error) {
-	return newIndefiniteRetry(mgr, nil, wrapped)
+	return newIndefiniteRetry(mgr, nil, "", wrapped)
 }
 
 func retryOutputFromConfig(conf *service.ParsedConfig, mgr bundle.NewManagement) (output.Streamed, error) {
-	pOut, err := conf.FieldOutput(dooFieldOutput)
+	v, err := conf.FieldAny(roFieldOutput)
 	if err != nil {
 		return nil, err
 	}
 
+	oConf, err := output.FromAny(mgr.Environment(), v)
+	if err != nil {
+		return nil, err
+	}
+	if oConf.Type == "" {
+		return nil, errors.New("unable to determine the type of the child output")
+	}
+
 	var boffCtor func() backoff.BackOff
 	if boffCtor, err = CommonRetryBackOffCtorFromParsed(conf); err != nil {
 		return nil, err
 	}
 
-	return newIndefiniteRetry(mgr, boffCtor, interop.UnwrapOwnedOutput(pOut))
+	child, err := mgr.IntoPath(roFieldOutput).NewOutput(oConf)
+	if err != nil {
+		return nil, err
+	}
+
+	return newIndefiniteRetry(mgr, boffCtor, oConf.Type, child)
 }
 
-func newIndefiniteRetry(mgr bundle.NewManagement, backoffCtor func() backoff.BackOff, wrapped output.Streamed) (*indefiniteRetry, error) {
+func newIndefiniteRetry(mgr bundle.NewManagement, backoffCtor func() backoff.BackOff, childType string, wrapped output.Streamed) (*indefiniteRetry, error) {
 	if backoffCtor == nil {
 		backoffCtor = func() backoff.BackOff {
 			boff := backoff.NewExponentialBackOff()
@@ -172,6 +190,7 @@ func newIndefiniteRetry(mgr bundle.NewManagement, backoffCtor func() backoff.Bac
 
 	return &indefiniteRetry{
 		log:             mgr.Logger(),
+		mRetry:          mgr.Metrics().GetCounterVec("output_retry", "child_type").With(childType),
 		wrapped:         wrapped,
 		backoffCtor:     backoffCtor,
 		transactionsOut: make(chan message.Transaction),
@@ -185,7 +204,8 @@ type indefiniteRetry struct {
 	wrapped     output.Streamed
 	backoffCtor func() backoff.BackOff
 
-	log log.Modular
+	log    log.Modular
+	mRetry metrics.StatCounter
 
 	transactionsIn  <-chan message.Transaction
 	transactionsOut chan message.Transaction
@@ -296,6 +316,7 @@ func (r *indefiniteRetry) loop() {
 
 					select {
 					case r.transactionsOut <- message.NewTransaction(ts.Payload.ShallowCopy(), resChan):
+						r.mRetry.Incr(1)
 					case <-r.shutSig.HardStopChan():
 						return
 					}
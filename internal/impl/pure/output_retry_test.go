@@ -12,6 +12,7 @@ import (
 
 	"github.com/benthosdev/benthos/v4/internal/bundle"
 	"github.com/benthosdev/benthos/v4/internal/component"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
 )
@@ -125,7 +126,11 @@ retry:
     max_interval: 10us
 `)
 
-	output, err := bundle.AllOutputs.Init(conf, mock.NewManager())
+	mgr := mock.NewManager()
+	stats := metrics.NewLocal()
+	mgr.M = stats
+
+	output, err := bundle.AllOutputs.Init(conf, mgr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -189,6 +194,8 @@ retry:
 		t.Fatal("timed out")
 	}
 
+	assert.Equal(t, int64(100), stats.GetCounters()[`output_retry{child_type="drop"}`])
+
 	output.TriggerCloseNow()
 	require.NoError(t, output.WaitForClose(ctx))
 }
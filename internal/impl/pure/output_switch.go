@@ -16,6 +16,7 @@ import (
 	"github.com/benthosdev/benthos/v4/internal/bundle"
 	"github.com/benthosdev/benthos/v4/internal/component"
 	"github.com/benthosdev/benthos/v4/internal/component/interop"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/output"
 	"github.com/benthosdev/benthos/v4/internal/log"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -36,7 +37,7 @@ func switchOutputSpec() *service.ConfigSpec {
 		Categories("Utility").
 		Stable().
 		Summary(`The switch output type allows you to route messages to different outputs based on their contents.`).
-		Description(`Messages that do not pass the check of a single output case are effectively dropped. In order to prevent this outcome set the field `+"[`strict_mode`](#strict_mode) to `true`"+`, in which case messages that do not pass at least one case are considered failed and will be nacked and/or reprocessed depending on your input.`).
+		Description(`Messages that do not pass the check of a single output case are effectively dropped. In order to prevent this outcome set the field `+"[`strict_mode`](#strict_mode) to `true`"+`, in which case messages that do not pass at least one case are considered failed and will be nacked and/or reprocessed depending on your input. Each message dropped this way increments the counter metric `+"`output_switch_dropped`"+`.`).
 		Example(
 			"Basic Multiplexing",
 			`
@@ -179,7 +180,8 @@ func init() {
 }
 
 type switchOutput struct {
-	logger log.Modular
+	logger   log.Modular
+	mDropped metrics.StatCounter
 
 	transactions <-chan message.Transaction
 
@@ -211,6 +213,7 @@ func switchOutputFromParsed(conf *service.ParsedConfig, mgr bundle.NewManagement
 
 	o := &switchOutput{
 		logger:       mgr.Logger(),
+		mDropped:     mgr.Metrics().GetCounter("output_switch_dropped"),
 		transactions: nil,
 		strictMode:   strictMode,
 		shutSig:      shutdown.NewSignaller(),
@@ -444,9 +447,12 @@ func (o *switchOutput) loop() {
 					}
 				}
 			}
-			if !routedAtLeastOnce && o.strictMode {
-				o.logger.Error("Message failed to match against at least one output check with strict mode enabled, it will be nacked and/or re-processed")
-				return ErrSwitchNoConditionMet
+			if !routedAtLeastOnce {
+				if o.strictMode {
+					o.logger.Error("Message failed to match against at least one output check with strict mode enabled, it will be nacked and/or re-processed")
+					return ErrSwitchNoConditionMet
+				}
+				o.mDropped.Incr(1)
 			}
 			return nil
 		}); checksErr != nil {
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/benthosdev/benthos/v4/internal/batch"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
 )
@@ -653,6 +654,9 @@ cases:
 `
 	s := newSwitch(t, mockOutputs, confStr)
 
+	stats := metrics.NewLocal()
+	s.mDropped = stats.GetCounter("output_switch_dropped")
+
 	readChan := make(chan message.Transaction)
 	resChan := make(chan error, 1)
 
@@ -672,6 +676,8 @@ cases:
 		t.Fatal("Timed out responding to output")
 	}
 
+	assert.Equal(t, int64(1), stats.GetCounters()["output_switch_dropped"])
+
 	s.TriggerCloseNow()
 	require.NoError(t, s.WaitForClose(ctx))
 }
@@ -262,3 +262,54 @@ format: json_array
 	require.NoError(t, err)
 	require.Empty(t, batches)
 }
+
+func TestArchiveUnarchiveRoundTrip(t *testing.T) {
+	ids := []string{"first", "second", "third"}
+
+	for _, format := range []string{"tar", "zip", "binary", "lines", "json_array"} {
+		format := format
+		t.Run(format, func(t *testing.T) {
+			aConf, err := archiveProcConfig().ParseYAML(fmt.Sprintf(`
+format: %v
+path: '${! json("id") }.json'
+`, format), nil)
+			require.NoError(t, err)
+
+			uConf, err := unarchiveProcConfig().ParseYAML(fmt.Sprintf(`
+format: %v
+`, format), nil)
+			require.NoError(t, err)
+
+			aProc, err := newArchiveFromParsed(aConf, service.MockResources())
+			require.NoError(t, err)
+
+			uProc, err := newUnarchiveFromParsed(uConf, service.MockResources())
+			require.NoError(t, err)
+
+			var msg service.MessageBatch
+			for _, id := range ids {
+				msg = append(msg, service.NewMessage([]byte(fmt.Sprintf(`{"id":%q}`, id))))
+			}
+
+			batches, err := aProc.ProcessBatch(context.Background(), msg)
+			require.NoError(t, err)
+			require.Len(t, batches, 1)
+			require.Len(t, batches[0], 1)
+
+			parts, err := uProc.Process(context.Background(), batches[0][0])
+			require.NoError(t, err)
+			require.Len(t, parts, len(ids))
+
+			for i, id := range ids {
+				pBytes, err := parts[i].AsBytes()
+				require.NoError(t, err)
+				assert.JSONEq(t, fmt.Sprintf(`{"id":%q}`, id), string(pBytes))
+
+				if format == "tar" || format == "zip" {
+					name, _ := parts[i].MetaGet("archive_filename")
+					assert.Equal(t, id+".json", name)
+				}
+			}
+		})
+	}
+}
@@ -2,10 +2,11 @@ package pure
 
 import (
 	"context"
-	"errors"
+	"fmt"
 
 	"github.com/benthosdev/benthos/v4/internal/bundle"
 	"github.com/benthosdev/benthos/v4/internal/component/interop"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/processor"
 	"github.com/benthosdev/benthos/v4/internal/log"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -17,6 +18,7 @@ const (
 	bcpFieldMinParts    = "min_parts"
 	bcpFieldMaxPartSize = "max_part_size"
 	bcpFieldMinPartSize = "min_part_size"
+	bcpFieldFlag        = "flag_violations"
 )
 
 func bcProcSpec() *service.ConfigSpec {
@@ -24,6 +26,14 @@ func bcProcSpec() *service.ConfigSpec {
 		Categories("Utility").
 		Stable().
 		Summary("Removes messages (and batches) that do not fit within certain size boundaries.").
+		Description(`
+This processor does not copy or parse message contents and is therefore cheap enough to be placed at the very beginning of a pipeline in order to protect downstream components from unexpected payloads.
+
+Each rejected batch is logged at the warn level along with the violated bound, and when `+"`flag_violations`"+` is set to `+"`true`"+` the batch is kept and each message within it is instead [marked as failed](/docs/configuration/error_handling), allowing them to be routed elsewhere.
+
+## Metrics
+
+A counter `+"`bounds_check_rejected`"+` is incremented for each batch that violates the configured bounds, regardless of whether it is dropped or flagged.`).
 		Fields(
 			service.NewIntField(bcpFieldMaxPartSize).
 				Description("The maximum size of a message to allow (in bytes)").
@@ -39,6 +49,11 @@ func bcProcSpec() *service.ConfigSpec {
 				Description("The minimum size of message batches to allow (in message count)").
 				Advanced().
 				Default(1),
+			service.NewBoolField(bcpFieldFlag).
+				Description("Whether batches that violate the bounds should be kept with each message marked as failed rather than being dropped.").
+				Version("4.28.0").
+				Advanced().
+				Default(false),
 		)
 }
 
@@ -66,8 +81,13 @@ func init() {
 				return nil, err
 			}
 
+			flagViolations, err := conf.FieldBool(bcpFieldFlag)
+			if err != nil {
+				return nil, err
+			}
+
 			mgr := interop.UnwrapManagement(res)
-			p, err := newBoundsCheck(maxParts, minParts, maxPartSize, minPartSize, mgr)
+			p, err := newBoundsCheck(maxParts, minParts, maxPartSize, minPartSize, flagViolations, mgr)
 			if err != nil {
 				return nil, err
 			}
@@ -85,54 +105,57 @@ type boundsCheck struct {
 	maxPartSize int
 	minPartSize int
 	log         log.Modular
+
+	flagViolations bool
+	mRejected      metrics.StatCounter
 }
 
 // newBoundsCheck returns a BoundsCheck processor.
-func newBoundsCheck(maxParts, minParts, maxPartSize, minPartSize int, mgr bundle.NewManagement) (processor.AutoObservedBatched, error) {
+func newBoundsCheck(maxParts, minParts, maxPartSize, minPartSize int, flagViolations bool, mgr bundle.NewManagement) (processor.AutoObservedBatched, error) {
 	return &boundsCheck{
 		maxParts:    maxParts,
 		minParts:    minParts,
 		maxPartSize: maxPartSize,
 		minPartSize: minPartSize,
 		log:         mgr.Logger(),
+
+		flagViolations: flagViolations,
+		mRejected:      mgr.Metrics().GetCounter("bounds_check_rejected"),
 	}, nil
 }
 
-func (m *boundsCheck) ProcessBatch(ctx *processor.BatchProcContext, msg message.Batch) ([]message.Batch, error) {
+func (m *boundsCheck) violation(msg message.Batch) error {
 	lParts := msg.Len()
 	if lParts < m.minParts {
-		m.log.Debug(
-			"Rejecting message due to message parts below minimum (%v): %v\n",
-			m.minParts, lParts,
-		)
-		return nil, nil
+		return fmt.Errorf("message parts below minimum (%v): %v", m.minParts, lParts)
 	} else if lParts > m.maxParts {
-		m.log.Debug(
-			"Rejecting message due to message parts exceeding limit (%v): %v\n",
-			m.maxParts, lParts,
-		)
-		return nil, nil
+		return fmt.Errorf("message parts exceeding limit (%v): %v", m.maxParts, lParts)
 	}
-
-	var reject bool
-	_ = msg.Iter(func(i int, p *message.Part) error {
-		if size := len(p.AsBytes()); size > m.maxPartSize ||
-			size < m.minPartSize {
-			m.log.Debug(
-				"Rejecting message due to message part size (%v -> %v): %v\n",
-				m.minPartSize,
-				m.maxPartSize,
-				size,
-			)
-			reject = true
-			return errors.New("exit")
+	for _, p := range msg {
+		if size := len(p.AsBytes()); size > m.maxPartSize || size < m.minPartSize {
+			return fmt.Errorf("message part size outside of bounds (%v -> %v): %v", m.minPartSize, m.maxPartSize, size)
 		}
-		return nil
-	})
-	if reject {
+	}
+	return nil
+}
+
+func (m *boundsCheck) ProcessBatch(ctx *processor.BatchProcContext, msg message.Batch) ([]message.Batch, error) {
+	err := m.violation(msg)
+	if err == nil {
+		msgs := [1]message.Batch{msg}
+		return msgs[:], nil
+	}
+
+	m.mRejected.Incr(1)
+	if !m.flagViolations {
+		m.log.Warn("Rejecting message due to %v", err)
 		return nil, nil
 	}
 
+	m.log.Warn("Flagging message due to %v", err)
+	for i := range msg {
+		ctx.OnError(err, i, nil)
+	}
 	msgs := [1]message.Batch{msg}
 	return msgs[:], nil
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/testutil"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -88,3 +89,39 @@ bounds_check:
 		assert.NoError(t, res)
 	}
 }
+
+func TestBoundsCheckFlagViolations(t *testing.T) {
+	conf, err := testutil.ProcessorFromYAML(`
+bounds_check:
+  max_part_size: 10
+  flag_violations: true
+`)
+	require.NoError(t, err)
+
+	mgr := mock.NewManager()
+	stats := metrics.NewLocal()
+	mgr.M = stats
+
+	proc, err := mgr.NewProcessor(conf)
+	require.NoError(t, err)
+
+	msgs, res := proc.ProcessBatch(context.Background(), message.QuickBatch([][]byte{
+		[]byte("hello"),
+		[]byte("hello world this exceeds max part size"),
+	}))
+	require.NoError(t, res)
+	require.Len(t, msgs, 1)
+	require.Equal(t, 2, msgs[0].Len())
+	for _, p := range msgs[0] {
+		assert.EqualError(t, p.ErrorGet(), "message part size outside of bounds (1 -> 10): 38")
+	}
+
+	msgs, res = proc.ProcessBatch(context.Background(), message.QuickBatch([][]byte{
+		[]byte("hello"),
+	}))
+	require.NoError(t, res)
+	require.Len(t, msgs, 1)
+	assert.NoError(t, msgs[0].Get(0).ErrorGet())
+
+	assert.Equal(t, int64(1), stats.GetCounters()["bounds_check_rejected"])
+}
@@ -52,6 +52,9 @@ with a 'key already exists' error, which can be detected with
 Retrieve the contents of a cached key and replace the original message payload
 with the result. If the key does not exist the action fails with an error, which
 can be detected with [processor error handling](/docs/configuration/error_handling).
+In order to store the result in a metadata field instead of the payload place the
+processor within a `+"[`branch` processor](/docs/components/processors/branch)"+`
+with a `+"`result_map`"+` such as `+"`meta cached = content().string()`"+`.
 
 ### `+"`delete`"+`
 
@@ -314,8 +317,8 @@ func (c *cacheProc) ProcessBatch(ctx *processor.BatchProcContext, msg message.Ba
 
 		var result []byte
 		var useResult bool
-		if cerr := c.mgr.AccessCache(context.Background(), c.cacheName, func(cache cache.V1) {
-			result, useResult, err = c.operator(context.Background(), cache, key, value, ttl)
+		if cerr := c.mgr.AccessCache(ctx.Context(), c.cacheName, func(cache cache.V1) {
+			result, useResult, err = c.operator(ctx.Context(), cache, key, value, ttl)
 		}); cerr != nil {
 			err = cerr
 		}
@@ -3,6 +3,7 @@ package pure_test
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -12,9 +13,11 @@ import (
 	"github.com/klauspost/compress/zlib"
 	"github.com/klauspost/pgzip"
 	"github.com/pierrec/lz4/v4"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/benthosdev/benthos/v4/internal/component/testutil"
+	"github.com/benthosdev/benthos/v4/internal/impl/pure"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
 )
@@ -314,3 +317,61 @@ compress:
 		t.Errorf("Unexpected output: %s != %s", act, exp)
 	}
 }
+
+func TestCompressDecompressRoundTrip(t *testing.T) {
+	input := [][]byte{
+		[]byte("hello world first part"),
+		bytes.Repeat([]byte("hello world second part "), 100),
+		[]byte("5"),
+	}
+
+	decompAlgs := map[string]struct{}{}
+	for _, alg := range pure.DecompressionAlgsList() {
+		decompAlgs[alg] = struct{}{}
+	}
+
+	for _, alg := range pure.CompressionAlgsList() {
+		alg := alg
+		if _, exists := decompAlgs[alg]; !exists {
+			continue
+		}
+		t.Run(alg, func(t *testing.T) {
+			compConf, err := testutil.ProcessorFromYAML(fmt.Sprintf(`
+compress:
+  algorithm: %v
+`, alg))
+			require.NoError(t, err)
+
+			decompConf, err := testutil.ProcessorFromYAML(fmt.Sprintf(`
+decompress:
+  algorithm: %v
+`, alg))
+			require.NoError(t, err)
+
+			mgr := mock.NewManager()
+			comp, err := mgr.NewProcessor(compConf)
+			require.NoError(t, err)
+			decomp, err := mgr.NewProcessor(decompConf)
+			require.NoError(t, err)
+
+			msgs, res := comp.ProcessBatch(context.Background(), message.QuickBatch(input))
+			require.NoError(t, res)
+			require.Len(t, msgs, 1)
+
+			// Append a part that can't be decompressed, which should be
+			// flagged without affecting the other parts.
+			compressed := append(msgs[0], message.NewPart([]byte("not compressed")))
+
+			msgs, res = decomp.ProcessBatch(context.Background(), compressed)
+			require.NoError(t, res)
+			require.Len(t, msgs, 1)
+			require.Equal(t, len(input)+1, msgs[0].Len())
+
+			for i, exp := range input {
+				assert.Equal(t, exp, msgs[0].Get(i).AsBytes(), i)
+				assert.NoError(t, msgs[0].Get(i).ErrorGet(), i)
+			}
+			assert.Error(t, msgs[0].Get(len(input)).ErrorGet())
+		})
+	}
+}
@@ -23,6 +23,7 @@ func init() {
 			Categories("Parsing").
 			Stable().
 			Summary(fmt.Sprintf("Decompresses messages according to the selected algorithm. Supported decompression algorithms are: %v", compAlgs)).
+			Description(`Messages that fail to decompress remain unchanged and are flagged as having failed without affecting the other messages of a batch, allowing you to use [standard processor error handling patterns](/docs/configuration/error_handling).`).
 			Fields(
 				service.NewStringEnumField(decompressPFieldAlgorithm, compAlgs...).
 					Description("The decompression algorithm to use.").
@@ -10,6 +10,7 @@ import (
 	"github.com/benthosdev/benthos/v4/internal/component"
 	"github.com/benthosdev/benthos/v4/internal/component/cache"
 	"github.com/benthosdev/benthos/v4/internal/component/interop"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/processor"
 	"github.com/benthosdev/benthos/v4/internal/log"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -40,7 +41,11 @@ This processor enacts on individual messages only, in order to perform a dedupli
 
 Performing deduplication on a stream using a distributed cache voids any at-least-once guarantees that it previously had. This is because the cache will preserve message signatures even if the message fails to leave the Benthos pipeline, which would cause message loss in the event of an outage at the output sink followed by a restart of the Benthos instance (or a server crash, etc).
 
-This problem can be mitigated by using an in-memory cache and distributing messages to horizontally scaled Benthos pipelines partitioned by the deduplication key. However, in situations where at-least-once delivery guarantees are important it is worth avoiding deduplication in favour of implement idempotent behaviour at the edge of your stream pipelines.`).
+This problem can be mitigated by using an in-memory cache and distributing messages to horizontally scaled Benthos pipelines partitioned by the deduplication key. However, in situations where at-least-once delivery guarantees are important it is worth avoiding deduplication in favour of implement idempotent behaviour at the edge of your stream pipelines.
+
+## Metrics
+
+A counter `+"`dedupe_dropped`"+` is incremented for each message dropped by this processor, including messages dropped due to cache errors when `+"`drop_on_err`"+` is enabled.`).
 		Example(
 			"Deduplicate based on Kafka key",
 			"The following configuration demonstrates a pipeline that deduplicates messages based on the Kafka key.",
@@ -107,6 +112,8 @@ type dedupeProc struct {
 	key       *field.Expression
 	mgr       bundle.NewManagement
 	cacheName string
+
+	mDropped metrics.StatCounter
 }
 
 func newDedupe(cache, keyStr string, dropOnErr bool, mgr bundle.NewManagement) (*dedupeProc, error) {
@@ -128,6 +135,7 @@ func newDedupe(cache, keyStr string, dropOnErr bool, mgr bundle.NewManagement) (
 		key:       key,
 		mgr:       mgr,
 		cacheName: cache,
+		mDropped:  mgr.Metrics().GetCounter("dedupe_dropped"),
 	}, nil
 }
 
@@ -141,20 +149,22 @@ func (d *dedupeProc) ProcessBatch(ctx *processor.BatchProcContext, batch message
 			return nil
 		}
 
-		if cerr := d.mgr.AccessCache(context.Background(), d.cacheName, func(cache cache.V1) {
-			err = cache.Add(context.Background(), key, []byte{'t'}, nil)
+		if cerr := d.mgr.AccessCache(ctx.Context(), d.cacheName, func(cache cache.V1) {
+			err = cache.Add(ctx.Context(), key, []byte{'t'}, nil)
 		}); cerr != nil {
 			err = cerr
 		}
 		if err != nil {
 			if errors.Is(err, component.ErrKeyAlreadyExists) {
 				ctx.Span(i).LogKV("event", "dropped", "type", "deduplicated")
+				d.mDropped.Incr(1)
 				return nil
 			}
 
 			d.log.Error("Cache error: %v\n", err)
 			if d.dropOnErr {
 				ctx.Span(i).LogKV("event", "dropped", "type", "deduplicated")
+				d.mDropped.Incr(1)
 				return nil
 			}
 
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/testutil"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -19,6 +20,8 @@ func TestDedupe(t *testing.T) {
 
 	mgr := mock.NewManager()
 	mgr.Caches["foocache"] = map[string]mock.CacheItem{}
+	stats := metrics.NewLocal()
+	mgr.M = stats
 
 	conf, err := testutil.ProcessorFromYAML(`
 dedupe:
@@ -55,6 +58,8 @@ dedupe:
 	require.NoError(t, err)
 	require.Len(t, msgOut, 1)
 	assert.Equal(t, 2, msgOut[0].Len())
+
+	assert.Equal(t, int64(2), stats.GetCounters()["dedupe_dropped"])
 }
 
 func TestDedupeBadCache(t *testing.T) {
@@ -18,7 +18,9 @@ func init() {
 		Description(`
 This is useful for forcing batch wide processors such as `+"[`dedupe`](/docs/components/processors/dedupe)"+` or interpolations such as the `+"`value`"+` field of the `+"`metadata`"+` processor to execute on individual message parts of a batch instead.
 
-Please note that most processors already process per message of a batch, and this processor is not needed in those cases.`).
+Please note that most processors already process per message of a batch, and this processor is not needed in those cases.
+
+The messages resulting from each individual message are added back into the batch in their original order, including any messages added by child processors such as `+"[`unarchive`](/docs/components/processors/unarchive)"+`, and messages that failed processing are flagged individually without affecting the rest of the batch.`).
 		Field(service.NewProcessorListField("").Default([]any{})),
 		func(conf *service.ParsedConfig, res *service.Resources) (service.BatchProcessor, error) {
 			mgr := interop.UnwrapManagement(res)
@@ -171,3 +171,35 @@ for_each:
 		t.Errorf("Wrong count of result msgs: %v", len(msgs))
 	}
 }
+
+func TestForEachSplitAndErrors(t *testing.T) {
+	conf, err := testutil.ProcessorFromYAML(`
+for_each:
+  - unarchive:
+      format: lines
+  - bloblang: 'root = this.value'
+`)
+	require.NoError(t, err)
+
+	proc, err := mock.NewManager().NewProcessor(conf)
+	require.NoError(t, err)
+
+	msgs, res := proc.ProcessBatch(context.Background(), message.QuickBatch([][]byte{
+		[]byte("{\"value\":\"a\"}\n{\"value\":\"b\"}"),
+		[]byte("not json"),
+		[]byte(`{"value":"c"}`),
+	}))
+	require.NoError(t, res)
+	require.Len(t, msgs, 1)
+
+	assert.Equal(t, [][]byte{
+		[]byte(`a`),
+		[]byte(`b`),
+		[]byte(`not json`),
+		[]byte(`c`),
+	}, message.GetAllBytes(msgs[0]))
+
+	for i, exp := range []bool{false, false, true, false} {
+		assert.Equal(t, exp, msgs[0][i].ErrorGet() != nil, i)
+	}
+}
@@ -36,6 +36,10 @@ func grokProcSpec() *service.ConfigSpec {
 		Description(`
 Type hints within patterns are respected, therefore with the pattern `+"`%{WORD:first},%{INT:second:int}`"+` and a payload of `+"`foo,1`"+` the resulting payload would be `+"`{\"first\":\"foo\",\"second\":1}`"+`.
 
+### Error Handling
+
+Messages that do not match any of the expressions remain unchanged and are flagged as having failed, allowing you to use [standard processor error handling patterns](/docs/configuration/error_handling) in order to route them elsewhere, such as a dead letter queue.
+
 ### Performance
 
 This processor currently uses the [Go RE2](https://golang.org/s/re2syntax) regular expression engine, which is guaranteed to run in time linear to the size of the input. However, this property often makes it less performant than PCRE based implementations of grok. For more information see [https://swtch.com/~rsc/regexp/regexp1.html](https://swtch.com/~rsc/regexp/regexp1.html).`).
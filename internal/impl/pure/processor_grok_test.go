@@ -53,6 +53,30 @@ grok:
 	}
 }
 
+func TestGrokNoMatch(t *testing.T) {
+	conf, err := testutil.ProcessorFromYAML(`
+grok:
+  expressions:
+    - "%%{WORD:first},%%{INT:second:int}"
+`)
+	require.NoError(t, err)
+
+	gSet, err := mock.NewManager().NewProcessor(conf)
+	require.NoError(t, err)
+
+	msgs, res := gSet.ProcessBatch(context.Background(), message.QuickBatch([][]byte{
+		[]byte(`foo,1`),
+		[]byte(`nope`),
+	}))
+	require.NoError(t, res)
+	require.Len(t, msgs, 1)
+	require.Equal(t, 2, msgs[0].Len())
+
+	assert.NoError(t, msgs[0].Get(0).ErrorGet())
+	assert.Equal(t, "nope", string(msgs[0].Get(1).AsBytes()))
+	assert.EqualError(t, msgs[0].Get(1).ErrorGet(), "no pattern matches found")
+}
+
 func TestGrok(t *testing.T) {
 	type gTest struct {
 		name        string
@@ -26,7 +26,9 @@ func init() {
 			Description(`
 This allows you to group messages using arbitrary fields within their content or metadata, process them individually, and send them to unique locations as per their group.
 
-The functionality of this processor depends on being applied across messages that are batched. You can find out more about batching [in this doc](/docs/configuration/batching).`).
+The functionality of this processor depends on being applied across messages that are batched. You can find out more about batching [in this doc](/docs/configuration/batching).
+
+The number of groups formed can be observed with the standard processor metric `+"`processor_batch_sent`"+`, and the average size of a group by dividing `+"`processor_sent`"+` by it.`).
 			Footnotes(`
 ## Examples
 
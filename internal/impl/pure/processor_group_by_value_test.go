@@ -5,8 +5,10 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/testutil"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -74,3 +76,33 @@ group_by_value:
 		t.Errorf("Wrong result: %s != %s", act, exp)
 	}
 }
+
+func TestGroupByValueMetrics(t *testing.T) {
+	conf, err := testutil.ProcessorFromYAML(`
+group_by_value:
+  value: ${! meta("tenant") }
+`)
+	require.NoError(t, err)
+
+	mgr := mock.NewManager()
+	stats := metrics.NewLocal()
+	mgr.M = stats
+
+	proc, err := mgr.NewProcessor(conf)
+	require.NoError(t, err)
+
+	input := message.Batch{}
+	for _, tenant := range []string{"a", "b", "a", "c", "a"} {
+		p := message.NewPart([]byte("hello"))
+		p.MetaSetMut("tenant", tenant)
+		input = append(input, p)
+	}
+
+	msgs, res := proc.ProcessBatch(context.Background(), input)
+	require.NoError(t, res)
+	require.Len(t, msgs, 3)
+
+	counters := stats.GetCounters()
+	assert.Equal(t, int64(3), counters["processor_batch_sent"])
+	assert.Equal(t, int64(5), counters["processor_sent"])
+}
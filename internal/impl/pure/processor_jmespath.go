@@ -28,6 +28,12 @@ func jmpProcSpec() *service.ConfigSpec {
 :::note Try out Bloblang
 For better performance and improved capabilities try out native Benthos mapping with the [`+"`mapping`"+` processor](/docs/components/processors/mapping).
 :::
+
+The query is compiled when the processor is created, and therefore an invalid query results in a config error.
+
+### Error Handling
+
+Messages that are not valid JSON documents, or that the query fails to execute against, remain unchanged and are flagged as having failed, allowing you to use [standard processor error handling patterns](/docs/configuration/error_handling). For example, failed messages can be removed by following this processor with a `+"[`catch`](/docs/components/processors/catch)"+` processor containing a `+"`mapping`"+` of `+"`root = deleted()`"+`.
 `).
 		Example("Mapping", `
 When receiving JSON documents of the form:
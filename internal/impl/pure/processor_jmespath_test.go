@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/Jeffail/gabs/v2"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/benthosdev/benthos/v4/internal/component/testutil"
@@ -69,6 +70,18 @@ jmespath:
 	}
 }
 
+func TestJMESPathBadQuery(t *testing.T) {
+	conf, err := testutil.ProcessorFromYAML(`
+jmespath:
+  query: 'foo.[bar'
+`)
+	require.NoError(t, err)
+
+	_, err = mock.NewManager().NewProcessor(conf)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to compile JMESPath query")
+}
+
 func TestJMESPathMutation(t *testing.T) {
 	conf, err := testutil.ProcessorFromYAML(`
 jmespath:
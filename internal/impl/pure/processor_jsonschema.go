@@ -8,6 +8,7 @@ import (
 
 	"github.com/benthosdev/benthos/v4/internal/bundle"
 	"github.com/benthosdev/benthos/v4/internal/component/interop"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/processor"
 	"github.com/benthosdev/benthos/v4/internal/filepath/ifs"
 	"github.com/benthosdev/benthos/v4/internal/log"
@@ -27,7 +28,14 @@ func jschemaProcSpec() *service.ConfigSpec {
 		Categories("Mapping").
 		Stable().
 		Summary(`Checks messages against a provided JSONSchema definition but does not change the payload under any circumstances. If a message does not match the schema it can be caught using error handling methods outlined [here](/docs/configuration/error_handling).`).
-		Description(`Please refer to the [JSON Schema website](https://json-schema.org/) for information and tutorials regarding the syntax of the schema.`).
+		Description(`
+Please refer to the [JSON Schema website](https://json-schema.org/) for information and tutorials regarding the syntax of the schema.
+
+When a schema is loaded with the field `+"`schema_path`"+` any relative `+"`$ref`"+` within it is resolved against the location of that schema document.
+
+## Metrics
+
+The counters `+"`json_schema_valid`"+` and `+"`json_schema_invalid`"+` are incremented for each message that passes or fails validation respectively, which is useful for alerting on upstream changes to the shape of data.`).
 		Footnotes(`
 ## Examples
 
@@ -110,6 +118,9 @@ func init() {
 type jsonSchemaProc struct {
 	log    log.Modular
 	schema *jsonschema.Schema
+
+	mValid   metrics.StatCounter
+	mInvalid metrics.StatCounter
 }
 
 func newJSONSchema(schemaStr, schemaPath string, mgr bundle.NewManagement) (processor.AutoObserved, error) {
@@ -136,8 +147,10 @@ func newJSONSchema(schemaStr, schemaPath string, mgr bundle.NewManagement) (proc
 	}
 
 	return &jsonSchemaProc{
-		log:    mgr.Logger(),
-		schema: schema,
+		log:      mgr.Logger(),
+		schema:   schema,
+		mValid:   mgr.Metrics().GetCounter("json_schema_valid"),
+		mInvalid: mgr.Metrics().GetCounter("json_schema_invalid"),
 	}, nil
 }
 
@@ -160,6 +173,7 @@ func (s *jsonSchemaProc) Process(ctx context.Context, part *message.Part) ([]*me
 	}
 
 	if !result.Valid() {
+		s.mInvalid.Incr(1)
 		s.log.Debug("The document is not valid")
 		var errStr string
 		for i, desc := range result.Errors() {
@@ -175,6 +189,7 @@ func (s *jsonSchemaProc) Process(ctx context.Context, part *message.Part) ([]*me
 		return nil, errors.New(errStr)
 	}
 
+	s.mValid.Incr(1)
 	s.log.Debug("The document is valid")
 	return []*message.Part{part}, nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/testutil"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -399,3 +400,48 @@ json_schema:
 		t.Error("expected error from loading bad schema")
 	}
 }
+
+func TestJSONSchemaLocalRefMetrics(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "age.json"), []byte(`{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "integer",
+  "minimum": 0
+}`), 0o777))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "person.json"), []byte(`{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "age": { "$ref": "age.json" }
+  }
+}`), 0o777))
+
+	conf, err := testutil.ProcessorFromYAML(fmt.Sprintf(`
+json_schema:
+  schema_path: file://%v
+`, filepath.Join(tmpDir, "person.json")))
+	require.NoError(t, err)
+
+	mgr := mock.NewManager()
+	stats := metrics.NewLocal()
+	mgr.M = stats
+
+	c, err := mgr.NewProcessor(conf)
+	require.NoError(t, err)
+
+	msgs, _ := c.ProcessBatch(context.Background(), message.Batch{
+		message.NewPart([]byte(`{"age":21}`)),
+		message.NewPart([]byte(`{"age":-21}`)),
+		message.NewPart([]byte(`{"age":"nope"}`)),
+	})
+	require.Len(t, msgs, 1)
+	require.Len(t, msgs[0], 3)
+
+	assert.NoError(t, msgs[0][0].ErrorGet())
+	assert.EqualError(t, msgs[0][1].ErrorGet(), "age must be greater than or equal to 0")
+	assert.Error(t, msgs[0][2].ErrorGet())
+
+	assert.Equal(t, int64(1), stats.GetCounters()["json_schema_valid"])
+	assert.Equal(t, int64(2), stats.GetCounters()["json_schema_invalid"])
+}
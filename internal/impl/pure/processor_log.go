@@ -29,7 +29,7 @@ func logProcSpec() *service.ConfigSpec {
 		Stable().
 		Summary(`Prints a log event for each message. Messages always remain unchanged. The log message can be set using function interpolations described [here](/docs/configuration/interpolation#bloblang-queries) which allows you to log the contents and metadata of messages.`).
 		Description(`
-The `+"`level`"+` field determines the log level of the printed events and can be any of the following values: TRACE, DEBUG, INFO, WARN, ERROR.
+The `+"`level`"+` field determines the log level of the printed events and can be any of the following values: TRACE, DEBUG, INFO, WARN, ERROR. The values ALL and FATAL are also accepted for parity with the [logger config](/docs/components/logger/about) and print events at the TRACE and ERROR levels respectively, and therefore never cause Benthos to exit.
 
 ### Structured Fields
 
@@ -143,25 +143,25 @@ func newLogProcessor(messageStr, levelStr, fieldsMappingStr string, depFields ma
 func (l *logProcessor) levelToLogFn(level string) (func(logger log.Modular, msg string), error) {
 	level = strings.ToUpper(level)
 	switch level {
-	case "TRACE":
+	case "TRACE", "ALL":
 		return func(logger log.Modular, msg string) {
-			logger.Trace(msg)
+			logger.Trace("%s", msg)
 		}, nil
 	case "DEBUG":
 		return func(logger log.Modular, msg string) {
-			logger.Debug(msg)
+			logger.Debug("%s", msg)
 		}, nil
 	case "INFO":
 		return func(logger log.Modular, msg string) {
-			logger.Info(msg)
+			logger.Info("%s", msg)
 		}, nil
 	case "WARN":
 		return func(logger log.Modular, msg string) {
-			logger.Warn(msg)
+			logger.Warn("%s", msg)
 		}, nil
-	case "ERROR":
+	case "ERROR", "FATAL":
 		return func(logger log.Modular, msg string) {
-			logger.Error(msg)
+			logger.Error("%s", msg)
 		}, nil
 	}
 	return nil, fmt.Errorf("log level not recognised: %v", level)
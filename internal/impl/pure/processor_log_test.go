@@ -138,6 +138,33 @@ log:
 	}
 }
 
+func TestLogLevelAliasesAndFormatting(t *testing.T) {
+	logMock := &mockLog{}
+
+	for _, level := range []string{"ALL", "FATAL"} {
+		conf, err := testutil.ProcessorFromYAML(`
+log:
+  message: '${! content() }'
+  level: ` + level + `
+`)
+		require.NoError(t, err)
+
+		mgr := mock.NewManager()
+		mgr.L = logMock
+
+		l, err := mgr.NewProcessor(conf)
+		require.NoError(t, err)
+
+		_, res := l.ProcessBatch(context.Background(), message.QuickBatch([][]byte{
+			[]byte(level + " 100%d done"),
+		}))
+		require.NoError(t, res)
+	}
+
+	assert.Equal(t, []string{"ALL 100%d done"}, logMock.traces)
+	assert.Equal(t, []string{"FATAL 100%d done"}, logMock.errors)
+}
+
 func TestLogWithFields(t *testing.T) {
 	conf, err := testutil.ProcessorFromYAML(`
 log:
@@ -33,7 +33,9 @@ func metProcSpec() *service.ConfigSpec {
 		Description(`
 This processor works by evaluating an [interpolated field `+"`value`"+`](/docs/configuration/interpolation#bloblang-queries) for each message and updating a emitted metric according to the [type](#types).
 
-Custom metrics such as these are emitted along with Benthos internal metrics, where you can customize where metrics are sent, which metric names are emitted and rename them as/when appropriate. For more information check out the [metrics docs here](/docs/components/metrics/about).`).
+Custom metrics such as these are emitted along with Benthos internal metrics, where you can customize where metrics are sent, which metric names are emitted and rename them as/when appropriate. For more information check out the [metrics docs here](/docs/components/metrics/about).
+
+When the `+"`value`"+` or a label of a message fails to interpolate, or the value cannot be parsed as a number appropriate for the metric type, the metric is not updated and the counter `+"`metric_processor_error`"+` of this processor is incremented instead. The message itself always remains unchanged.`).
 		Footnotes(`
 ## Types
 
@@ -193,6 +195,8 @@ type metricProcessor struct {
 	mGaugeVec   metrics.StatGaugeVec
 	mTimerVec   metrics.StatTimerVec
 
+	mErr metrics.StatCounter
+
 	handler func(string, int, message.Batch) error
 }
 
@@ -261,6 +265,7 @@ func newMetricProcessor(typeStr, name, valueStr string, labels map[string]string
 	}
 
 	stats := mgr.Metrics()
+	m.mErr = stats.GetCounter("metric_processor_error")
 	switch strings.ToLower(typeStr) {
 	case "counter":
 		if len(m.labels) > 0 {
@@ -399,10 +404,12 @@ func (m *metricProcessor) ProcessBatch(ctx context.Context, msg message.Batch) (
 		value, err := m.value.String(i, msg)
 		if err != nil {
 			m.log.Error("Value interpolation error: %v", err)
+			m.mErr.Incr(1)
 			return nil
 		}
 		if err := m.handler(value, i, msg); err != nil {
 			m.log.Error("Handler error: %v", err)
+			m.mErr.Incr(1)
 		}
 		return nil
 	})
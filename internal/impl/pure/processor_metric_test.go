@@ -70,7 +70,8 @@ metric:
 	}
 
 	expMetrics := map[string]int64{
-		"foo.bar": 4,
+		"foo.bar":                4,
+		"metric_processor_error": 1,
 	}
 
 	for _, i := range inputs {
@@ -122,7 +123,8 @@ metric:
 	}
 
 	expMetrics := map[string]int64{
-		"foo.bar": 8,
+		"foo.bar":                8,
+		"metric_processor_error": 4,
 	}
 
 	for _, i := range inputs {
@@ -174,7 +176,8 @@ metric:
 	}
 
 	expMetrics := map[string]int64{
-		"foo.bar": 7,
+		"foo.bar":                7,
+		"metric_processor_error": 5,
 	}
 
 	for _, i := range inputs {
@@ -240,4 +243,5 @@ metric:
 	}
 
 	assert.Equal(t, expTimingAvgs, actTimingAvgs)
+	assert.Equal(t, map[string]int64{"metric_processor_error": 5}, mockMetrics.FlushCounters())
 }
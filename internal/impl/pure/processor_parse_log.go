@@ -2,6 +2,7 @@ package pure
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -68,9 +69,13 @@ Attempts to parse a log following the [Syslog rfc3164](https://tools.ietf.org/ht
 - `+"`procid`"+` (string)
 - `+"`appname`"+` (string)
 - `+"`msgid`"+` (string)
+
+### `+"`logfmt`"+`
+
+Attempts to parse a log following the [logfmt](https://brandur.org/logfmt) convention of space separated `+"`key=value`"+` pairs, where values containing spaces are double quoted. The resulting structured document contains a string field for each key, and keys without a value are set to `+"`true`"+`. When the same key appears more than once the last value is kept.
 `).
 		Fields(
-			service.NewStringEnumField(plpFieldFormat, "syslog_rfc5424", "syslog_rfc3164").
+			service.NewStringEnumField(plpFieldFormat, "syslog_rfc5424", "syslog_rfc3164", "logfmt").
 				Description("A common log [format](#formats) to parse."),
 			service.NewBoolField(plpFieldBestEffort).
 				Description("Still returns partially parsed messages even if an error occurs.").
@@ -270,12 +275,83 @@ func parserRFC3164(bestEffort, wrfc3339 bool, year, tz string) (parserFormat, er
 	}, nil
 }
 
+func parserLogfmt(bestEffort bool) parserFormat {
+	return func(body []byte) (map[string]any, error) {
+		resMap, err := parseLogfmt(body)
+		if err != nil && !(bestEffort && len(resMap) > 0) {
+			return nil, err
+		}
+		return resMap, nil
+	}
+}
+
+// parseLogfmt parses a line of logfmt key/value pairs, returning the pairs
+// parsed up until the point at which an error occurs.
+func parseLogfmt(body []byte) (map[string]any, error) {
+	resMap := map[string]any{}
+
+	i := 0
+	for {
+		for i < len(body) && (body[i] == ' ' || body[i] == '\t') {
+			i++
+		}
+		if i >= len(body) || body[i] == '\n' || body[i] == '\r' {
+			break
+		}
+
+		keyStart := i
+		for i < len(body) && body[i] > ' ' && body[i] != '=' && body[i] != '"' {
+			i++
+		}
+		key := string(body[keyStart:i])
+		if key == "" {
+			return resMap, fmt.Errorf("expected a key at char %v", i)
+		}
+		if i >= len(body) || body[i] != '=' {
+			resMap[key] = true
+			continue
+		}
+		i++
+
+		if i < len(body) && body[i] == '"' {
+			valStart := i
+			for i++; i < len(body) && body[i] != '"'; i++ {
+				if body[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(body) {
+				return resMap, fmt.Errorf("unterminated quoted value for key %v", key)
+			}
+			i++
+			val, err := strconv.Unquote(string(body[valStart:i]))
+			if err != nil {
+				return resMap, fmt.Errorf("invalid quoted value for key %v: %w", key, err)
+			}
+			resMap[key] = val
+			continue
+		}
+
+		valStart := i
+		for i < len(body) && body[i] > ' ' {
+			i++
+		}
+		resMap[key] = string(body[valStart:i])
+	}
+	if len(resMap) == 0 {
+		return nil, errors.New("no key/value pairs found")
+	}
+	return resMap, nil
+}
+
 func getParseFormat(parser string, bestEffort, rfc3339 bool, defYear, defTZ string) (parserFormat, error) {
 	switch parser {
 	case "syslog_rfc5424":
 		return parserRFC5424(bestEffort), nil
 	case "syslog_rfc3164":
 		return parserRFC3164(bestEffort, rfc3339, defYear, defTZ)
+	case "logfmt":
+		return parserLogfmt(bestEffort), nil
 	}
 	return nil, fmt.Errorf("format not recognised: %s", parser)
 }
@@ -45,6 +45,34 @@ func TestParseLogCases(t *testing.T) {
 			input:   `<28>Dec  2 16:49:23 host app[23410]: Test`,
 			output:  fmt.Sprintf(`{"appname":"app","facility":3,"hostname":"host","message":"Test","priority":28,"procid":"23410","severity":4,"timestamp":"%v-12-02T16:49:23Z"}`, time.Now().Year()),
 		},
+		{
+			name:    "valid logfmt input, valid json output",
+			format:  "logfmt",
+			bestEff: false,
+			input:   `ts=2049-10-11T22:14:15Z level=info msg="failed to make \"a\" toast" retry count=`,
+			output:  `{"count":"","level":"info","msg":"failed to make \"a\" toast","retry":true,"ts":"2049-10-11T22:14:15Z"}`,
+		},
+		{
+			name:    "invalid logfmt input, unchanged output",
+			format:  "logfmt",
+			bestEff: false,
+			input:   `level=info msg="unterminated`,
+			output:  `level=info msg="unterminated`,
+		},
+		{
+			name:    "invalid logfmt input, best effort output",
+			format:  "logfmt",
+			bestEff: true,
+			input:   `level=info msg="unterminated`,
+			output:  `{"level":"info"}`,
+		},
+		{
+			name:    "empty logfmt input, unchanged output",
+			format:  "logfmt",
+			bestEff: true,
+			input:   `  `,
+			output:  `  `,
+		},
 	}
 
 	for _, test := range tests {
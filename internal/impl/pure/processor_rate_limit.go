@@ -79,7 +79,7 @@ func (r *rateLimitProc) Process(ctx context.Context, msg *message.Part) ([]*mess
 			err = rerr
 		}
 		if ctx.Err() != nil {
-			return nil, err
+			return nil, ctx.Err()
 		}
 		if err != nil {
 			r.mgr.Logger().Error("Failed to access rate limit: %v", err)
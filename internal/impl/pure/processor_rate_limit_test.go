@@ -168,3 +168,31 @@ rate_limit:
 		t.Error("Timed out")
 	}
 }
+
+func TestRateLimitCancelled(t *testing.T) {
+	ctx, done := context.WithCancel(context.Background())
+	rlFn := func(context.Context) (time.Duration, error) {
+		done()
+		return 0, nil
+	}
+
+	mgr := mock.NewManager()
+	mgr.RateLimits["foo"] = rlFn
+
+	conf, err := testutil.ProcessorFromYAML(`
+rate_limit:
+  resource: foo
+`)
+	require.NoError(t, err)
+
+	proc, err := mgr.NewProcessor(conf)
+	require.NoError(t, err)
+
+	output, res := proc.ProcessBatch(ctx, message.QuickBatch([][]byte{
+		[]byte(`foo`),
+	}))
+	require.NoError(t, res)
+	require.Len(t, output, 1)
+	require.Len(t, output[0], 1)
+	assert.ErrorIs(t, output[0][0].ErrorGet(), context.Canceled)
+}
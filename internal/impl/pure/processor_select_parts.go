@@ -3,7 +3,9 @@ package pure
 import (
 	"context"
 
+	"github.com/benthosdev/benthos/v4/internal/bundle"
 	"github.com/benthosdev/benthos/v4/internal/component/interop"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/processor"
 	"github.com/benthosdev/benthos/v4/internal/message"
 	"github.com/benthosdev/benthos/v4/public/service"
@@ -26,7 +28,11 @@ If none of the selected parts exist in the input batch (resulting in an empty ou
 
 Message indexes can be negative, and if so the part will be selected from the end counting backwards starting from -1. E.g. if index = -1 then the selected part will be the last part of the message, if index = -2 then the part before the last element with be selected, and so on.
 
-This processor is only applicable to [batched messages](/docs/configuration/batching).`).
+This processor is only applicable to [batched messages](/docs/configuration/batching).
+
+## Metrics
+
+A counter `+"`select_parts_dropped`"+` is incremented by the number of messages of each batch that were not selected.`).
 			Field(service.NewIntListField(spFieldParts).
 				Description(`An array of message indexes of a batch. Indexes can be negative, and if so the part will be selected from the end counting backwards starting from -1.`).
 				Default([]any{})),
@@ -36,12 +42,13 @@ This processor is only applicable to [batched messages](/docs/configuration/batc
 				return nil, err
 			}
 
-			proc, err := newSelectParts(partIndexes)
+			nm := interop.UnwrapManagement(mgr)
+			proc, err := newSelectParts(partIndexes, nm)
 			if err != nil {
 				return nil, err
 			}
 
-			return interop.NewUnwrapInternalBatchProcessor(processor.NewAutoObservedBatchedProcessor("select_parts", proc, interop.UnwrapManagement(mgr))), nil
+			return interop.NewUnwrapInternalBatchProcessor(processor.NewAutoObservedBatchedProcessor("select_parts", proc, nm)), nil
 		})
 	if err != nil {
 		panic(err)
@@ -49,12 +56,14 @@ This processor is only applicable to [batched messages](/docs/configuration/batc
 }
 
 type selectPartsProc struct {
-	parts []int
+	parts    []int
+	mDropped metrics.StatCounter
 }
 
-func newSelectParts(parts []int) (*selectPartsProc, error) {
+func newSelectParts(parts []int, mgr bundle.NewManagement) (*selectPartsProc, error) {
 	return &selectPartsProc{
-		parts: parts,
+		parts:    parts,
+		mDropped: mgr.Metrics().GetCounter("select_parts_dropped"),
 	}, nil
 }
 
@@ -62,6 +71,7 @@ func (m *selectPartsProc) ProcessBatch(ctx *processor.BatchProcContext, msg mess
 	newMsg := message.QuickBatch(nil)
 
 	lParts := msg.Len()
+	selected := make(map[int]struct{}, len(m.parts))
 	for _, index := range m.parts {
 		if index < 0 {
 			// Negative indexes count backwards from the end.
@@ -72,6 +82,10 @@ func (m *selectPartsProc) ProcessBatch(ctx *processor.BatchProcContext, msg mess
 			continue
 		}
 		newMsg = append(newMsg, msg.Get(index).ShallowCopy())
+		selected[index] = struct{}{}
+	}
+	if dropped := lParts - len(selected); dropped > 0 {
+		m.mDropped.Incr(int64(dropped))
 	}
 
 	if newMsg.Len() == 0 {
@@ -6,8 +6,10 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/testutil"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -147,3 +149,27 @@ select_parts:
 		t.Error("Expected failure with zero parts selected")
 	}
 }
+
+func TestSelectPartsDroppedMetric(t *testing.T) {
+	conf, err := testutil.ProcessorFromYAML(`
+select_parts:
+  parts: [ 0, -1, 0, 5 ]
+`)
+	require.NoError(t, err)
+
+	mgr := mock.NewManager()
+	stats := metrics.NewLocal()
+	mgr.M = stats
+
+	proc, err := mgr.NewProcessor(conf)
+	require.NoError(t, err)
+
+	msgs, res := proc.ProcessBatch(context.Background(), message.QuickBatch([][]byte{
+		[]byte("0"), []byte("1"), []byte("2"), []byte("3"),
+	}))
+	require.NoError(t, res)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, [][]byte{[]byte("0"), []byte("3"), []byte("0")}, message.GetAllBytes(msgs[0]))
+
+	assert.Equal(t, int64(2), stats.GetCounters()["select_parts_dropped"])
+}
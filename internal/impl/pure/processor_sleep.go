@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"sync"
 	"time"
 
 	"github.com/benthosdev/benthos/v4/internal/bloblang/field"
 	"github.com/benthosdev/benthos/v4/internal/bundle"
 	"github.com/benthosdev/benthos/v4/internal/component/interop"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/processor"
 	"github.com/benthosdev/benthos/v4/internal/log"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -18,6 +20,7 @@ import (
 
 const (
 	spFieldDuration = "duration"
+	spFieldJitter   = "jitter"
 )
 
 func init() {
@@ -25,16 +28,32 @@ func init() {
 		Categories("Utility").
 		Stable().
 		Summary(`Sleep for a period of time specified as a duration string for each message. This processor will interpolate functions within the `+"`duration`"+` field, you can find a list of functions [here](/docs/configuration/interpolation#bloblang-queries).`).
+		Description(`
+A sleep is interrupted when the pipeline is shut down, in which case the message is flagged as having failed.
+
+## Metrics
+
+A counter `+"`sleep_slept_ns`"+` is incremented by the total number of nanoseconds spent sleeping by this processor.`).
 		Field(service.NewInterpolatedStringField(spFieldDuration).
-			Description("The duration of time to sleep for each execution.")),
+			Description("The duration of time to sleep for each execution.")).
+		Field(service.NewFloatField(spFieldJitter).
+			Description("A fraction of the duration by which each sleep is randomly lengthened or shortened, where a value of `0.1` with a duration of `1s` results in sleeps of between `900ms` and `1.1s`. This is useful for avoiding many components waking at the same time.").
+			Advanced().
+			Default(0.0).
+			Version("4.28.0")),
 		func(conf *service.ParsedConfig, res *service.Resources) (service.BatchProcessor, error) {
 			sleepStr, err := conf.FieldString(spFieldDuration)
 			if err != nil {
 				return nil, err
 			}
 
+			jitter, err := conf.FieldFloat(spFieldJitter)
+			if err != nil {
+				return nil, err
+			}
+
 			mgr := interop.UnwrapManagement(res)
-			p, err := newSleep(sleepStr, mgr)
+			p, err := newSleep(sleepStr, jitter, mgr)
 			if err != nil {
 				return nil, err
 			}
@@ -49,18 +68,25 @@ type sleepProc struct {
 	closeOnce   sync.Once
 	closeChan   chan struct{}
 	durationStr *field.Expression
+	jitter      float64
 	log         log.Modular
+	mSlept      metrics.StatCounter
 }
 
-func newSleep(sleepStr string, mgr bundle.NewManagement) (*sleepProc, error) {
+func newSleep(sleepStr string, jitter float64, mgr bundle.NewManagement) (*sleepProc, error) {
 	durationStr, err := mgr.BloblEnvironment().NewField(sleepStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse duration expression: %v", err)
 	}
+	if jitter < 0 || jitter > 1 {
+		return nil, fmt.Errorf("jitter must be between 0 and 1, got %v", jitter)
+	}
 	t := &sleepProc{
 		closeChan:   make(chan struct{}),
 		durationStr: durationStr,
+		jitter:      jitter,
 		log:         mgr.Logger(),
+		mSlept:      mgr.Metrics().GetCounter("sleep_slept_ns"),
 	}
 	return t, nil
 }
@@ -79,12 +105,21 @@ func (s *sleepProc) ProcessBatch(ctx *processor.BatchProcContext, msg message.Ba
 			continue
 		}
 
+		if s.jitter > 0 {
+			period += time.Duration((rand.Float64()*2 - 1) * s.jitter * float64(period))
+		}
+
+		tStarted := time.Now()
 		select {
 		case <-time.After(period):
 		case <-ctx.Context().Done():
-			return nil, ctx.Context().Err()
+			err = ctx.Context().Err()
 		case <-s.closeChan:
-			return nil, errors.New("processor stopped")
+			err = errors.New("processor stopped")
+		}
+		s.mSlept.Incr(time.Since(tStarted).Nanoseconds())
+		if err != nil {
+			return nil, err
 		}
 	}
 	return []message.Batch{msg}, nil
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/testutil"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -109,3 +110,41 @@ sleep:
 		t.Errorf("Message didn't take long enough")
 	}
 }
+
+func TestSleepJitterAndMetrics(t *testing.T) {
+	conf, err := testutil.ProcessorFromYAML(`
+sleep:
+  duration: 100ms
+  jitter: 0.5
+`)
+	require.NoError(t, err)
+
+	mgr := mock.NewManager()
+	stats := metrics.NewLocal()
+	mgr.M = stats
+
+	slp, err := mgr.NewProcessor(conf)
+	require.NoError(t, err)
+
+	tBefore := time.Now()
+	batches, err := slp.ProcessBatch(context.Background(), message.QuickBatch([][]byte{[]byte("hello world")}))
+	dur := time.Since(tBefore)
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+
+	assert.GreaterOrEqual(t, dur, time.Millisecond*50)
+	assert.GreaterOrEqual(t, stats.GetCounters()["sleep_slept_ns"], (time.Millisecond * 50).Nanoseconds())
+	assert.LessOrEqual(t, stats.GetCounters()["sleep_slept_ns"], dur.Nanoseconds())
+}
+
+func TestSleepBadJitter(t *testing.T) {
+	conf, err := testutil.ProcessorFromYAML(`
+sleep:
+  duration: 100ms
+  jitter: 1.5
+`)
+	require.NoError(t, err)
+
+	_, err = mock.NewManager().NewProcessor(conf)
+	require.Error(t, err)
+}
@@ -53,6 +53,24 @@ pipeline:
                 name: GeorgesAnger
                 value: ${! json("user.anger") }
             - mapping: root = deleted()
+`,
+		).
+		Example("If Else", `
+A case without a check always passes, and therefore placing one at the end of a switch behaves as an else branch for messages that did not match any prior case. Switch processors can also be nested in order to express more complex conditions.
+
+Here we parse JSON documents with a `+"`jmespath`"+` query, and any messages that are not JSON are instead parsed with `+"`grok`"+`.`,
+			`
+pipeline:
+  processors:
+    - switch:
+        - check: this.catch(null).type() == "object"
+          processors:
+            - jmespath:
+                query: '{ user: user.name, action: action }'
+
+        - processors:
+            - grok:
+                expressions: [ '%{WORD:user} %{WORD:action}' ]
 `,
 		).
 		Field(service.NewObjectListField("",
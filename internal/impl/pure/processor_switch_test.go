@@ -313,3 +313,43 @@ func BenchmarkSortReverse(b *testing.B) {
 
 	pure.SwitchReorderFromGroup(group, unsortedParts)
 }
+
+func TestSwitchNestedElse(t *testing.T) {
+	conf, err := testutil.ProcessorFromYAML(`
+switch:
+  - check: 'this.catch(null).type() == "object"'
+    processors:
+      - switch:
+          - check: 'this.type == "buy"'
+            processors:
+              - bloblang: 'root = "json buy"'
+          - processors:
+              - bloblang: 'root = "json other"'
+  - processors:
+      - bloblang: 'root = "not json: " + content().string()'
+`)
+	require.NoError(t, err)
+
+	c, err := mock.NewManager().NewProcessor(conf)
+	require.NoError(t, err)
+
+	defer func() {
+		ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+		defer done()
+		assert.NoError(t, c.Close(ctx))
+	}()
+
+	msgs, res := c.ProcessBatch(context.Background(), message.Batch{
+		message.NewPart([]byte(`{"type":"buy"}`)),
+		message.NewPart([]byte(`foo`)),
+		message.NewPart([]byte(`{"type":"sell"}`)),
+	})
+	require.NoError(t, res)
+	require.Len(t, msgs, 1)
+
+	resStrs := []string{}
+	for _, b := range message.GetAllBytes(msgs[0]) {
+		resStrs = append(resStrs, string(b))
+	}
+	assert.Equal(t, []string{"json buy", "not json: foo", "json other"}, resStrs)
+}
@@ -35,7 +35,7 @@ If the processor `+"`bar`"+` fails for a particular message, that message will s
 
 This processor is useful for when child processors depend on the successful output of previous processors. This processor can be followed with a `+"[catch](/docs/components/processors/catch)"+` processor for defining child processors to be applied only to failed messages.
 
-More information about error handing can be found [here](/docs/configuration/error_handling).
+Messages that remain flagged as failed at the end of the pipeline can be routed to a dead-letter queue by checking `+"`errored()`"+` within a `+"[`switch` output](/docs/components/outputs/switch)"+`. More information about error handing can be found [here](/docs/configuration/error_handling).
 
 ### Nesting within a catch block
 
@@ -215,3 +215,34 @@ try:
 		t.Errorf("Wrong count of result msgs: %v", len(msgs))
 	}
 }
+
+func TestTryCatchRecover(t *testing.T) {
+	conf, err := testutil.ProcessorFromYAML(`
+for_each:
+  - try:
+      - bloblang: 'root = this.value'
+      - bloblang: 'root = "parsed " + content().string()'
+  - catch:
+      - bloblang: 'root = "recovered " + content().string()'
+  - bloblang: 'root = content().uppercase()'
+`)
+	require.NoError(t, err)
+
+	proc, err := mock.NewManager().NewProcessor(conf)
+	require.NoError(t, err)
+
+	msgs, res := proc.ProcessBatch(context.Background(), message.QuickBatch([][]byte{
+		[]byte(`{"value":"foo"}`),
+		[]byte(`not json`),
+	}))
+	require.NoError(t, res)
+	require.Len(t, msgs, 1)
+
+	assert.Equal(t, [][]byte{
+		[]byte("PARSED FOO"),
+		[]byte("RECOVERED NOT JSON"),
+	}, message.GetAllBytes(msgs[0]))
+	for _, p := range msgs[0] {
+		assert.NoError(t, p.ErrorGet())
+	}
+}
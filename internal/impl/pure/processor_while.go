@@ -12,6 +12,7 @@ import (
 	"github.com/benthosdev/benthos/v4/internal/bundle"
 	"github.com/benthosdev/benthos/v4/internal/component"
 	"github.com/benthosdev/benthos/v4/internal/component/interop"
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/processor"
 	"github.com/benthosdev/benthos/v4/internal/log"
 	"github.com/benthosdev/benthos/v4/internal/message"
@@ -37,7 +38,11 @@ The field `+"`max_loops`"+`, if greater than zero, caps the number of loops for
 
 If following a loop execution the number of messages in a batch is reduced to zero the loop is exited regardless of the condition result. If following a loop execution there are more than 1 message batches the query is checked against the first batch only.
 
-The conditions of this processor are applied across entire message batches. You can find out more about batching [in this doc](/docs/configuration/batching).`).
+The conditions of this processor are applied across entire message batches. You can find out more about batching [in this doc](/docs/configuration/batching).
+
+## Metrics
+
+A counter `+"`while_loops`"+` is incremented for each execution of the child processors, and a counter `+"`while_max_loops_reached`"+` is incremented each time a loop is exited because it reached the limit set by `+"`max_loops`"+`.`).
 		Fields(
 
 			service.NewBoolField(wpFieldAtLeastOnce).
@@ -104,6 +109,9 @@ type whileProc struct {
 	children    []processor.V1
 	log         log.Modular
 
+	mLoops           metrics.StatCounter
+	mMaxLoopsReached metrics.StatCounter
+
 	shutSig *shutdown.Signaller
 }
 
@@ -125,7 +133,11 @@ func newWhile(maxLoops int, atLeastOnce bool, checkStr string, children []proces
 		check:       check,
 		children:    children,
 		log:         mgr.Logger(),
-		shutSig:     shutdown.NewSignaller(),
+
+		mLoops:           mgr.Metrics().GetCounter("while_loops"),
+		mMaxLoopsReached: mgr.Metrics().GetCounter("while_max_loops_reached"),
+
+		shutSig: shutdown.NewSignaller(),
 	}, nil
 }
 
@@ -149,10 +161,12 @@ func (w *whileProc) ProcessBatch(ctx *processor.BatchProcContext, msg message.Ba
 		}
 		if w.maxLoops > 0 && loops >= w.maxLoops {
 			w.log.Trace("Reached max loops count")
+			w.mMaxLoopsReached.Incr(1)
 			break
 		}
 
 		w.log.Trace("Looped")
+		w.mLoops.Incr(1)
 		for i := range msg {
 			ctx.Span(i).LogKV("event", "loop")
 		}
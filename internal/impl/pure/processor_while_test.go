@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/benthosdev/benthos/v4/internal/component/metrics"
 	"github.com/benthosdev/benthos/v4/internal/component/processor"
 	"github.com/benthosdev/benthos/v4/internal/component/testutil"
 	"github.com/benthosdev/benthos/v4/internal/manager/mock"
@@ -150,6 +151,42 @@ while:
 	}
 }
 
+func TestWhileMetrics(t *testing.T) {
+	conf, err := testutil.ProcessorFromYAML(`
+while:
+  check: 'batch_size() < 10'
+  max_loops: 3
+  processors:
+    - insert_part:
+        content: foo
+        index: 0
+`)
+	require.NoError(t, err)
+
+	mgr := mock.NewManager()
+	stats := metrics.NewLocal()
+	mgr.M = stats
+
+	c, err := mgr.NewProcessor(conf)
+	require.NoError(t, err)
+
+	msgs, res := c.ProcessBatch(context.Background(), message.QuickBatch([][]byte{[]byte("bar")}))
+	require.NoError(t, res)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, 4, msgs[0].Len())
+
+	msgs, res = c.ProcessBatch(context.Background(), message.QuickBatch([][]byte{
+		[]byte("a"), []byte("b"), []byte("c"), []byte("d"), []byte("e"),
+		[]byte("f"), []byte("g"), []byte("h"), []byte("i"),
+	}))
+	require.NoError(t, res)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, 10, msgs[0].Len())
+
+	assert.Equal(t, int64(4), stats.GetCounters()["while_loops"])
+	assert.Equal(t, int64(1), stats.GetCounters()["while_max_loops_reached"])
+}
+
 func TestWhileWithStaticTrue(t *testing.T) {
 	conf, err := testutil.ProcessorFromYAML(`
 while:
@@ -22,11 +22,30 @@ func redisListInputConfig() *service.ConfigSpec {
 	return service.NewConfigSpec().
 		Stable().
 		Summary(`Pops messages from the beginning of a Redis list using the BLPop command.`).
+		Description(`
+When multiple keys are specified the first non-empty list, in the order that the keys are specified, is popped from.
+
+### Metadata
+
+This input adds the following metadata fields to each message:
+
+`+"```text"+`
+- redis_key
+`+"```"+`
+
+You can access these metadata fields using [function interpolation](/docs/configuration/interpolation#bloblang-queries).`).
 		Categories("Services").
 		Fields(clientFields()...).
 		Fields(
 			service.NewStringField("key").
-				Description("The key of a list to read from."),
+				Description("The key of a list to read from.").
+				Optional(),
+			service.NewStringListField("keys").
+				Description("A list of keys of lists to read from, which are combined with the `key` field when both are set.").
+				Example([]string{"foo", "bar"}).
+				Advanced().
+				Optional().
+				Version("4.28.0"),
 			service.NewAutoRetryNacksToggleField(),
 			service.NewInputMaxInFlightField().Version("4.9.0"),
 			service.NewDurationField("timeout").
@@ -77,8 +96,22 @@ func newRedisListInputFromConfig(conf *service.ParsedConfig, mgr *service.Resour
 		log:    mgr.Logger(),
 	}
 
-	if r.key, err = conf.FieldString("key"); err != nil {
-		return nil, err
+	if conf.Contains("key") {
+		var key string
+		if key, err = conf.FieldString("key"); err != nil {
+			return nil, err
+		}
+		r.keys = append(r.keys, key)
+	}
+	if conf.Contains("keys") {
+		var keys []string
+		if keys, err = conf.FieldStringList("keys"); err != nil {
+			return nil, err
+		}
+		r.keys = append(r.keys, keys...)
+	}
+	if len(r.keys) == 0 {
+		return nil, errors.New("at least one list key must be specified with either the key or keys fields")
 	}
 
 	if r.timeout, err = conf.FieldDuration("timeout"); err != nil {
@@ -107,7 +140,7 @@ func newRedisListInputFromConfig(conf *service.ParsedConfig, mgr *service.Resour
 type redisListReader struct {
 	client  redis.UniversalClient
 	timeout time.Duration
-	key     string
+	keys    []string
 	pop     func(ctx context.Context, timeout time.Duration, keys ...string) *redis.StringSliceCmd
 
 	log *service.Logger
@@ -122,7 +155,7 @@ func (r *redisListReader) Connect(ctx context.Context) error {
 }
 
 func (r *redisListReader) Read(ctx context.Context) (*service.Message, service.AckFunc, error) {
-	res, err := r.pop(ctx, r.timeout, r.key).Result()
+	res, err := r.pop(ctx, r.timeout, r.keys...).Result()
 	if err != nil && !errors.Is(err, redis.Nil) {
 		return nil, nil, err
 	}
@@ -131,7 +164,9 @@ func (r *redisListReader) Read(ctx context.Context) (*service.Message, service.A
 		return nil, nil, context.Canceled
 	}
 
-	return service.NewMessage([]byte(res[1])),
+	msg := service.NewMessage([]byte(res[1]))
+	msg.MetaSetMut("redis_key", res[0])
+	return msg,
 		func(context.Context, error) error { return nil },
 		nil
 }
@@ -0,0 +1,67 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestRedisListInputKeys(t *testing.T) {
+	conf, err := redisListInputConfig().ParseYAML(`
+url: redis://localhost:6379
+key: foo
+keys: [ bar, baz ]
+`, nil)
+	require.NoError(t, err)
+
+	i, err := newRedisListInputFromConfig(conf, service.MockResources())
+	require.NoError(t, err)
+
+	r := i.(*redisListReader)
+	assert.Equal(t, []string{"foo", "bar", "baz"}, r.keys)
+
+	var poppedKeys []string
+	r.pop = func(ctx context.Context, timeout time.Duration, keys ...string) *redis.StringSliceCmd {
+		poppedKeys = keys
+		cmd := redis.NewStringSliceCmd(ctx)
+		cmd.SetVal([]string{"bar", "hello world"})
+		return cmd
+	}
+
+	msg, _, err := r.Read(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo", "bar", "baz"}, poppedKeys)
+
+	mBytes, err := msg.AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(mBytes))
+
+	v, exists := msg.MetaGet("redis_key")
+	assert.True(t, exists)
+	assert.Equal(t, "bar", v)
+
+	r.pop = func(ctx context.Context, timeout time.Duration, keys ...string) *redis.StringSliceCmd {
+		cmd := redis.NewStringSliceCmd(ctx)
+		cmd.SetErr(errors.New("nope"))
+		return cmd
+	}
+	_, _, err = r.Read(context.Background())
+	require.EqualError(t, err, "nope")
+}
+
+func TestRedisListInputNoKeys(t *testing.T) {
+	conf, err := redisListInputConfig().ParseYAML(`
+url: redis://localhost:6379
+`, nil)
+	require.NoError(t, err)
+
+	_, err = newRedisListInputFromConfig(conf, service.MockResources())
+	require.EqualError(t, err, "at least one list key must be specified with either the key or keys fields")
+}
@@ -25,7 +25,18 @@ In order to subscribe to channels using the `+"`PSUBSCRIBE`"+` command set the f
 - `+"`h*llo`"+` subscribes to hllo and heeeello
 - `+"`h[ae]llo`"+` subscribes to hello and hallo, but not hillo
 
-Use `+"`\\`"+` to escape special characters if you want to match them verbatim.`).
+Use `+"`\\`"+` to escape special characters if you want to match them verbatim.
+
+### Metadata
+
+This input adds the following metadata fields to each message:
+
+`+"```text"+`
+- redis_pubsub_channel
+- redis_pubsub_pattern (only when `+"`use_patterns`"+` is enabled)
+`+"```"+`
+
+You can access these metadata fields using [function interpolation](/docs/configuration/interpolation#bloblang-queries).`).
 		Categories("Services").
 		Fields(clientFields()...).
 		Fields(
@@ -119,7 +130,7 @@ func (r *redisPubSubReader) Read(ctx context.Context) (*service.Message, service
 			_ = r.disconnect()
 			return nil, nil, service.ErrEndOfInput
 		}
-		return service.NewMessage([]byte(rMsg.Payload)), func(ctx context.Context, err error) error {
+		return pubSubMessage(rMsg), func(ctx context.Context, err error) error {
 			return nil
 		}, nil
 	case <-ctx.Done():
@@ -127,6 +138,15 @@ func (r *redisPubSubReader) Read(ctx context.Context) (*service.Message, service
 	}
 }
 
+func pubSubMessage(rMsg *redis.Message) *service.Message {
+	msg := service.NewMessage([]byte(rMsg.Payload))
+	msg.MetaSetMut("redis_pubsub_channel", rMsg.Channel)
+	if rMsg.Pattern != "" {
+		msg.MetaSetMut("redis_pubsub_pattern", rMsg.Pattern)
+	}
+	return msg
+}
+
 func (r *redisPubSubReader) disconnect() error {
 	r.cMut.Lock()
 	defer r.cMut.Unlock()
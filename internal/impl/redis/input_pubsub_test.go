@@ -0,0 +1,37 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisPubSubMessageMetadata(t *testing.T) {
+	msg := pubSubMessage(&redis.Message{
+		Channel: "hello",
+		Pattern: "h*llo",
+		Payload: "meow",
+	})
+
+	mBytes, err := msg.AsBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, "meow", string(mBytes))
+
+	v, exists := msg.MetaGet("redis_pubsub_channel")
+	assert.True(t, exists)
+	assert.Equal(t, "hello", v)
+
+	v, exists = msg.MetaGet("redis_pubsub_pattern")
+	assert.True(t, exists)
+	assert.Equal(t, "h*llo", v)
+
+	// Messages received without a pattern subscription have no pattern.
+	msg = pubSubMessage(&redis.Message{
+		Channel: "hello",
+		Payload: "woof",
+	})
+
+	_, exists = msg.MetaGet("redis_pubsub_pattern")
+	assert.False(t, exists)
+}
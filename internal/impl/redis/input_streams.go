@@ -30,7 +30,9 @@ func redisStreamsInputConfig() *service.ConfigSpec {
 	return service.NewConfigSpec().
 		Stable().
 		Summary(`Pulls messages from Redis (v5.0+) streams with the XREADGROUP command. The `+"`client_id`"+` should be unique for each consumer of a group.`).
-		Description(`Redis stream entries are key/value pairs, as such it is necessary to specify the key that contains the body of the message. All other keys/value pairs are saved as metadata fields.`).
+		Description(`Redis stream entries are key/value pairs, as such it is necessary to specify the key that contains the body of the message. All other keys/value pairs are saved as metadata fields. Entries that do not contain the body key are acknowledged and discarded.
+
+Consumer groups are created if they do not already exist, and entries are only acknowledged with XACK once they have been successfully delivered downstream. When the input starts any entries pending for the `+"`client_id`"+` within the consumer group, such as those consumed by a previous instance that were never acknowledged, are delivered again before new entries are consumed.`).
 		Categories("Services").
 		Fields(clientFields()...).
 		Fields(
@@ -335,7 +337,6 @@ func (r *redisStreamsReader) read(ctx context.Context) (pendingRedisStreamMsg, e
 	}
 	r.connBackoff.Reset()
 
-	pendingMsgs := []pendingRedisStreamMsg{}
 	for _, strRes := range res {
 		if _, exists := r.backlogs[strRes.Stream]; exists {
 			if len(strRes.Messages) > 0 {
@@ -344,11 +345,24 @@ func (r *redisStreamsReader) read(ctx context.Context) (pendingRedisStreamMsg, e
 				delete(r.backlogs, strRes.Stream)
 			}
 		}
+	}
+
+	pendingMsgs := r.msgsFromStreams(res)
+	if len(pendingMsgs) == 0 {
+		return msg, context.Canceled
+	}
+	r.pendingMsgs = pendingMsgs[1:]
+	return pendingMsgs[0], nil
+}
+
+// msgsFromStreams converts the entries of an XREADGROUP result into messages,
+// entries that do not contain the body key are queued for acknowledgement and
+// discarded.
+func (r *redisStreamsReader) msgsFromStreams(res []redis.XStream) []pendingRedisStreamMsg {
+	var msgs []pendingRedisStreamMsg
+	for _, strRes := range res {
 		for _, xmsg := range strRes.Messages {
 			body, exists := xmsg.Values[r.bodyKey]
-			if !exists {
-				continue
-			}
 			delete(xmsg.Values, r.bodyKey)
 
 			var bodyBytes []byte
@@ -358,7 +372,11 @@ func (r *redisStreamsReader) read(ctx context.Context) (pendingRedisStreamMsg, e
 			case []byte:
 				bodyBytes = t
 			}
-			if bodyBytes == nil {
+			if !exists || bodyBytes == nil {
+				// Acknowledge entries that cannot be consumed, otherwise they
+				// remain pending and are redelivered each time we start up.
+				r.log.Warnf("Discarding entry %v of stream %v as it does not contain the body key '%v'", xmsg.ID, strRes.Stream, r.bodyKey)
+				r.addAsyncAcks(strRes.Stream, xmsg.ID)
 				continue
 			}
 
@@ -368,25 +386,14 @@ func (r *redisStreamsReader) read(ctx context.Context) (pendingRedisStreamMsg, e
 				part.MetaSetMut(k, v)
 			}
 
-			nextMsg := pendingRedisStreamMsg{
-				payload: service.MessageBatch{},
+			msgs = append(msgs, pendingRedisStreamMsg{
+				payload: service.MessageBatch{part},
 				stream:  strRes.Stream,
 				id:      xmsg.ID,
-			}
-			nextMsg.payload = append(nextMsg.payload, part)
-			if msg.payload == nil {
-				msg = nextMsg
-			} else {
-				pendingMsgs = append(pendingMsgs, nextMsg)
-			}
+			})
 		}
 	}
-
-	r.pendingMsgs = pendingMsgs
-	if msg.payload == nil {
-		return msg, context.Canceled
-	}
-	return msg, nil
+	return msgs
 }
 
 func (r *redisStreamsReader) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
@@ -0,0 +1,74 @@
+package redis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestRedisStreamsMsgsFromStreams(t *testing.T) {
+	conf, err := redisStreamsInputConfig().ParseYAML(`
+url: redis://localhost:6379
+streams: [ foo, bar ]
+consumer_group: baz
+`, nil)
+	require.NoError(t, err)
+
+	r, err := newRedisStreamsReader(conf, service.MockResources())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, r.Close(context.Background()))
+	})
+
+	msgs := r.msgsFromStreams([]redis.XStream{
+		{
+			Stream: "foo",
+			Messages: []redis.XMessage{
+				{ID: "1-0", Values: map[string]any{"body": "first", "meow": "woof"}},
+				{ID: "2-0", Values: map[string]any{"notbody": "nope"}},
+			},
+		},
+		{
+			Stream: "bar",
+			Messages: []redis.XMessage{
+				{ID: "3-0", Values: map[string]any{"body": []byte("second")}},
+				{ID: "4-0", Values: map[string]any{"body": 5}},
+			},
+		},
+	})
+	require.Len(t, msgs, 2)
+
+	assert.Equal(t, "foo", msgs[0].stream)
+	assert.Equal(t, "1-0", msgs[0].id)
+	require.Len(t, msgs[0].payload, 1)
+	mBytes, err := msgs[0].payload[0].AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(mBytes))
+	v, exists := msgs[0].payload[0].MetaGet("meow")
+	assert.True(t, exists)
+	assert.Equal(t, "woof", v)
+	v, exists = msgs[0].payload[0].MetaGet("redis_stream")
+	assert.True(t, exists)
+	assert.Equal(t, "1-0", v)
+
+	assert.Equal(t, "bar", msgs[1].stream)
+	assert.Equal(t, "3-0", msgs[1].id)
+	require.Len(t, msgs[1].payload, 1)
+	mBytes, err = msgs[1].payload[0].AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(mBytes))
+
+	// Entries without a usable body are acknowledged so that they are not
+	// redelivered.
+	r.aMut.Lock()
+	defer r.aMut.Unlock()
+	assert.Equal(t, map[string][]string{
+		"foo": {"2-0"},
+		"bar": {"4-0"},
+	}, r.ackSend)
+}
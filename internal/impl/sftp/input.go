@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"sync"
 	"time"
 
@@ -21,6 +22,7 @@ const (
 	siFieldCredentials         = "credentials"
 	siFieldPaths               = "paths"
 	siFieldDeleteOnFinish      = "delete_on_finish"
+	siFieldMoveOnFinish        = "move_on_finish"
 	siFieldWatcher             = "watcher"
 	siFieldWatcherEnabled      = "enabled"
 	siFieldWatcherMinimumAge   = "minimum_age"
@@ -59,6 +61,12 @@ You can access these metadata fields using [function interpolation](/docs/config
 				Description("Whether to delete files from the server once they are processed.").
 				Advanced().
 				Default(false),
+			service.NewStringField(siFieldMoveOnFinish).
+				Description("An optional directory on the server to move files into once they are processed, preserving their base names. This option cannot be combined with `"+siFieldDeleteOnFinish+"`, and when used with the watcher the directory should not be matched by any of the target paths.").
+				Example("/processed").
+				Advanced().
+				Optional().
+				Version("4.28.0"),
 			service.NewObjectField(siFieldWatcher,
 				service.NewBoolField(siFieldWatcherEnabled).
 					Description("Whether file watching is enabled.").
@@ -104,6 +112,7 @@ type sftpReader struct {
 	creds          Credentials
 	scannerCtor    interop.FallbackReaderCodec
 	deleteOnFinish bool
+	moveOnFinish   string
 
 	watcherEnabled      bool
 	watcherCache        string
@@ -140,6 +149,14 @@ func newSFTPReaderFromParsed(conf *service.ParsedConfig, mgr *service.Resources)
 	if s.deleteOnFinish, err = conf.FieldBool(siFieldDeleteOnFinish); err != nil {
 		return
 	}
+	if conf.Contains(siFieldMoveOnFinish) {
+		if s.moveOnFinish, err = conf.FieldString(siFieldMoveOnFinish); err != nil {
+			return
+		}
+		if s.deleteOnFinish && s.moveOnFinish != "" {
+			return nil, fmt.Errorf("cannot set both %v and %v", siFieldDeleteOnFinish, siFieldMoveOnFinish)
+		}
+	}
 
 	{
 		wConf := conf.Namespace(siFieldWatcher)
@@ -221,23 +238,8 @@ func (s *sftpReader) Connect(ctx context.Context) (err error) {
 		if aErr != nil {
 			return nil
 		}
-		if s.deleteOnFinish {
-			s.scannerMut.Lock()
-			client := s.client
-			if client == nil {
-				if client, outErr = s.creds.GetClient(s.mgr.FS(), s.address); outErr != nil {
-					outErr = fmt.Errorf("obtain private client: %w", outErr)
-				}
-				defer func() {
-					_ = client.Close()
-				}()
-			}
-			if outErr == nil {
-				if outErr = client.Remove(nextPath); outErr != nil {
-					outErr = fmt.Errorf("remove %v: %w", nextPath, outErr)
-				}
-			}
-			s.scannerMut.Unlock()
+		if s.deleteOnFinish || s.moveOnFinish != "" {
+			outErr = s.finishFile(nextPath)
 		}
 		return
 	}, scanner.SourceDetails{Name: nextPath}); err != nil {
@@ -251,6 +253,37 @@ func (s *sftpReader) Connect(ctx context.Context) (err error) {
 	return
 }
 
+// finishFile either deletes or moves a file that has been fully processed,
+// according to the configured finish behaviour.
+func (s *sftpReader) finishFile(filePath string) error {
+	s.scannerMut.Lock()
+	defer s.scannerMut.Unlock()
+
+	client := s.client
+	if client == nil {
+		var err error
+		if client, err = s.creds.GetClient(s.mgr.FS(), s.address); err != nil {
+			return fmt.Errorf("obtain private client: %w", err)
+		}
+		defer func() {
+			_ = client.Close()
+		}()
+	}
+
+	if s.deleteOnFinish {
+		if err := client.Remove(filePath); err != nil {
+			return fmt.Errorf("remove %v: %w", filePath, err)
+		}
+		return nil
+	}
+
+	target := path.Join(s.moveOnFinish, path.Base(filePath))
+	if err := client.Rename(filePath, target); err != nil {
+		return fmt.Errorf("move %v to %v: %w", filePath, target, err)
+	}
+	return nil
+}
+
 func (s *sftpReader) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
 	s.scannerMut.Lock()
 	scanner := s.scanner
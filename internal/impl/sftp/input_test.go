@@ -0,0 +1,154 @@
+package sftp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+// memSFTPClient returns a client connected to an in-memory SFTP server.
+func memSFTPClient(t *testing.T) *sftp.Client {
+	t.Helper()
+
+	serverConn, clientConn := net.Pipe()
+
+	server := sftp.NewRequestServer(serverConn, sftp.InMemHandler())
+	go func() {
+		_ = server.Serve()
+	}()
+	t.Cleanup(func() {
+		_ = server.Close()
+	})
+
+	client, err := sftp.NewClientPipe(clientConn, clientConn)
+	require.NoError(t, err)
+	return client
+}
+
+func memSFTPWriteFile(t *testing.T, client *sftp.Client, path, content string) {
+	t.Helper()
+
+	f, err := client.Create(path)
+	require.NoError(t, err)
+	_, err = f.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func memSFTPExists(t *testing.T, client *sftp.Client, path string) bool {
+	t.Helper()
+
+	_, err := client.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false
+	}
+	require.NoError(t, err)
+	return true
+}
+
+func memSFTPReader(t *testing.T, client *sftp.Client) *sftpReader {
+	t.Helper()
+
+	pConf, err := sftpInputSpec().ParseYAML(`
+address: localhost:22
+paths: [ /data/*.txt ]
+move_on_finish: /processed
+`, nil)
+	require.NoError(t, err)
+
+	r, err := newSFTPReaderFromParsed(pConf, service.MockResources())
+	require.NoError(t, err)
+
+	r.client = client
+	return r
+}
+
+// memSFTPConsume reads the single message of the first file from the reader
+// until the end of the file is reached, and then acknowledges it with the
+// provided result, returning the error from the acknowledgement.
+func memSFTPConsume(t *testing.T, r *sftpReader, res error) error {
+	t.Helper()
+
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	require.NoError(t, r.Connect(ctx))
+
+	batch, ackFn, err := r.ReadBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, batch, 1)
+
+	mBytes, err := batch[0].AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(mBytes))
+
+	p, _ := batch[0].MetaGetMut("sftp_path")
+	assert.Equal(t, "/data/foo.txt", p)
+
+	_, _, err = r.ReadBatch(ctx)
+	require.ErrorIs(t, err, service.ErrNotConnected)
+
+	assert.True(t, memSFTPExists(t, r.client, "/data/foo.txt"), "file moved before ack")
+	return ackFn(ctx, res)
+}
+
+func TestSFTPInputMoveOnFinish(t *testing.T) {
+	client := memSFTPClient(t)
+	require.NoError(t, client.Mkdir("/data"))
+	require.NoError(t, client.Mkdir("/processed"))
+	memSFTPWriteFile(t, client, "/data/foo.txt", "hello world")
+
+	r := memSFTPReader(t, client)
+	require.NoError(t, memSFTPConsume(t, r, nil))
+
+	assert.False(t, memSFTPExists(t, client, "/data/foo.txt"))
+	assert.True(t, memSFTPExists(t, client, "/processed/foo.txt"))
+
+	f, err := client.Open("/processed/foo.txt")
+	require.NoError(t, err)
+	b, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	assert.Equal(t, "hello world", string(b))
+}
+
+func TestSFTPInputMoveOnFinishNack(t *testing.T) {
+	client := memSFTPClient(t)
+	require.NoError(t, client.Mkdir("/data"))
+	require.NoError(t, client.Mkdir("/processed"))
+	memSFTPWriteFile(t, client, "/data/foo.txt", "hello world")
+
+	r := memSFTPReader(t, client)
+	require.NoError(t, memSFTPConsume(t, r, errors.New("nope")))
+
+	assert.True(t, memSFTPExists(t, client, "/data/foo.txt"))
+	assert.False(t, memSFTPExists(t, client, "/processed/foo.txt"))
+}
+
+func TestSFTPInputMoveOnFinishRenameFailure(t *testing.T) {
+	client := memSFTPClient(t)
+	require.NoError(t, client.Mkdir("/data"))
+	require.NoError(t, client.Mkdir("/processed"))
+	memSFTPWriteFile(t, client, "/data/foo.txt", "hello world")
+
+	// The server rejects renames onto an existing file.
+	memSFTPWriteFile(t, client, "/processed/foo.txt", "already here")
+
+	r := memSFTPReader(t, client)
+	err := memSFTPConsume(t, r, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("move %v to %v", "/data/foo.txt", "/processed/foo.txt"))
+
+	assert.True(t, memSFTPExists(t, client, "/data/foo.txt"))
+}
@@ -3,6 +3,7 @@ package sql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -19,7 +20,16 @@ func sqlInsertOutputConfig() *service.ConfigSpec {
 		Stable().
 		Categories("Services").
 		Summary("Inserts a row into an SQL database for each message.").
-		Description(``).
+		Description(`
+A connection is established and verified with a ping when the output connects, and a failure to reach the database is reported as a connection error that is retried.
+
+### Transactions
+
+The rows of a batch are written with a single multi-row insert statement, and for the drivers ` + "`clickhouse`" + ` and ` + "`oracle`" + `, which do not support those, they are instead inserted within a single transaction using a prepared statement that is reused for each row. Either way, if any row of a batch fails to insert then none of the batch is committed and the whole batch is retried.
+
+### Conflicts
+
+Rows that violate a unique constraint are treated as errors by default. Setting the field ` + "`on_conflict`" + ` to ` + "`ignore`" + ` instead skips those rows and treats their insert as a success, which is supported for the drivers ` + "`mysql`" + `, ` + "`postgres`" + ` and ` + "`sqlite`" + `. For ` + "`postgres`" + ` and ` + "`sqlite`" + ` this adds the clause ` + "`ON CONFLICT DO NOTHING`" + `, and for ` + "`mysql`" + ` it adds the clause ` + "`ON DUPLICATE KEY UPDATE`" + ` with an assignment of the first column to itself, which leaves the existing row unchanged. Other errors, such as values that are invalid for their column, still fail the insert. For other databases you can use the field ` + "`suffix`" + ` to add a conflict clause that they support.`).
 		Field(driverField).
 		Field(dsnField).
 		Field(service.NewStringField("table").
@@ -41,6 +51,13 @@ func sqlInsertOutputConfig() *service.ConfigSpec {
 			Optional().
 			Advanced().
 			Example("ON CONFLICT (name) DO NOTHING")).
+		Field(service.NewStringAnnotatedEnumField("on_conflict", map[string]string{
+			"error":  "Rows that violate a unique constraint fail the insert of the batch.",
+			"ignore": "Rows that violate a unique constraint are skipped and the remaining rows are inserted.",
+		}).
+			Description("Determines how rows that violate a unique constraint are handled. The option `ignore` is only supported for the drivers `mysql`, `postgres` and `sqlite`, and cannot be combined with a `suffix`.").
+			Advanced().
+			Default("error")).
 		Field(service.NewIntField("max_in_flight").
 			Description("The maximum number of inserts to run in parallel.").
 			Default(64))
@@ -177,6 +194,33 @@ func newSQLInsertOutputFromConfig(conf *service.ParsedConfig, mgr *service.Resou
 		s.builder = s.builder.Suffix(suffixStr)
 	}
 
+	onConflict, err := conf.FieldString("on_conflict")
+	if err != nil {
+		return nil, err
+	}
+	if onConflict == "ignore" {
+		switch s.driver {
+		case "mysql":
+			if conf.Contains("suffix") {
+				return nil, fmt.Errorf("on_conflict ignore cannot be combined with a suffix for driver %v", s.driver)
+			}
+			if len(columns) == 0 {
+				return nil, errors.New("on_conflict ignore requires at least one column for driver mysql")
+			}
+			// Unlike INSERT IGNORE, which also downgrades errors such as
+			// invalid or truncated values to warnings, a no-op update only
+			// skips rows that conflict with a unique key.
+			s.builder = s.builder.Suffix(fmt.Sprintf("ON DUPLICATE KEY UPDATE %[1]v = %[1]v", columns[0]))
+		case "postgres", "sqlite":
+			if conf.Contains("suffix") {
+				return nil, fmt.Errorf("on_conflict ignore cannot be combined with a suffix for driver %v", s.driver)
+			}
+			s.builder = s.builder.Suffix("ON CONFLICT DO NOTHING")
+		default:
+			return nil, fmt.Errorf("on_conflict ignore is not supported for driver %v", s.driver)
+		}
+	}
+
 	if s.connSettings, err = connSettingsFromParsed(conf, mgr); err != nil {
 		return nil, err
 	}
@@ -196,6 +240,15 @@ func (s *sqlInsertOutput) Connect(ctx context.Context) error {
 		return err
 	}
 
+	// Opening a database does not establish a connection, therefore ping it so
+	// that a bad DSN or unreachable server is reported as a connection error
+	// before any init statements are attempted.
+	if err = s.db.PingContext(ctx); err != nil {
+		_ = s.db.Close()
+		s.db = nil
+		return err
+	}
+
 	s.connSettings.apply(ctx, s.db, s.logger)
 
 	go func() {
@@ -220,17 +273,19 @@ func (s *sqlInsertOutput) WriteBatch(ctx context.Context, batch service.MessageB
 	var stmt *sql.Stmt
 	if s.useTxStmt {
 		var err error
-		if tx, err = s.db.Begin(); err != nil {
+		if tx, err = s.db.BeginTx(ctx, nil); err != nil {
 			return err
 		}
 		sqlStr, _, err := insertBuilder.ToSql()
 		if err != nil {
+			_ = tx.Rollback()
 			return err
 		}
-		if stmt, err = tx.Prepare(sqlStr); err != nil {
+		if stmt, err = tx.PrepareContext(ctx, sqlStr); err != nil {
 			_ = tx.Rollback()
 			return err
 		}
+		defer stmt.Close()
 	}
 
 	for i := range batch {
@@ -238,23 +293,26 @@ func (s *sqlInsertOutput) WriteBatch(ctx context.Context, batch service.MessageB
 		if s.argsMapping != nil {
 			resMsg, err := batch.BloblangQuery(i, s.argsMapping)
 			if err != nil {
+				rollback(tx)
 				return err
 			}
 
 			iargs, err := resMsg.AsStructured()
 			if err != nil {
+				rollback(tx)
 				return err
 			}
 
 			var ok bool
 			if args, ok = iargs.([]any); !ok {
+				rollback(tx)
 				return fmt.Errorf("mapping returned non-array result: %T", iargs)
 			}
 		}
 
 		if tx == nil {
 			insertBuilder = insertBuilder.Values(args...)
-		} else if _, err := stmt.Exec(args...); err != nil {
+		} else if _, err := stmt.ExecContext(ctx, args...); err != nil {
 			_ = tx.Rollback()
 			return err
 		}
@@ -269,6 +327,12 @@ func (s *sqlInsertOutput) WriteBatch(ctx context.Context, batch service.MessageB
 	return err
 }
 
+func rollback(tx *sql.Tx) {
+	if tx != nil {
+		_ = tx.Rollback()
+	}
+}
+
 func (s *sqlInsertOutput) Close(ctx context.Context) error {
 	s.shutSig.TriggerHardStop()
 	s.dbMut.RLock()
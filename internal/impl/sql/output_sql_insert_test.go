@@ -2,8 +2,13 @@ package sql
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/benthosdev/benthos/v4/public/service"
@@ -28,3 +33,158 @@ args_mapping: 'root = [ this.id ]'
 	require.NoError(t, err)
 	require.NoError(t, insertOutput.Close(context.Background()))
 }
+
+func TestSQLInsertOutputInitAfterFailedConnect(t *testing.T) {
+	dbDir := filepath.Join(t.TempDir(), "notyet")
+
+	spec := sqlInsertOutputConfig()
+	insertConfig, err := spec.ParseYAML(fmt.Sprintf(`
+driver: sqlite
+dsn: file:%v/foo.db
+table: things
+columns: [ foo ]
+args_mapping: 'root = [ this.id ]'
+init_statement: |
+  CREATE TABLE IF NOT EXISTS things (
+    foo varchar(50) not null
+  );
+`, dbDir), nil)
+	require.NoError(t, err)
+
+	insertOutput, err := newSQLInsertOutputFromConfig(insertConfig, service.MockResources())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, insertOutput.Close(context.Background()))
+	})
+
+	// The database directory does not exist yet so the connection fails.
+	require.Error(t, insertOutput.Connect(context.Background()))
+
+	require.NoError(t, os.MkdirAll(dbDir, 0o755))
+	require.NoError(t, insertOutput.Connect(context.Background()))
+
+	require.NoError(t, insertOutput.WriteBatch(context.Background(), service.MessageBatch{
+		service.NewMessage([]byte(`{"id":"a"}`)),
+	}))
+
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%v/foo.db", dbDir))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	var foo string
+	require.NoError(t, db.QueryRow("SELECT foo FROM things").Scan(&foo))
+	assert.Equal(t, "a", foo)
+}
+
+func TestSQLInsertOutputOnConflict(t *testing.T) {
+	dsn := fmt.Sprintf("file:%v/foo.db", t.TempDir())
+
+	newOutput := func(t *testing.T, onConflict string) *sqlInsertOutput {
+		t.Helper()
+
+		insertConfig, err := sqlInsertOutputConfig().ParseYAML(fmt.Sprintf(`
+driver: sqlite
+dsn: %v
+table: things
+columns: [ foo ]
+args_mapping: 'root = [ this.id ]'
+on_conflict: %v
+init_statement: |
+  CREATE TABLE IF NOT EXISTS things (
+    foo varchar(50) not null primary key
+  );
+`, dsn, onConflict), nil)
+		require.NoError(t, err)
+
+		insertOutput, err := newSQLInsertOutputFromConfig(insertConfig, service.MockResources())
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, insertOutput.Close(context.Background()))
+		})
+		require.NoError(t, insertOutput.Connect(context.Background()))
+		return insertOutput
+	}
+
+	errOutput := newOutput(t, "error")
+	require.NoError(t, errOutput.WriteBatch(context.Background(), service.MessageBatch{
+		service.NewMessage([]byte(`{"id":"a"}`)),
+	}))
+	require.Error(t, errOutput.WriteBatch(context.Background(), service.MessageBatch{
+		service.NewMessage([]byte(`{"id":"a"}`)),
+		service.NewMessage([]byte(`{"id":"b"}`)),
+	}))
+
+	ignoreOutput := newOutput(t, "ignore")
+	require.NoError(t, ignoreOutput.WriteBatch(context.Background(), service.MessageBatch{
+		service.NewMessage([]byte(`{"id":"a"}`)),
+		service.NewMessage([]byte(`{"id":"b"}`)),
+	}))
+
+	db, err := sql.Open("sqlite", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM things").Scan(&count))
+	assert.Equal(t, 2, count)
+}
+
+func TestSQLInsertOutputOnConflictUnsupported(t *testing.T) {
+	for _, conf := range []string{
+		`
+driver: mssql
+dsn: woof
+table: quack
+columns: [ foo ]
+args_mapping: 'root = [ this.id ]'
+on_conflict: ignore
+`,
+		`
+driver: postgres
+dsn: woof
+table: quack
+columns: [ foo ]
+args_mapping: 'root = [ this.id ]'
+suffix: ON CONFLICT (foo) DO NOTHING
+on_conflict: ignore
+`,
+		`
+driver: mysql
+dsn: woof
+table: quack
+columns: [ foo ]
+args_mapping: 'root = [ this.id ]'
+suffix: ON DUPLICATE KEY UPDATE foo = VALUES(foo)
+on_conflict: ignore
+`,
+	} {
+		insertConfig, err := sqlInsertOutputConfig().ParseYAML(conf, nil)
+		require.NoError(t, err)
+
+		_, err = newSQLInsertOutputFromConfig(insertConfig, service.MockResources())
+		require.Error(t, err, conf)
+	}
+}
+
+func TestSQLInsertOutputOnConflictMySQL(t *testing.T) {
+	insertConfig, err := sqlInsertOutputConfig().ParseYAML(`
+driver: mysql
+dsn: woof
+table: quack
+columns: [ foo, bar ]
+args_mapping: 'root = [ this.id, this.name ]'
+on_conflict: ignore
+`, nil)
+	require.NoError(t, err)
+
+	insertOutput, err := newSQLInsertOutputFromConfig(insertConfig, service.MockResources())
+	require.NoError(t, err)
+
+	query, _, err := insertOutput.builder.Values("a", "b").ToSql()
+	require.NoError(t, err)
+	assert.Equal(t, "INSERT INTO quack (foo,bar) VALUES (?,?) ON DUPLICATE KEY UPDATE foo = foo", query)
+}
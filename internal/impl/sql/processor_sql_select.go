@@ -3,8 +3,10 @@ package sql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 
@@ -21,7 +23,9 @@ func SelectProcessorConfig() *service.ConfigSpec {
 		Categories("Integration").
 		Summary("Runs an SQL select query against a database and returns the result as an array of objects, one for each row returned, containing a key for each column queried and its value.").
 		Description(`
-If the query fails to execute then the message will remain unchanged and the error can be caught using error handling methods outlined [here](/docs/configuration/error_handling).`).
+If the query fails to execute then the message will remain unchanged and the error can be caught using error handling methods outlined [here](/docs/configuration/error_handling).
+
+A query that succeeds but returns zero rows results in an empty array by default. Setting ` + "`error_on_empty`" + ` to ` + "`true`" + ` instead flags such messages with an error, which allows missing rows to be handled separately using the same error handling methods.`).
 		Field(driverField).
 		Field(dsnField).
 		Field(service.NewStringField("table").
@@ -48,6 +52,17 @@ If the query fails to execute then the message will remain unchanged and the err
 		Field(service.NewStringField("suffix").
 			Description("An optional suffix to append to the select query.").
 			Optional().
+			Advanced()).
+		Field(service.NewDurationField("query_timeout").
+			Description("An optional maximum period of time to wait for each query to complete, after which the query is cancelled and the message is flagged with an error.").
+			Example("5s").
+			Version("4.28.0").
+			Optional().
+			Advanced()).
+		Field(service.NewBoolField("error_on_empty").
+			Description("Whether a query returning zero rows should flag the message with an error rather than replacing its contents with an empty array.").
+			Version("4.28.0").
+			Default(false).
 			Advanced())
 
 	for _, f := range connFields() {
@@ -97,8 +112,10 @@ type sqlSelectProcessor struct {
 	builder squirrel.SelectBuilder
 	dbMut   sync.RWMutex
 
-	where       string
-	argsMapping *bloblang.Executor
+	where        string
+	argsMapping  *bloblang.Executor
+	queryTimeout time.Duration
+	errorOnEmpty bool
 
 	logger  *service.Logger
 	shutSig *shutdown.Signaller
@@ -166,6 +183,16 @@ func NewSQLSelectProcessorFromConfig(conf *service.ParsedConfig, mgr *service.Re
 		s.builder = s.builder.Suffix(suffixStr)
 	}
 
+	if conf.Contains("query_timeout") {
+		if s.queryTimeout, err = conf.FieldDuration("query_timeout"); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.errorOnEmpty, err = conf.FieldBool("error_on_empty"); err != nil {
+		return nil, err
+	}
+
 	connSettings, err := connSettingsFromParsed(conf, mgr)
 	if err != nil {
 		return nil, err
@@ -223,23 +250,40 @@ func (s *sqlSelectProcessor) ProcessBatch(ctx context.Context, batch service.Mes
 			queryBuilder = queryBuilder.Where(s.where, args...)
 		}
 
-		rows, err := queryBuilder.RunWith(s.db).QueryContext(ctx)
-		if err != nil {
-			s.logger.Debugf("Failed to run query: %v", err)
+		if err := s.query(ctx, queryBuilder, msg); err != nil {
 			msg.SetError(err)
-			continue
-		}
-
-		if jArray, err := sqlRowsToArray(rows); err != nil {
-			s.logger.Debugf("Failed to convert rows: %v", err)
-			msg.SetError(err)
-		} else {
-			msg.SetStructuredMut(jArray)
 		}
 	}
 	return []service.MessageBatch{batch}, nil
 }
 
+var errEmptyResult = errors.New("query returned zero rows")
+
+func (s *sqlSelectProcessor) query(ctx context.Context, queryBuilder squirrel.SelectBuilder, msg *service.Message) error {
+	if s.queryTimeout > 0 {
+		var done context.CancelFunc
+		ctx, done = context.WithTimeout(ctx, s.queryTimeout)
+		defer done()
+	}
+
+	rows, err := queryBuilder.RunWith(s.db).QueryContext(ctx)
+	if err != nil {
+		s.logger.Debugf("Failed to run query: %v", err)
+		return err
+	}
+
+	jArray, err := sqlRowsToArray(rows)
+	if err != nil {
+		s.logger.Debugf("Failed to convert rows: %v", err)
+		return err
+	}
+	if len(jArray) == 0 && s.errorOnEmpty {
+		return errEmptyResult
+	}
+	msg.SetStructuredMut(jArray)
+	return nil
+}
+
 func (s *sqlSelectProcessor) Close(ctx context.Context) error {
 	s.shutSig.TriggerHardStop()
 	select {
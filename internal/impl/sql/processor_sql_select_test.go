@@ -0,0 +1,74 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benthosdev/benthos/v4/public/service"
+)
+
+func TestSQLSelectProcessorEmptyResults(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	for _, test := range []struct {
+		name         string
+		errorOnEmpty bool
+	}{
+		{name: "empty array", errorOnEmpty: false},
+		{name: "error on empty", errorOnEmpty: true},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			pConf, err := SelectProcessorConfig().ParseYAML(fmt.Sprintf(`
+driver: sqlite
+dsn: file:%v/%v.db
+table: things
+columns: [ foo, bar ]
+where: foo = ?
+args_mapping: 'root = [ this.id ]'
+query_timeout: 5s
+error_on_empty: %v
+init_statement: |
+  CREATE TABLE IF NOT EXISTS things (
+    foo varchar(50) not null,
+    bar varchar(50) not null
+  );
+  INSERT INTO things (foo, bar) VALUES ('a', 'first');
+`, tmpDir, test.errorOnEmpty, test.errorOnEmpty), nil)
+			require.NoError(t, err)
+
+			proc, err := NewSQLSelectProcessorFromConfig(pConf, service.MockResources())
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				require.NoError(t, proc.Close(context.Background()))
+			})
+
+			batches, err := proc.ProcessBatch(context.Background(), service.MessageBatch{
+				service.NewMessage([]byte(`{"id":"a"}`)),
+				service.NewMessage([]byte(`{"id":"b"}`)),
+			})
+			require.NoError(t, err)
+			require.Len(t, batches, 1)
+			require.Len(t, batches[0], 2)
+
+			require.NoError(t, batches[0][0].GetError())
+			mBytes, err := batches[0][0].AsBytes()
+			require.NoError(t, err)
+			assert.Equal(t, `[{"bar":"first","foo":"a"}]`, string(mBytes))
+
+			mBytes, err = batches[0][1].AsBytes()
+			require.NoError(t, err)
+			if test.errorOnEmpty {
+				assert.ErrorIs(t, batches[0][1].GetError(), errEmptyResult)
+				assert.Equal(t, `{"id":"b"}`, string(mBytes))
+			} else {
+				assert.NoError(t, batches[0][1].GetError())
+				assert.Equal(t, `[]`, string(mBytes))
+			}
+		})
+	}
+}
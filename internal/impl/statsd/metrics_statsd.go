@@ -21,6 +21,7 @@ func statsdSpec() *service.ConfigSpec {
 	return service.NewConfigSpec().
 		Stable().
 		Summary("Pushes metrics using the [StatsD protocol](https://github.com/statsd/statsd). Supported tagging formats are 'none', 'datadog' and 'influxdb'.").
+		Description("Metrics are buffered and flushed over UDP, sends never block the pipeline and packets that fail to send are dropped. The number of dropped packets is logged when the exporter is closed.").
 		Fields(
 			service.NewStringField(smFieldAddress).
 				Description("The address to send metrics to."),
@@ -74,7 +75,7 @@ func (s *statsdStat) Incr(count int64) {
 }
 
 func (s *statsdStat) IncrFloat64(count float64) {
-	s.Incr(int64(count))
+	s.s.FIncr(s.path, count, s.tags...)
 }
 
 func (s *statsdStat) Decr(count int64) {
@@ -82,7 +83,7 @@ func (s *statsdStat) Decr(count int64) {
 }
 
 func (s *statsdStat) DecrFloat64(count float64) {
-	s.Decr(int64(count))
+	s.s.FDecr(s.path, count, s.tags...)
 }
 
 func (s *statsdStat) Timing(delta int64) {
@@ -94,14 +95,15 @@ func (s *statsdStat) Set(value int64) {
 }
 
 func (s *statsdStat) SetFloat64(value float64) {
-	s.Set(int64(value))
+	s.s.FGauge(s.path, value, s.tags...)
 }
 
 //------------------------------------------------------------------------------
 
 type statsdMetrics struct {
-	s   *statsd.Client
-	log *service.Logger
+	s      *statsd.Client
+	log    *service.Logger
+	noTags bool
 }
 
 func newStatsdFromParsed(conf *service.ParsedConfig, log *service.Logger) (s *statsdMetrics, err error) {
@@ -130,6 +132,9 @@ func newStatsdFromParsed(conf *service.ParsedConfig, log *service.Logger) (s *st
 	case TagFormatDatadog:
 		statsdOpts = append(statsdOpts, statsd.TagStyle(statsd.TagFormatDatadog))
 	case TagFormatNone:
+		// The client defaults to the InfluxDB style, so labels are dropped
+		// entirely rather than relying on the absence of an option.
+		s.noTags = true
 	default:
 		return nil, fmt.Errorf("tag format '%s' was not recognised", tagFormatStr)
 	}
@@ -152,7 +157,7 @@ func (h *statsdMetrics) NewCounterCtor(path string, n ...string) service.Metrics
 		return &statsdStat{
 			path: path,
 			s:    h.s,
-			tags: tags(n, labelValues),
+			tags: h.tags(n, labelValues),
 		}
 	}
 }
@@ -162,7 +167,7 @@ func (h *statsdMetrics) NewTimerCtor(path string, n ...string) service.MetricsEx
 		return &statsdStat{
 			path: path,
 			s:    h.s,
-			tags: tags(n, labelValues),
+			tags: h.tags(n, labelValues),
 		}
 	}
 }
@@ -172,7 +177,7 @@ func (h *statsdMetrics) NewGaugeCtor(path string, n ...string) service.MetricsEx
 		return &statsdStat{
 			path: path,
 			s:    h.s,
-			tags: tags(n, labelValues),
+			tags: h.tags(n, labelValues),
 		}
 	}
 }
@@ -183,11 +188,14 @@ func (h *statsdMetrics) HandlerFunc() http.HandlerFunc {
 
 func (h *statsdMetrics) Close(context.Context) error {
 	_ = h.s.Close()
+	if lost := h.s.GetLostPackets(); lost > 0 {
+		h.log.Warnf("Dropped %v statsd packets due to send failures", lost)
+	}
 	return nil
 }
 
-func tags(labels, values []string) []statsd.Tag {
-	if len(labels) != len(values) {
+func (h *statsdMetrics) tags(labels, values []string) []statsd.Tag {
+	if h.noTags || len(labels) != len(values) {
 		return nil
 	}
 	tags := make([]statsd.Tag, len(labels))
@@ -0,0 +1,89 @@
+package statsd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsdWireFormat(t *testing.T) {
+	for _, test := range []struct {
+		format string
+		exp    []string
+	}{
+		{
+			format: "none",
+			exp: []string{
+				"bar:1.5|g",
+				"baz:5|ms",
+				"foo:2.5|c",
+				"foo:2|c",
+			},
+		},
+		{
+			format: "datadog",
+			exp: []string{
+				"bar:1.5|g|#label:b",
+				"baz:5|ms|#label:c",
+				"foo:2.5|c|#label:a",
+				"foo:2|c|#label:a",
+			},
+		},
+		{
+			format: "influxdb",
+			exp: []string{
+				"bar,label=b:1.5|g",
+				"baz,label=c:5|ms",
+				"foo,label=a:2.5|c",
+				"foo,label=a:2|c",
+			},
+		},
+	} {
+		test := test
+		t.Run(test.format, func(t *testing.T) {
+			conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				_ = conn.Close()
+			})
+
+			conf, err := statsdSpec().ParseYAML(fmt.Sprintf(`
+address: %v
+flush_period: 10ms
+tag_format: %v
+`, conn.LocalAddr().String(), test.format), nil)
+			require.NoError(t, err)
+
+			s, err := newStatsdFromParsed(conf, nil)
+			require.NoError(t, err)
+
+			s.NewCounterCtor("foo", "label")("a").Incr(2)
+			s.NewCounterCtor("foo", "label")("a").(*statsdStat).IncrFloat64(2.5)
+			s.NewGaugeCtor("bar", "label")("b").(*statsdStat).SetFloat64(1.5)
+			s.NewTimerCtor("baz", "label")("c").Timing(5)
+			require.NoError(t, s.Close(context.Background()))
+
+			var lines []string
+			buf := make([]byte, 1024)
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+			for len(lines) < len(test.exp) {
+				n, _, err := conn.ReadFrom(buf)
+				require.NoError(t, err)
+				for _, l := range strings.Split(string(buf[:n]), "\n") {
+					if l != "" {
+						lines = append(lines, l)
+					}
+				}
+			}
+			sort.Strings(lines)
+			assert.Equal(t, test.exp, lines)
+		})
+	}
+}
@@ -47,7 +47,7 @@ Attempts to parse a string as an XML document and returns a structured result, w
 				cast = *castOpt
 			}
 			return bloblang.BytesMethod(func(xmlBytes []byte) (any, error) {
-				xmlObj, err := ToMap(xmlBytes, cast)
+				xmlObj, err := ToMap(xmlBytes, cast, defaultAttrPrefix)
 				if err != nil {
 					return nil, fmt.Errorf("failed to parse value as XML: %w", err)
 				}
@@ -6,6 +6,7 @@ package xml
 
 import (
 	"encoding/xml"
+	"strings"
 
 	"github.com/clbanning/mxj/v2"
 	"golang.org/x/net/html/charset"
@@ -18,12 +19,54 @@ func init() {
 	mxj.CustomDecoder = dec
 }
 
+// defaultAttrPrefix is the prefix mxj gives to the keys of attributes. The
+// prefix is configured globally within mxj and so is never changed, instead
+// keys are renamed after parsing and before serializing.
+const defaultAttrPrefix = "-"
+
 // ToMap parses a byte slice as XML and returns a generic structure that can be
-// serialized to JSON.
-func ToMap(xmlBytes []byte, cast bool) (map[string]any, error) {
+// serialized to JSON, where the keys of attributes are prefixed with
+// attrPrefix.
+func ToMap(xmlBytes []byte, cast bool, attrPrefix string) (map[string]any, error) {
 	root, err := mxj.NewMapXml(xmlBytes, cast)
 	if err != nil {
 		return nil, err
 	}
+	if attrPrefix != defaultAttrPrefix {
+		return replaceAttrPrefix(map[string]any(root), defaultAttrPrefix, attrPrefix).(map[string]any), nil
+	}
 	return map[string]any(root), nil
 }
+
+// FromMap serializes a generic structure as an XML document, where keys
+// prefixed with attrPrefix become attributes. When attrPrefix is empty all keys
+// become elements.
+func FromMap(root map[string]any, attrPrefix string) ([]byte, error) {
+	if attrPrefix != defaultAttrPrefix && attrPrefix != "" {
+		root = replaceAttrPrefix(root, attrPrefix, defaultAttrPrefix).(map[string]any)
+	}
+	return mxj.Map(root).Xml()
+}
+
+// replaceAttrPrefix returns a copy of a generic structure where the prefix of
+// any keys beginning with from is replaced with to.
+func replaceAttrPrefix(v any, from, to string) any {
+	switch t := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(t))
+		for k, c := range t {
+			if strings.HasPrefix(k, from) {
+				k = to + strings.TrimPrefix(k, from)
+			}
+			m[k] = replaceAttrPrefix(c, from, to)
+		}
+		return m
+	case []any:
+		s := make([]any, len(t))
+		for i, c := range t {
+			s[i] = replaceAttrPrefix(c, from, to)
+		}
+		return s
+	}
+	return v
+}
@@ -2,6 +2,7 @@ package xml
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/benthosdev/benthos/v4/public/service"
@@ -10,6 +11,8 @@ import (
 const (
 	pFieldOperator = "operator"
 	pFieldCast     = "cast"
+	pFieldArrays   = "force_arrays"
+	pFieldPrefix   = "attribute_prefix"
 )
 
 func xmlProcSpec() *service.ConfigSpec {
@@ -24,7 +27,7 @@ func xmlProcSpec() *service.ConfigSpec {
 
 Converts an XML document into a JSON structure, where elements appear as keys of an object according to the following rules:
 
-- If an element contains attributes they are parsed by prefixing a hyphen, `+"`-`"+`, to the attribute label.
+- If an element contains attributes they are parsed by prefixing the attribute label with the `+"`attribute_prefix`"+`, which defaults to a hyphen, `+"`-`"+`.
 - If the element is a simple element and has attributes, the element value is given the key `+"`#text`"+`.
 - XML comments, directives, and process instructions are ignored.
 - When elements are repeated the resulting JSON value is an array.
@@ -77,14 +80,36 @@ With cast set to true, the resulting JSON structure would look like this:
     ]
   }
 }
-`+"```").
+`+"```"+`
+
+Elements listed in the field `+"`force_arrays`"+` are always converted into arrays, even when only a single element of that name is present. This is useful when a document may contain one or many of an element and downstream processing expects a consistent structure. For example, with `+"`force_arrays: [ elements ]`"+` the document `+"`<root><elements>foo1</elements></root>`"+` becomes `+"`{\"root\":{\"elements\":[\"foo1\"]}}`"+`.
+
+Messages that cannot be parsed as XML are left unchanged and flagged as having failed, allowing you to handle them using [error handling patterns](/docs/configuration/error_handling).
+
+### `+"`to_xml`"+`
+
+Converts a JSON object into an XML document following the inverse of the rules described for `+"`to_json`"+`, where keys prefixed with the `+"`attribute_prefix`"+` become attributes and the key `+"`#text`"+` becomes the text of an element. If the object has a single key then it is used as the root element, otherwise the elements are wrapped within a `+"`<doc>`"+` root element.
+
+Messages that are not JSON objects are left unchanged and flagged as having failed.`).
 		Fields(
-			service.NewStringEnumField(pFieldOperator, "to_json").
+			service.NewStringEnumField(pFieldOperator, "to_json", "to_xml").
 				Description("An XML [operation](#operators) to apply to messages.").
 				Default(""),
 			service.NewBoolField(pFieldCast).
 				Description("Whether to try to cast values that are numbers and booleans to the right type. Default: all values are strings.").
 				Default(false),
+			service.NewStringListField(pFieldArrays).
+				Description("A list of element names that should always be converted into arrays when using the `to_json` operator, even when only a single element is present.").
+				Example([]string{"item", "entry"}).
+				Version("4.28.0").
+				Advanced().
+				Default([]any{}),
+			service.NewStringField(pFieldPrefix).
+				Description("The prefix given to the keys of attributes when using the `to_json` operator, and that identifies keys to write as attributes when using the `to_xml` operator. When empty the `to_xml` operator writes all keys as elements.").
+				Example("@").
+				Version("4.28.0").
+				Advanced().
+				Default("-"),
 		)
 }
 
@@ -100,8 +125,11 @@ func init() {
 }
 
 type xmlProc struct {
-	log  *service.Logger
-	cast bool
+	log        *service.Logger
+	operator   string
+	cast       bool
+	arrays     map[string]struct{}
+	attrPrefix string
 }
 
 func xmlProcFromParsed(pConf *service.ParsedConfig, mgr *service.Resources) (*xmlProc, error) {
@@ -109,7 +137,7 @@ func xmlProcFromParsed(pConf *service.ParsedConfig, mgr *service.Resources) (*xm
 	if err != nil {
 		return nil, err
 	}
-	if operator != "to_json" {
+	if operator != "to_json" && operator != "to_xml" {
 		return nil, fmt.Errorf("operator not recognised: %v", operator)
 	}
 
@@ -118,28 +146,93 @@ func xmlProcFromParsed(pConf *service.ParsedConfig, mgr *service.Resources) (*xm
 		return nil, err
 	}
 
+	arrays, err := pConf.FieldStringList(pFieldArrays)
+	if err != nil {
+		return nil, err
+	}
+
+	attrPrefix, err := pConf.FieldString(pFieldPrefix)
+	if err != nil {
+		return nil, err
+	}
+
 	j := &xmlProc{
-		log:  mgr.Logger(),
-		cast: cast,
+		log:        mgr.Logger(),
+		operator:   operator,
+		cast:       cast,
+		arrays:     make(map[string]struct{}, len(arrays)),
+		attrPrefix: attrPrefix,
+	}
+	for _, a := range arrays {
+		j.arrays[a] = struct{}{}
 	}
 	return j, nil
 }
 
+// forceArrays walks a structure parsed from XML and wraps the values of any
+// keys within the arrays set that are not already arrays.
+func forceArrays(v any, arrays map[string]struct{}) {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, c := range t {
+			if _, exists := arrays[k]; exists {
+				if _, isArr := c.([]any); !isArr {
+					c = []any{c}
+					t[k] = c
+				}
+			}
+			forceArrays(c, arrays)
+		}
+	case []any:
+		for _, c := range t {
+			forceArrays(c, arrays)
+		}
+	}
+}
+
 func (p *xmlProc) Process(ctx context.Context, msg *service.Message) (service.MessageBatch, error) {
+	if p.operator == "to_xml" {
+		return p.processToXML(msg)
+	}
+
 	mBytes, err := msg.AsBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	root, err := ToMap(mBytes, p.cast)
+	root, err := ToMap(mBytes, p.cast, p.attrPrefix)
 	if err != nil {
 		p.log.Debugf("Failed to parse part as XML: %v", err)
 		return nil, err
 	}
+	if len(p.arrays) > 0 {
+		forceArrays(root, p.arrays)
+	}
 	msg.SetStructuredMut(root)
 	return service.MessageBatch{msg}, nil
 }
 
+func (p *xmlProc) processToXML(msg *service.Message) (service.MessageBatch, error) {
+	v, err := msg.AsStructured()
+	if err != nil {
+		p.log.Debugf("Failed to parse part as JSON: %v", err)
+		return nil, err
+	}
+
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return nil, errors.New("expected a JSON object")
+	}
+
+	xmlBytes, err := FromMap(obj, p.attrPrefix)
+	if err != nil {
+		p.log.Debugf("Failed to serialize part as XML: %v", err)
+		return nil, err
+	}
+	msg.SetBytes(xmlBytes)
+	return service.MessageBatch{msg}, nil
+}
+
 func (p *xmlProc) Close(ctx context.Context) error {
 	return nil
 }
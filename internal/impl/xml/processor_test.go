@@ -2,6 +2,7 @@ package xml
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -134,3 +135,164 @@ cast: true
 
 	assert.Equal(t, `{"root":{"bool":true,"number":{"#text":123,"-id":99},"title":"This is a title"}}`, string(mBytes))
 }
+
+func TestXMLForceArrays(t *testing.T) {
+	pConf, err := xmlProcSpec().ParseYAML(`
+operator: to_json
+force_arrays: [ elements, thing ]
+`, nil)
+	require.NoError(t, err)
+
+	proc, err := xmlProcFromParsed(pConf, service.MockResources())
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		input  string
+		output string
+	}{
+		{
+			input:  `<root><elements>foo1</elements><title>bar</title></root>`,
+			output: `{"root":{"elements":["foo1"],"title":"bar"}}`,
+		},
+		{
+			input:  `<root><elements>foo1</elements><elements>foo2</elements></root>`,
+			output: `{"root":{"elements":["foo1","foo2"]}}`,
+		},
+		{
+			input:  `<root><elements id="1"><thing>a</thing></elements></root>`,
+			output: `{"root":{"elements":[{"-id":"1","thing":["a"]}]}}`,
+		},
+	} {
+		msgsOut, err := proc.Process(context.Background(), service.NewMessage([]byte(test.input)))
+		require.NoError(t, err)
+		require.Len(t, msgsOut, 1)
+
+		mBytes, err := msgsOut[0].AsBytes()
+		require.NoError(t, err)
+		assert.Equal(t, test.output, string(mBytes), test.input)
+	}
+}
+
+func TestXMLInvalid(t *testing.T) {
+	pConf, err := xmlProcSpec().ParseYAML(`operator: to_json`, nil)
+	require.NoError(t, err)
+
+	proc, err := xmlProcFromParsed(pConf, service.MockResources())
+	require.NoError(t, err)
+
+	_, err = proc.Process(context.Background(), service.NewMessage([]byte(`not xml`)))
+	require.Error(t, err)
+}
+
+func TestXMLToXML(t *testing.T) {
+	pConf, err := xmlProcSpec().ParseYAML(`operator: to_xml`, nil)
+	require.NoError(t, err)
+
+	proc, err := xmlProcFromParsed(pConf, service.MockResources())
+	require.NoError(t, err)
+
+	msgsOut, err := proc.Process(context.Background(), service.NewMessage([]byte(`{"root":{"title":{"#text":"foo","-lang":"en"}}}`)))
+	require.NoError(t, err)
+	require.Len(t, msgsOut, 1)
+
+	mBytes, err := msgsOut[0].AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, `<root><title lang="en">foo</title></root>`, string(mBytes))
+
+	_, err = proc.Process(context.Background(), service.NewMessage([]byte(`["not","an","object"]`)))
+	require.Error(t, err)
+}
+
+func TestXMLToXMLMultipleRoots(t *testing.T) {
+	pConf, err := xmlProcSpec().ParseYAML(`operator: to_xml`, nil)
+	require.NoError(t, err)
+
+	proc, err := xmlProcFromParsed(pConf, service.MockResources())
+	require.NoError(t, err)
+
+	msgsOut, err := proc.Process(context.Background(), service.NewMessage([]byte(`{"a":"foo","b":"bar"}`)))
+	require.NoError(t, err)
+	require.Len(t, msgsOut, 1)
+
+	mBytes, err := msgsOut[0].AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, `<doc><a>foo</a><b>bar</b></doc>`, string(mBytes))
+}
+
+func TestXMLAttributePrefix(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		prefix string
+		output string
+	}{
+		{
+			name:   "custom prefix",
+			prefix: "@",
+			output: `{"root":{"elements":[{"#text":"foo1","@id":"1"},{"@id":"2","thing":{"@a":"b"}}]}}`,
+		},
+		{
+			name:   "empty prefix",
+			prefix: "",
+			output: `{"root":{"elements":[{"#text":"foo1","id":"1"},{"id":"2","thing":{"a":"b"}}]}}`,
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			pConf, err := xmlProcSpec().ParseYAML(fmt.Sprintf(`
+operator: to_json
+attribute_prefix: '%v'
+`, test.prefix), nil)
+			require.NoError(t, err)
+
+			proc, err := xmlProcFromParsed(pConf, service.MockResources())
+			require.NoError(t, err)
+
+			msgsOut, err := proc.Process(context.Background(), service.NewMessage([]byte(
+				`<root><elements id="1">foo1</elements><elements id="2"><thing a="b"/></elements></root>`,
+			)))
+			require.NoError(t, err)
+			require.Len(t, msgsOut, 1)
+
+			mBytes, err := msgsOut[0].AsBytes()
+			require.NoError(t, err)
+			assert.Equal(t, test.output, string(mBytes))
+		})
+	}
+}
+
+func TestXMLToXMLAttributePrefix(t *testing.T) {
+	pConf, err := xmlProcSpec().ParseYAML(`
+operator: to_xml
+attribute_prefix: '@'
+`, nil)
+	require.NoError(t, err)
+
+	proc, err := xmlProcFromParsed(pConf, service.MockResources())
+	require.NoError(t, err)
+
+	inBytes := []byte(`{"root":{"title":{"#text":"foo","@lang":"en"},"items":[{"@id":"1"},{"@id":"2"}]}}`)
+	msgsOut, err := proc.Process(context.Background(), service.NewMessage(inBytes))
+	require.NoError(t, err)
+	require.Len(t, msgsOut, 1)
+
+	mBytes, err := msgsOut[0].AsBytes()
+	require.NoError(t, err)
+	assert.Equal(t, `<root><items id="1"/><items id="2"/><title lang="en">foo</title></root>`, string(mBytes))
+
+	pConf, err = xmlProcSpec().ParseYAML(`
+operator: to_json
+attribute_prefix: '@'
+`, nil)
+	require.NoError(t, err)
+
+	proc, err = xmlProcFromParsed(pConf, service.MockResources())
+	require.NoError(t, err)
+
+	msgsOut, err = proc.Process(context.Background(), service.NewMessage(mBytes))
+	require.NoError(t, err)
+	require.Len(t, msgsOut, 1)
+
+	mBytes, err = msgsOut[0].AsBytes()
+	require.NoError(t, err)
+	assert.JSONEq(t, string(inBytes), string(mBytes))
+}
@@ -1,6 +1,10 @@
 package log
 
-import "github.com/benthosdev/benthos/v4/internal/docs"
+import (
+	"time"
+
+	"github.com/benthosdev/benthos/v4/internal/docs"
+)
 
 const (
 	fieldLogLevel         = "level"
@@ -9,6 +13,7 @@ const (
 	fieldLevelName        = "level_name"
 	fieldMessageName      = "message_name"
 	fieldTimestampName    = "timestamp_name"
+	fieldTimestampFormat  = "timestamp_format"
 	fieldStaticFields     = "static_fields"
 	fieldFile             = "file"
 	fieldFilePath         = "path"
@@ -24,6 +29,7 @@ type Config struct {
 	LevelName     string            `yaml:"level_name"`
 	MessageName   string            `yaml:"message_name"`
 	TimestampName string            `yaml:"timestamp_name"`
+	TimestampFmt  string            `yaml:"timestamp_format"`
 	StaticFields  map[string]string `yaml:"static_fields"`
 	File          File              `yaml:"file"`
 }
@@ -43,6 +49,7 @@ func NewConfig() Config {
 		AddTimeStamp:  false,
 		LevelName:     "level",
 		TimestampName: "time",
+		TimestampFmt:  time.RFC3339,
 		MessageName:   "msg",
 		StaticFields: map[string]string{
 			"@service": "benthos",
@@ -90,6 +97,9 @@ func FromParsed(pConf *docs.ParsedConfig) (conf Config, err error) {
 	if conf.TimestampName, err = pConf.FieldString(fieldTimestampName); err != nil {
 		return
 	}
+	if conf.TimestampFmt, err = pConf.FieldString(fieldTimestampFormat); err != nil {
+		return
+	}
 	if conf.StaticFields, err = pConf.FieldStringMap(fieldStaticFields); err != nil {
 		return
 	}
@@ -19,6 +19,7 @@ func Spec() docs.FieldSpecs {
 		docs.FieldBool(fieldAddTimeStamp, "Whether to include timestamps in logs.").HasDefault(false),
 		docs.FieldString(fieldLevelName, "The name of the level field added to logs when the `format` is `json`.").HasDefault("level"),
 		docs.FieldString(fieldTimestampName, "The name of the timestamp field added to logs when `add_timestamp` is set to `true` and the `format` is `json`.").HasDefault("time"),
+		docs.FieldString(fieldTimestampFormat, "The format of the timestamp added to logs when `add_timestamp` is set to `true`, specified as a [Go time layout](https://pkg.go.dev/time#pkg-constants).").HasDefault("2006-01-02T15:04:05Z07:00").AtVersion("4.28.0").Advanced(),
 		docs.FieldString(fieldMessageName, "The name of the message field added to logs when the `format` is `json`.").HasDefault("msg"),
 		docs.FieldString(fieldStaticFields, "A map of key/value pairs to add to each structured log.").Map().HasDefault(map[string]any{
 			"@service": "benthos",
@@ -51,6 +51,7 @@ func New(stream io.Writer, fs ifs.FS, config Config) (Modular, error) {
 	case "json":
 		logger.SetFormatter(&logrus.JSONFormatter{
 			DisableTimestamp: !config.AddTimeStamp,
+			TimestampFormat:  config.TimestampFmt,
 			FieldMap: logrus.FieldMap{
 				logrus.FieldKeyTime:  config.TimestampName,
 				logrus.FieldKeyMsg:   config.MessageName,
@@ -62,6 +63,7 @@ func New(stream io.Writer, fs ifs.FS, config Config) (Modular, error) {
 			DisableTimestamp: !config.AddTimeStamp,
 			QuoteEmptyFields: true,
 			FullTimestamp:    config.AddTimeStamp,
+			TimestampFormat:  config.TimestampFmt,
 			FieldMap: logrus.FieldMap{
 				logrus.FieldKeyTime:  config.TimestampName,
 				logrus.FieldKeyMsg:   config.MessageName,
@@ -87,7 +89,6 @@ func New(stream io.Writer, fs ifs.FS, config Config) (Modular, error) {
 		logger.Level = logrus.DebugLevel
 	case "TRACE", "ALL":
 		logger.Level = logrus.TraceLevel
-		logger.Level = logrus.TraceLevel
 	}
 
 	sFields := logrus.Fields{}
@@ -2,7 +2,9 @@ package log
 
 import (
 	"bytes"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -168,3 +170,30 @@ func TestLogLevels(t *testing.T) {
 		}
 	}
 }
+
+func TestLoggerTimestampFormat(t *testing.T) {
+	for _, format := range []string{"json", "logfmt"} {
+		format := format
+		t.Run(format, func(t *testing.T) {
+			loggerConfig := NewConfig()
+			loggerConfig.AddTimeStamp = true
+			loggerConfig.TimestampFmt = "2006"
+			loggerConfig.Format = format
+			loggerConfig.StaticFields = nil
+
+			var buf bytes.Buffer
+
+			logger, err := New(&buf, ifs.OS(), loggerConfig)
+			require.NoError(t, err)
+
+			logger.Info("Info message")
+
+			year := strconv.Itoa(time.Now().Year())
+			if format == "json" {
+				assert.Equal(t, `{"level":"info","msg":"Info message","time":"`+year+`"}`+"\n", buf.String())
+			} else {
+				assert.Equal(t, `time=`+year+` level=info msg="Info message"`+"\n", buf.String())
+			}
+		})
+	}
+}
@@ -157,3 +157,83 @@ func TestInitializationOrdering(t *testing.T) {
 
 	wg.Wait()
 }
+
+type closeOrderCache struct {
+	cache.V1
+	closed func()
+}
+
+func (c *closeOrderCache) Close(ctx context.Context) error {
+	c.closed()
+	return nil
+}
+
+type closeOrderProcessor struct {
+	processor.V1
+	closed func()
+}
+
+func (p *closeOrderProcessor) Close(ctx context.Context) error {
+	p.closed()
+	return nil
+}
+
+type closeOrderRateLimit struct {
+	ratelimit.V1
+	closed func()
+}
+
+func (r *closeOrderRateLimit) Close(ctx context.Context) error {
+	r.closed()
+	return nil
+}
+
+func TestShutdownOrdering(t *testing.T) {
+	env := bundle.NewEnvironment()
+
+	var closed []string
+	require.NoError(t, env.CacheAdd(func(c cache.Config, mgr bundle.NewManagement) (cache.V1, error) {
+		return &closeOrderCache{closed: func() { closed = append(closed, "cache") }}, nil
+	}, docs.ComponentSpec{
+		Name: "testcache",
+	}))
+
+	require.NoError(t, env.ProcessorAdd(func(c processor.Config, mgr bundle.NewManagement) (processor.V1, error) {
+		return &closeOrderProcessor{closed: func() { closed = append(closed, "processor") }}, nil
+	}, docs.ComponentSpec{
+		Name: "testprocessor",
+	}))
+
+	require.NoError(t, env.RateLimitAdd(func(c ratelimit.Config, mgr bundle.NewManagement) (ratelimit.V1, error) {
+		return &closeOrderRateLimit{closed: func() { closed = append(closed, "rate_limit") }}, nil
+	}, docs.ComponentSpec{
+		Name: "testratelimit",
+	}))
+
+	cConf := cache.NewConfig()
+	cConf.Label = "foocache"
+	cConf.Type = "testcache"
+
+	procConf := processor.NewConfig()
+	procConf.Label = "fooproc"
+	procConf.Type = "testprocessor"
+
+	rlConf := ratelimit.NewConfig()
+	rlConf.Label = "fooratelimit"
+	rlConf.Type = "testratelimit"
+
+	resConf := NewResourceConfig()
+	resConf.ResourceCaches = append(resConf.ResourceCaches, cConf)
+	resConf.ResourceProcessors = append(resConf.ResourceProcessors, procConf)
+	resConf.ResourceRateLimits = append(resConf.ResourceRateLimits, rlConf)
+
+	mgr, err := New(resConf, OptSetEnvironment(env))
+	require.NoError(t, err)
+
+	mgr.TriggerStopConsuming()
+	require.NoError(t, mgr.WaitForClose(context.Background()))
+
+	// Processor resources may access caches and rate limits up until they're
+	// closed.
+	assert.Equal(t, []string{"processor", "cache", "rate_limit"}, closed)
+}
@@ -928,7 +928,8 @@ func (t *Type) TriggerCloseNow() {
 }
 
 // WaitForClose is a blocking call to wait until the component has finished
-// shutting down and cleaning up resources.
+// shutting down and cleaning up resources. Caches and rate limits are closed
+// last as the other resource types may depend on them.
 func (t *Type) WaitForClose(ctx context.Context) error {
 	if err := t.inputs.Walk(func(name string, i **InputWrapper, set func(i **InputWrapper)) error {
 		if i == nil {
@@ -943,11 +944,11 @@ func (t *Type) WaitForClose(ctx context.Context) error {
 		return err
 	}
 
-	if err := t.caches.Walk(func(name string, c *cache.V1, set func(c *cache.V1)) error {
-		if c == nil {
+	if err := t.processors.Walk(func(name string, p *processor.V1, set func(p *processor.V1)) error {
+		if p == nil {
 			return nil
 		}
-		if err := (*c).Close(ctx); err != nil {
+		if err := (*p).Close(ctx); err != nil {
 			return fmt.Errorf("resource '%s' failed to cleanly shutdown: %v", name, err)
 		}
 		set(nil)
@@ -956,11 +957,11 @@ func (t *Type) WaitForClose(ctx context.Context) error {
 		return err
 	}
 
-	if err := t.processors.Walk(func(name string, p *processor.V1, set func(p *processor.V1)) error {
-		if p == nil {
+	if err := t.outputs.Walk(func(name string, o **outputWrapper, set func(o **outputWrapper)) error {
+		if o == nil {
 			return nil
 		}
-		if err := (*p).Close(ctx); err != nil {
+		if err := (*o).WaitForClose(ctx); err != nil {
 			return fmt.Errorf("resource '%s' failed to cleanly shutdown: %v", name, err)
 		}
 		set(nil)
@@ -969,11 +970,11 @@ func (t *Type) WaitForClose(ctx context.Context) error {
 		return err
 	}
 
-	if err := t.rateLimits.Walk(func(name string, r *ratelimit.V1, set func(r *ratelimit.V1)) error {
-		if r == nil {
+	if err := t.caches.Walk(func(name string, c *cache.V1, set func(c *cache.V1)) error {
+		if c == nil {
 			return nil
 		}
-		if err := (*r).Close(ctx); err != nil {
+		if err := (*c).Close(ctx); err != nil {
 			return fmt.Errorf("resource '%s' failed to cleanly shutdown: %v", name, err)
 		}
 		set(nil)
@@ -982,11 +983,11 @@ func (t *Type) WaitForClose(ctx context.Context) error {
 		return err
 	}
 
-	if err := t.outputs.Walk(func(name string, o **outputWrapper, set func(o **outputWrapper)) error {
-		if o == nil {
+	if err := t.rateLimits.Walk(func(name string, r *ratelimit.V1, set func(r *ratelimit.V1)) error {
+		if r == nil {
 			return nil
 		}
-		if err := (*o).WaitForClose(ctx); err != nil {
+		if err := (*r).Close(ctx); err != nil {
 			return fmt.Errorf("resource '%s' failed to cleanly shutdown: %v", name, err)
 		}
 		set(nil)
@@ -12,7 +12,7 @@ import (
 	"github.com/benthosdev/benthos/v4/internal/value"
 )
 
-var threadsField = docs.FieldInt("threads", "The number of threads to execute processing pipelines across.").HasDefault(-1)
+var threadsField = docs.FieldInt("threads", "The number of threads to execute processing pipelines across. When set to `-1` the number of threads matches `GOMAXPROCS`, which defaults to the number of logical CPUs available. When more than one thread is used messages may be emitted in a different order than they were received.").HasDefault(-1)
 
 func ConfigSpec() docs.FieldSpec {
 	return docs.FieldObject(
@@ -31,7 +31,7 @@ type Pool struct {
 // NewPool creates a new processing pool.
 func NewPool(threads int, log log.Modular, msgProcessors ...processor.V1) (*Pool, error) {
 	if threads <= 0 {
-		threads = runtime.NumCPU()
+		threads = runtime.GOMAXPROCS(0)
 	}
 
 	p := &Pool{
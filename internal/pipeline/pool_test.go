@@ -3,6 +3,8 @@ package pipeline_test
 import (
 	"context"
 	"reflect"
+	"runtime"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -278,3 +280,89 @@ func TestPoolMultiNaturalClose(t *testing.T) {
 	close(tChan)
 	require.NoError(t, proc.WaitForClose(context.Background()))
 }
+
+type mockBlockingProcessor struct {
+	enteredChan chan struct{}
+	releaseChan chan struct{}
+
+	active    int64
+	maxActive int64
+}
+
+func (m *mockBlockingProcessor) ProcessBatch(ctx context.Context, msg message.Batch) ([]message.Batch, error) {
+	active := atomic.AddInt64(&m.active, 1)
+	defer atomic.AddInt64(&m.active, -1)
+	for {
+		maxActive := atomic.LoadInt64(&m.maxActive)
+		if active <= maxActive || atomic.CompareAndSwapInt64(&m.maxActive, maxActive, active) {
+			break
+		}
+	}
+
+	m.enteredChan <- struct{}{}
+	<-m.releaseChan
+	return []message.Batch{msg}, nil
+}
+
+func (m *mockBlockingProcessor) Close(ctx context.Context) error {
+	return nil
+}
+
+func TestPoolDefaultThreads(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(3))
+
+	nMsgs := 4
+	mockProc := &mockBlockingProcessor{
+		enteredChan: make(chan struct{}, nMsgs),
+		releaseChan: make(chan struct{}),
+	}
+
+	proc, err := pipeline.NewPool(-1, log.Noop(), mockProc)
+	require.NoError(t, err)
+
+	tChan, resChan := make(chan message.Transaction), make(chan error, nMsgs)
+	require.NoError(t, proc.Consume(tChan))
+
+	go func() {
+		for j := 0; j < nMsgs; j++ {
+			select {
+			case tChan <- message.NewTransaction(message.QuickBatch([][]byte{[]byte("foo")}), resChan):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// A thread for each of GOMAXPROCS must be processing concurrently before
+	// any are released.
+	for j := 0; j < 3; j++ {
+		select {
+		case <-mockProc.enteredChan:
+		case <-ctx.Done():
+			t.Fatal("Timed out")
+		}
+	}
+	close(mockProc.releaseChan)
+
+	for j := 0; j < nMsgs; j++ {
+		select {
+		case procT := <-proc.TransactionChan():
+			require.NoError(t, procT.Ack(ctx, nil))
+		case <-ctx.Done():
+			t.Fatal("Timed out")
+		}
+		select {
+		case res := <-resChan:
+			require.NoError(t, res)
+		case <-ctx.Done():
+			t.Fatal("Timed out")
+		}
+	}
+	assert.Equal(t, int64(3), atomic.LoadInt64(&mockProc.maxActive))
+
+	proc.TriggerCloseNow()
+	require.NoError(t, proc.WaitForClose(ctx))
+}
@@ -179,10 +179,11 @@ func (m *Type) Read(id string) (*StreamStatus, error) {
 }
 
 // Update attempts to stop an existing stream and replace it with a new version
-// of the same stream.
+// of the same stream. If the new version fails to start then the old version
+// is started again.
 func (m *Type) Update(ctx context.Context, id string, conf stream.Config) error {
 	m.lock.Lock()
-	_, exists := m.streams[id]
+	wrapper, exists := m.streams[id]
 	closed := m.closed
 	m.lock.Unlock()
 
@@ -196,7 +197,15 @@ func (m *Type) Update(ctx context.Context, id string, conf stream.Config) error
 	if err := m.Delete(ctx, id); err != nil {
 		return err
 	}
-	return m.Create(id, conf)
+	if err := m.Create(id, conf); err != nil {
+		// The old stream has already been drained, so in order to leave things
+		// as they were we attempt to start it again from its old config.
+		if rErr := m.Create(id, wrapper.Config()); rErr != nil {
+			m.manager.Logger().Error("Failed to restore stream '%v' after a failed update: %v", id, rErr)
+		}
+		return err
+	}
+	return nil
 }
 
 // Delete attempts to stop and remove a stream by its ID. Returns an error if
@@ -113,3 +113,25 @@ func TestTypeBasicClose(t *testing.T) {
 		t.Errorf("Unexpected error: %v != %v", act, exp)
 	}
 }
+
+func TestTypeUpdateFailureRestores(t *testing.T) {
+	ctx, done := context.WithTimeout(context.Background(), time.Second*30)
+	defer done()
+
+	res, err := bmanager.New(bmanager.NewResourceConfig())
+	require.NoError(t, err)
+
+	mgr := New(res)
+	require.NoError(t, mgr.Create("foo", harmlessConf(t)))
+
+	badConf := harmlessConf(t)
+	badConf.Output.Type = "does_not_exist"
+	require.Error(t, mgr.Update(ctx, "foo", badConf))
+
+	info, err := mgr.Read("foo")
+	require.NoError(t, err)
+	require.True(t, info.IsRunning())
+	require.Equal(t, harmlessConf(t), info.Config())
+
+	require.NoError(t, mgr.Stop(ctx))
+}
@@ -5,6 +5,7 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -51,6 +52,15 @@ func (s *Span) SetTag(key, value string) {
 	s.w.SetAttributes(attribute.String(key, value))
 }
 
+// SetError records an error on the span and sets its status to errored.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.w.RecordError(err)
+	s.w.SetStatus(codes.Error, err.Error())
+}
+
 // Finish the span.
 func (s *Span) Finish() {
 	if s == nil {
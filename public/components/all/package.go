@@ -21,6 +21,7 @@ import (
 	_ "github.com/benthosdev/benthos/v4/public/components/discord"
 	_ "github.com/benthosdev/benthos/v4/public/components/elasticsearch"
 	_ "github.com/benthosdev/benthos/v4/public/components/gcp"
+	_ "github.com/benthosdev/benthos/v4/public/components/grpc"
 	_ "github.com/benthosdev/benthos/v4/public/components/hdfs"
 	_ "github.com/benthosdev/benthos/v4/public/components/influxdb"
 	_ "github.com/benthosdev/benthos/v4/public/components/io"
@@ -51,6 +51,13 @@ type BatchBuffer interface {
 	// of the buffer is temporal (a windowing algorithm, etc) it might be
 	// considered correct to simply drop message batches that are not acked.
 	//
+	// The error provided to the acknowledge function is the result of
+	// delivering the batch downstream. A non-nil error indicates that the batch
+	// was rejected, in which case buffers that preserve delivery guarantees
+	// should retain the batch for redelivery rather than delete it. Buffers
+	// that defer calling the acknowledge function given to WriteBatch until
+	// delivery should propagate the error to it so that it reaches the input.
+	//
 	// When the buffer is closed (EndOfInput has been called and no more
 	// messages are available) this method should return an ErrEndOfBuffer in
 	// order to indicate the end of the buffered stream.
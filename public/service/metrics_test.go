@@ -216,6 +216,7 @@ logger:
 		"counter:output_connection_up:[label path]:[foooutput root.output]":            1,
 		"counter:output_sent:[label path]:[foooutput root.output]":                     2,
 		"gauge:customthing:[label path topic]:[ root.pipeline.processors.0 testtopic]": 1234,
+		"gauge:output_in_flight:[label path]:[foooutput root.output]":                  0,
 	}, testMetrics.values)
 	testMetrics.lock.Unlock()
 }
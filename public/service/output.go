@@ -288,7 +288,7 @@ func (o *OwnedOutput) XUnwrapper() any {
 //------------------------------------------------------------------------------
 
 var docsAsync = `
-This output benefits from sending multiple messages in flight in parallel for improved performance. You can tune the max number of in flight messages (or message batches) with the field ` + "`max_in_flight`" + `.`
+This output benefits from sending multiple messages in flight in parallel for improved performance. You can tune the max number of in flight messages (or message batches) with the field ` + "`max_in_flight`" + `. When more than one message is in flight the ordering of delivery is best-effort, and so this field should be set to ` + "`1`" + ` when strict ordering is required.`
 
 var docsBatches = `
 This output benefits from sending messages as a batch for improved performance. Batches can be formed at both the input and output level. You can find out more [in this doc](/docs/configuration/batching).`